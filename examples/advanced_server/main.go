@@ -127,11 +127,23 @@ func registerAdvancedTools(adapter *gosdk.GoSDKAdapter, logger *logging.Logger)
 		delay, _ := params["delay"].(float64)
 		message, _ := params["message"].(string)
 
-		// Simulate delay
-		select {
-		case <-time.After(time.Duration(delay) * time.Second):
-		case <-ctx.Done():
-			return nil, ctx.Err()
+		// Simulate delay, reporting progress each second to clients that
+		// asked for it
+		progress := gosdk.ProgressFromContext(ctx)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		start := time.Now()
+		done := time.After(time.Duration(delay) * time.Second)
+	wait:
+		for {
+			select {
+			case <-ticker.C:
+				_ = progress.Report(time.Since(start).Seconds(), delay, "waiting")
+			case <-done:
+				break wait
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
 		}
 
 		logger.Infof("Delayed tool completed after %v seconds", delay)
@@ -0,0 +1,191 @@
+// Package basicserver holds the tools, prompts and resources of the
+// basic_server example, so the example binary can register them and tests
+// can exercise them in-process (see NewInProcessClient).
+package basicserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/client"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework/adapters/gosdk"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Server identity used by the example
+const (
+	Name    = "example-server"
+	Version = "1.0.0"
+)
+
+// Register registers all of the example's tools, prompts and resources
+func Register(server framework.MCPServer) error {
+	if err := RegisterTools(server); err != nil {
+		return fmt.Errorf("failed to register tools: %w", err)
+	}
+	if err := RegisterPrompts(server); err != nil {
+		return fmt.Errorf("failed to register prompts: %w", err)
+	}
+	if err := RegisterResources(server); err != nil {
+		return fmt.Errorf("failed to register resources: %w", err)
+	}
+	return nil
+}
+
+// NewInProcessClient runs the example server in-process and returns a client
+// connected to it over in-memory pipes, so tests need no server binary.
+// The client still has to be initialized; closing it stops the server.
+func NewInProcessClient(ctx context.Context) (*client.Client, error) {
+	adapter := gosdk.NewGoSDKAdapter(Name, Version)
+	if err := Register(adapter); err != nil {
+		return nil, err
+	}
+
+	clientToServerR, clientToServerW := io.Pipe()
+	serverToClientR, serverToClientW := io.Pipe()
+	transport := &mcp.IOTransport{Reader: clientToServerR, Writer: serverToClientW}
+	if _, err := adapter.Server().Connect(ctx, transport, nil); err != nil {
+		return nil, fmt.Errorf("failed to start in-process server: %w", err)
+	}
+
+	return client.NewClientWithIO(serverToClientR, clientToServerW, protocol.ClientInfo{
+		Name:    "basic-server-fixture",
+		Version: Version,
+	})
+}
+
+// RegisterTools registers the echo and math tools
+func RegisterTools(server framework.MCPServer) error {
+	// Register a simple echo tool
+	echoSchema := types.ToolSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"message": map[string]interface{}{
+				"type":        "string",
+				"description": "Message to echo",
+			},
+		},
+		Required: []string{"message"},
+	}
+
+	echoHandler := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		var params map[string]interface{}
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, fmt.Errorf("failed to parse arguments: %w", err)
+		}
+
+		message, ok := params["message"].(string)
+		if !ok {
+			return nil, fmt.Errorf("message parameter is required")
+		}
+
+		return []types.TextContent{
+			{Type: "text", Text: fmt.Sprintf("Echo: %s", message)},
+		}, nil
+	}
+
+	if err := server.RegisterTool("echo", "Echo a message back", echoSchema, echoHandler); err != nil {
+		return fmt.Errorf("failed to register echo tool: %w", err)
+	}
+
+	// Register a math tool
+	mathSchema := types.ToolSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"operation": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"add", "subtract", "multiply", "divide"},
+				"description": "Math operation to perform",
+			},
+			"a": map[string]interface{}{
+				"type":        "number",
+				"description": "First number",
+			},
+			"b": map[string]interface{}{
+				"type":        "number",
+				"description": "Second number",
+			},
+		},
+		Required: []string{"operation", "a", "b"},
+	}
+
+	mathHandler := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		var params map[string]interface{}
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, fmt.Errorf("failed to parse arguments: %w", err)
+		}
+
+		operation, _ := params["operation"].(string)
+		a, _ := params["a"].(float64)
+		b, _ := params["b"].(float64)
+
+		var result float64
+		switch operation {
+		case "add":
+			result = a + b
+		case "subtract":
+			result = a - b
+		case "multiply":
+			result = a * b
+		case "divide":
+			if b == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			result = a / b
+		default:
+			return nil, fmt.Errorf("unknown operation: %s", operation)
+		}
+
+		return []types.TextContent{
+			{Type: "text", Text: fmt.Sprintf("%.2f %s %.2f = %.2f", a, operation, b, result)},
+		}, nil
+	}
+
+	if err := server.RegisterTool("math", "Perform math operations", mathSchema, mathHandler); err != nil {
+		return fmt.Errorf("failed to register math tool: %w", err)
+	}
+
+	return nil
+}
+
+// RegisterPrompts registers the greeting prompt
+func RegisterPrompts(server framework.MCPServer) error {
+	greetingHandler := func(ctx context.Context, args map[string]interface{}) (string, error) {
+		name, _ := args["name"].(string)
+		if name == "" {
+			name = "World"
+		}
+		return fmt.Sprintf("Hello, %s! Welcome to the MCP server.", name), nil
+	}
+
+	if err := server.RegisterPrompt("greeting", "Generate a greeting", greetingHandler); err != nil {
+		return fmt.Errorf("failed to register greeting prompt: %w", err)
+	}
+
+	return nil
+}
+
+// RegisterResources registers the example://info resource
+func RegisterResources(server framework.MCPServer) error {
+	infoHandler := func(ctx context.Context, uri string) ([]byte, string, error) {
+		data := fmt.Sprintf("Resource URI: %s\nServer: %s v%s", uri, Name, Version)
+		return []byte(data), "text/plain", nil
+	}
+
+	if err := server.RegisterResource(
+		"example://info",
+		"Server Information",
+		"Information about the example server",
+		"text/plain",
+		infoHandler,
+	); err != nil {
+		return fmt.Errorf("failed to register info resource: %w", err)
+	}
+
+	return nil
+}
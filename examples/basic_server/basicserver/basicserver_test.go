@@ -0,0 +1,80 @@
+package basicserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/client"
+)
+
+// startClient runs the example server in-process and returns an initialized
+// client for it, closed when the test ends
+func startClient(t *testing.T) (context.Context, *client.Client) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	c, err := NewInProcessClient(ctx)
+	if err != nil {
+		t.Fatalf("NewInProcessClient() error = %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	result, err := c.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if result.ServerInfo.Name != Name {
+		t.Errorf("ServerInfo.Name = %q, want %q", result.ServerInfo.Name, Name)
+	}
+	return ctx, c
+}
+
+func TestEchoTool(t *testing.T) {
+	ctx, c := startClient(t)
+
+	result, err := client.TestToolExecution(ctx, c, "echo", map[string]interface{}{"message": "Hello, World!"})
+	if err != nil {
+		t.Fatalf("echo error = %v", err)
+	}
+	if len(result) != 1 || result[0].Text != "Echo: Hello, World!" {
+		t.Errorf("echo result = %+v, want a single %q", result, "Echo: Hello, World!")
+	}
+}
+
+func TestMathTool(t *testing.T) {
+	ctx, c := startClient(t)
+
+	tests := []struct {
+		operation string
+		a, b      float64
+		want      string
+	}{
+		{"add", 10, 20, "10.00 add 20.00 = 30.00"},
+		{"subtract", 10, 20, "10.00 subtract 20.00 = -10.00"},
+		{"multiply", 3, 4, "3.00 multiply 4.00 = 12.00"},
+		{"divide", 1, 4, "1.00 divide 4.00 = 0.25"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			args := map[string]interface{}{"operation": tt.operation, "a": tt.a, "b": tt.b}
+			result, err := client.TestToolExecution(ctx, c, "math", args)
+			if err != nil {
+				t.Fatalf("math error = %v", err)
+			}
+			if len(result) != 1 || result[0].Text != tt.want {
+				t.Errorf("math result = %+v, want a single %q", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestMathToolDivisionByZero(t *testing.T) {
+	ctx, c := startClient(t)
+
+	args := map[string]interface{}{"operation": "divide", "a": 1, "b": 0}
+	if _, err := c.CallTool(ctx, "math", args); err == nil {
+		t.Fatal("math divide by zero succeeded, want an error")
+	}
+}
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/request"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/response"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Flags understood by CallTool
+const (
+	// FlagArgs holds the tool arguments as a JSON object (default "{}")
+	FlagArgs = "args"
+	// FlagLenient accepts comments and trailing commas in FlagArgs
+	FlagLenient = "lenient"
+	// FlagOutput writes the tool result to a file (as JSON via
+	// response.FormatResult) instead of printing it
+	FlagOutput = "output"
+	// FlagArgsFormat selects how FlagArgs is parsed: "json" (default) or "yaml"
+	FlagArgsFormat = "args-format"
+)
+
+// Argument formats accepted by FlagArgsFormat
+const (
+	ArgsFormatJSON = "json"
+	ArgsFormatYAML = "yaml"
+)
+
+// ToolArguments returns the tool arguments given on the command line as a
+// JSON object. With --args-format yaml, FlagArgs is parsed as a YAML mapping
+// instead. With --lenient, JSON comments and trailing commas are accepted
+// (see request.NormalizeLenientJSON).
+func ToolArguments(args *Args) (json.RawMessage, error) {
+	raw := args.GetFlag(FlagArgs, "{}")
+
+	var toolArgs map[string]interface{}
+	switch format := args.GetFlag(FlagArgsFormat, ArgsFormatJSON); format {
+	case ArgsFormatJSON:
+		var data json.RawMessage = []byte(raw)
+		if args.GetBoolFlag(FlagLenient, false) {
+			normalized, err := request.NormalizeLenientJSON(data)
+			if err != nil {
+				return nil, usageErrorf("invalid JSON arguments: %w", err)
+			}
+			data = normalized
+		}
+		if err := json.Unmarshal(data, &toolArgs); err != nil {
+			return nil, usageErrorf("invalid JSON arguments: %w", err)
+		}
+	case ArgsFormatYAML:
+		if err := yaml.Unmarshal([]byte(raw), &toolArgs); err != nil {
+			return nil, usageErrorf("invalid YAML arguments: %w", err)
+		}
+		if toolArgs == nil {
+			toolArgs = map[string]interface{}{} // empty document
+		}
+	default:
+		return nil, usageErrorf("unsupported --%s %q (want %s or %s)", FlagArgsFormat, format, ArgsFormatJSON, ArgsFormatYAML)
+	}
+
+	data, err := json.Marshal(toolArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode arguments: %w", err)
+	}
+	return data, nil
+}
+
+// CallTool runs a tool from the command line and writes its text results to
+// stdout, one per line. The tool name is the subcommand ("call <tool>") and
+// its arguments come from ToolArguments.
+//
+// With --output <path>, the result is written to path as JSON (see
+// response.FormatResult) and a confirmation is printed to stderr instead.
+// With --json, stdout receives {"tool": ..., "content": [...]} (or
+// {"tool": ..., "output_path": ...} with --output).
+//
+// Example:
+//
+//	// example-server call echo --args '{"message": "hi"}'
+//	args := cli.ParseArgs(os.Args[1:])
+//	if args.Command == "call" {
+//		return cli.CallTool(ctx, server, args, os.Stdout, os.Stderr)
+//	}
+func CallTool(ctx context.Context, server framework.MCPServer, args *Args, stdout, stderr io.Writer) error {
+	toolName := args.Subcommand
+	if toolName == "" {
+		return usageErrorf("tool name required")
+	}
+
+	toolArgs, err := ToolArguments(args)
+	if err != nil {
+		return err
+	}
+
+	result, err := server.CallTool(ctx, toolName, toolArgs)
+	if err != nil {
+		return fmt.Errorf("tool execution failed: %w", err)
+	}
+
+	if outputPath := args.GetFlag(FlagOutput, ""); outputPath != "" {
+		if err := writeResultFile(toolName, result, outputPath); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(stderr, "Wrote result of %s to %s\n", toolName, outputPath); err != nil {
+			return err
+		}
+		if JSONOutput(args) {
+			return writeJSON(stdout, map[string]interface{}{"tool": toolName, "output_path": outputPath})
+		}
+		return nil
+	}
+
+	if JSONOutput(args) {
+		if result == nil {
+			result = []types.TextContent{}
+		}
+		return writeJSON(stdout, map[string]interface{}{"tool": toolName, "content": result})
+	}
+	for _, content := range result {
+		if _, err := fmt.Fprintln(stdout, content.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeResultFile writes a tool result to outputPath with
+// response.FormatResult
+func writeResultFile(toolName string, result []types.TextContent, outputPath string) error {
+	resultMap := resultToMap(toolName, result)
+	if _, err := response.FormatResult(resultMap, outputPath); err != nil {
+		return err
+	}
+	// FormatResult only sets output_path once the file is written
+	if _, ok := resultMap["output_path"]; !ok {
+		return fmt.Errorf("failed to write result to %s", outputPath)
+	}
+	return nil
+}
+
+// resultToMap converts a tool result for response.FormatResult. A single
+// JSON object result is used as-is; anything else is wrapped as
+// {"tool": name, "content": [text, ...]}.
+func resultToMap(toolName string, result []types.TextContent) map[string]interface{} {
+	if len(result) == 1 {
+		var object map[string]interface{}
+		if json.Unmarshal([]byte(result[0].Text), &object) == nil && object != nil {
+			return object
+		}
+	}
+	texts := make([]string, len(result))
+	for i, content := range result {
+		texts[i] = content.Text
+	}
+	return map[string]interface{}{"tool": toolName, "content": texts}
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// fakeServer is a framework.MCPServer with a fixed set of tools that
+// records the arguments of the last call
+type fakeServer struct {
+	framework.MCPServer
+	tools    map[string]framework.ToolHandler
+	lastArgs json.RawMessage
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{tools: map[string]framework.ToolHandler{
+		"echo": func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+			return []types.TextContent{{Type: "text", Text: string(args)}}, nil
+		},
+		"fail": func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+			return nil, fmt.Errorf("boom")
+		},
+	}}
+}
+
+func (s *fakeServer) CallTool(ctx context.Context, name string, args json.RawMessage) ([]types.TextContent, error) {
+	s.lastArgs = args
+	handler, ok := s.tools[name]
+	if !ok {
+		return nil, &framework.ErrToolNotFound{ToolName: name}
+	}
+	return handler(ctx, args)
+}
+
+func (s *fakeServer) ListTools() []types.ToolInfo {
+	return []types.ToolInfo{
+		{Name: "echo", Description: "Echo arguments"},
+		{Name: "fail", Description: "Always fails"},
+	}
+}
+
+func TestCallTool(t *testing.T) {
+	tests := []struct {
+		name    string
+		argv    []string
+		want    string
+		wantErr string
+	}{
+		{name: "default arguments", argv: []string{"call", "echo"}, want: "{}\n"},
+		{name: "JSON arguments", argv: []string{"call", "echo", "--args", `{"a": 1}`}, want: "{\"a\":1}\n"},
+		{
+			name: "lenient arguments",
+			argv: []string{"call", "echo", "--lenient", "--args", "{\n  // count\n  \"a\": 1,\n}"},
+			want: "{\"a\":1}\n",
+		},
+		{
+			name: "YAML arguments",
+			argv: []string{"call", "echo", "--args-format", "yaml", "--args", "path: a.txt\ntags:\n  - x\n  - y\nopts:\n  limit: 5\n"},
+			want: "{\"opts\":{\"limit\":5},\"path\":\"a.txt\",\"tags\":[\"x\",\"y\"]}\n",
+		},
+		{name: "empty YAML", argv: []string{"call", "echo", "--args-format=yaml", "--args="}, want: "{}\n"},
+		{name: "YAML not a mapping", argv: []string{"call", "echo", "--args-format", "yaml", "--args", "- a"}, wantErr: "invalid YAML arguments"},
+		{name: "unknown format", argv: []string{"call", "echo", "--args-format", "toml"}, wantErr: "unsupported --args-format"},
+		{name: "trailing comma without lenient", argv: []string{"call", "echo", "--args", `{"a": 1,}`}, wantErr: "invalid JSON arguments"},
+		{name: "no tool name", argv: []string{"call"}, wantErr: "tool name required"},
+		{name: "unknown tool", argv: []string{"call", "missing"}, wantErr: "not found"},
+		{name: "tool error", argv: []string{"call", "fail"}, wantErr: "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := CallTool(context.Background(), newFakeServer(), ParseArgs(tt.argv), &out, io.Discard)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("CallTool() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CallTool() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("CallTool() output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCallTool_OutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.json")
+
+	var out, errOut bytes.Buffer
+	argv := []string{"call", "echo", "--args", `{"a":1}`, "--output", path}
+	if err := CallTool(context.Background(), newFakeServer(), ParseArgs(argv), &out, &errOut); err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("CallTool() stdout = %q, want empty", out.String())
+	}
+	if errOut.String() != "Wrote result of echo to "+path+"\n" {
+		t.Errorf("CallTool() stderr = %q, want confirmation", errOut.String())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("output file is not JSON: %v", err)
+	}
+	if want := map[string]interface{}{"a": float64(1)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("output file = %v, want %v", got, want)
+	}
+}
+
+func TestCallTool_OutputFileTextResult(t *testing.T) {
+	server := newFakeServer()
+	server.tools["greet"] = func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		return []types.TextContent{{Type: "text", Text: "hello"}, {Type: "text", Text: "world"}}, nil
+	}
+	path := filepath.Join(t.TempDir(), "greet.json")
+
+	var out bytes.Buffer
+	if err := CallTool(context.Background(), server, ParseArgs([]string{"call", "greet", "--output", path}), &out, io.Discard); err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("output file is not JSON: %v", err)
+	}
+	want := map[string]interface{}{"tool": "greet", "content": []interface{}{"hello", "world"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("output file = %v, want %v", got, want)
+	}
+
+	badPath := filepath.Join(t.TempDir(), "missing", "out.json")
+	if err := CallTool(context.Background(), server, ParseArgs([]string{"call", "greet", "--output", badPath}), &out, io.Discard); err == nil {
+		t.Error("CallTool() with unwritable output error = nil, want error")
+	}
+}
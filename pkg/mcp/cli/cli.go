@@ -17,12 +17,23 @@ package cli
 
 import (
 	"os"
+	"strings"
 
 	"golang.org/x/term"
 )
 
+// Environment variables that force the execution mode reported by IsTTY
+const (
+	// EnvForceCLI makes IsTTY report true (CLI mode) when set to "1" or "true"
+	EnvForceCLI = "MCP_FORCE_CLI"
+	// EnvForceMCP makes IsTTY report false (MCP server mode) when set to "1"
+	// or "true". It takes precedence over EnvForceCLI.
+	EnvForceMCP = "MCP_FORCE_MCP"
+)
+
 // IsTTY checks if the program is running in a terminal (TTY).
 // Returns true if stdin is connected to a terminal, false otherwise.
+// MCP_FORCE_MCP and MCP_FORCE_CLI override the detection.
 //
 // This is useful for detecting if the program should run in CLI mode
 // (when TTY is detected) or MCP server mode (when running via stdio).
@@ -37,11 +48,18 @@ import (
 //		runMCPServer()
 //	}
 func IsTTY() bool {
-	return term.IsTerminal(int(os.Stdin.Fd()))
+	if envFlag(EnvForceMCP) {
+		return false
+	}
+	if envFlag(EnvForceCLI) {
+		return true
+	}
+	return IsTTYFile(os.Stdin)
 }
 
 // IsTTYFile checks if a specific file descriptor is connected to a terminal.
 // This allows checking stdout or stderr instead of stdin.
+// Returns false for a nil or closed file, or if the check fails.
 //
 // Example:
 //
@@ -49,8 +67,29 @@ func IsTTY() bool {
 //		// Can use colored output
 //		fmt.Println("\033[32mSuccess\033[0m")
 //	}
-func IsTTYFile(file *os.File) bool {
-	return term.IsTerminal(int(file.Fd()))
+func IsTTYFile(file *os.File) (isTTY bool) {
+	if file == nil {
+		return false
+	}
+	// Terminal checks go through platform syscalls; never let one take
+	// down the caller just to pick an execution mode
+	defer func() {
+		if recover() != nil {
+			isTTY = false
+		}
+	}()
+
+	fd := file.Fd()
+	if fd == ^uintptr(0) { // closed file
+		return false
+	}
+	return term.IsTerminal(int(fd))
+}
+
+// envFlag reports whether the environment variable is set to "1" or "true"
+func envFlag(name string) bool {
+	value := strings.ToLower(os.Getenv(name))
+	return value == "1" || value == "true"
 }
 
 // ExecutionMode represents the detected execution mode
@@ -63,9 +102,21 @@ const (
 	ModeMCP ExecutionMode = "mcp"
 )
 
-// DetectMode detects the execution mode based on TTY detection.
-// Returns ModeCLI if running in a terminal, ModeMCP otherwise.
+// EnvMode selects the execution mode explicitly: "cli" or "mcp"
+const EnvMode = "MCP_MODE"
+
+// DetectMode detects the execution mode.
+// MCP_MODE=cli or MCP_MODE=mcp (case-insensitive) wins; any other value is
+// ignored. Otherwise it returns ModeCLI if running in a terminal (see IsTTY),
+// ModeMCP otherwise.
 func DetectMode() ExecutionMode {
+	switch ExecutionMode(strings.ToLower(strings.TrimSpace(os.Getenv(EnvMode)))) {
+	case ModeCLI:
+		return ModeCLI
+	case ModeMCP:
+		return ModeMCP
+	}
+
 	if IsTTY() {
 		return ModeCLI
 	}
@@ -99,7 +150,8 @@ func ParseArgs(argv []string) *Args {
 		Positional: make([]string, 0),
 	}
 
-	for i, arg := range argv {
+	for i := 0; i < len(argv); i++ {
+		arg := argv[i]
 		if arg == "" {
 			continue
 		}
@@ -114,7 +166,7 @@ func ParseArgs(argv []string) *Args {
 				args.Flags[key] = value
 			} else {
 				// --flag format (check if next arg is value)
-				if i+1 < len(argv) && argv[i+1][0] != '-' {
+				if i+1 < len(argv) && !isFlag(argv[i+1]) && !booleanFlags[flag] {
 					args.Flags[flag] = argv[i+1]
 					i++ // Skip next arg
 				} else {
@@ -127,7 +179,7 @@ func ParseArgs(argv []string) *Args {
 		// Handle short flags (-f or -f value)
 		if len(arg) > 1 && arg[0] == '-' && arg[1] != '-' {
 			flag := arg[1:]
-			if i+1 < len(argv) && argv[i+1][0] != '-' {
+			if i+1 < len(argv) && !isFlag(argv[i+1]) && !booleanFlags[flag] {
 				args.Flags[flag] = argv[i+1]
 				i++ // Skip next arg
 			} else {
@@ -149,6 +201,23 @@ func ParseArgs(argv []string) *Args {
 	return args
 }
 
+// booleanFlags never take a value from the next argument, so "-v list"
+// is the verbose flag followed by the list command. (They may still be set
+// explicitly with --flag=value.)
+var booleanFlags = map[string]bool{
+	FlagQuiet:   true,
+	"q":         true,
+	FlagVerbose: true,
+	"v":         true,
+	FlagJSON:    true,
+	FlagLenient: true,
+}
+
+// isFlag reports whether arg looks like a flag rather than a flag value
+func isFlag(arg string) bool {
+	return len(arg) > 1 && arg[0] == '-'
+}
+
 // indexByte returns the index of the first occurrence of byte c in s,
 // or -1 if c is not present in s.
 func indexByte(s string, c byte) int {
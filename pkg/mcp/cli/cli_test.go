@@ -22,6 +22,48 @@ func TestIsTTYFile(t *testing.T) {
 	_ = IsTTYFile(os.Stderr)
 }
 
+func TestIsTTY_Override(t *testing.T) {
+	tests := []struct {
+		name     string
+		forceCLI string
+		forceMCP string
+		want     bool
+	}{
+		{name: "force CLI", forceCLI: "1", want: true},
+		{name: "force CLI true", forceCLI: "true", want: true},
+		{name: "force MCP", forceMCP: "1", want: false},
+		{name: "force MCP wins", forceCLI: "1", forceMCP: "TRUE", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvForceCLI, tt.forceCLI)
+			t.Setenv(EnvForceMCP, tt.forceMCP)
+			if got := IsTTY(); got != tt.want {
+				t.Errorf("IsTTY() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTTYFile_NilAndClosed(t *testing.T) {
+	if IsTTYFile(nil) {
+		t.Error("IsTTYFile(nil) = true, want false")
+	}
+
+	file, err := os.CreateTemp(t.TempDir(), "tty")
+	if err != nil {
+		t.Fatalf("CreateTemp() error = %v", err)
+	}
+	if IsTTYFile(file) {
+		t.Error("IsTTYFile(regular file) = true, want false")
+	}
+	file.Close()
+	if IsTTYFile(file) {
+		t.Error("IsTTYFile(closed file) = true, want false")
+	}
+}
+
 func TestDetectMode(t *testing.T) {
 	mode := DetectMode()
 	if mode != ModeCLI && mode != ModeMCP {
@@ -29,6 +71,33 @@ func TestDetectMode(t *testing.T) {
 	}
 }
 
+func TestDetectMode_Override(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		forceCLI string
+		forceMCP string
+		want     ExecutionMode
+	}{
+		{name: "cli over forced MCP TTY", mode: "cli", forceMCP: "1", want: ModeCLI},
+		{name: "mcp over forced CLI TTY", mode: "mcp", forceCLI: "1", want: ModeMCP},
+		{name: "case insensitive", mode: " CLI ", forceMCP: "1", want: ModeCLI},
+		{name: "invalid falls back to TTY", mode: "server", forceCLI: "1", want: ModeCLI},
+		{name: "unset falls back to TTY", forceMCP: "1", want: ModeMCP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvMode, tt.mode)
+			t.Setenv(EnvForceCLI, tt.forceCLI)
+			t.Setenv(EnvForceMCP, tt.forceMCP)
+			if got := DetectMode(); got != tt.want {
+				t.Errorf("DetectMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseArgs_SimpleCommand(t *testing.T) {
 	args := ParseArgs([]string{"tool", "list"})
 
@@ -52,22 +121,22 @@ func TestParseArgs_WithFlags(t *testing.T) {
 	if args.Subcommand != "call" {
 		t.Errorf("args.Subcommand = %q, want %q", args.Subcommand, "call")
 	}
-	if args.GetFlag("name") != "my_tool" {
-		t.Errorf("args.GetFlag(\"name\") = %q, want %q", args.GetFlag("name"), "my_tool")
+	if args.GetFlag("name", "") != "my_tool" {
+		t.Errorf("args.GetFlag(\"name\") = %q, want %q", args.GetFlag("name", ""), "my_tool")
 	}
-	if args.GetFlag("arg") != "value" {
-		t.Errorf("args.GetFlag(\"arg\") = %q, want %q", args.GetFlag("arg"), "value")
+	if args.GetFlag("arg", "") != "value" {
+		t.Errorf("args.GetFlag(\"arg\") = %q, want %q", args.GetFlag("arg", ""), "value")
 	}
 }
 
 func TestParseArgs_FlagEqualsValue(t *testing.T) {
 	args := ParseArgs([]string{"tool", "call", "--name=my_tool", "--arg=value"})
 
-	if args.GetFlag("name") != "my_tool" {
-		t.Errorf("args.GetFlag(\"name\") = %q, want %q", args.GetFlag("name"), "my_tool")
+	if args.GetFlag("name", "") != "my_tool" {
+		t.Errorf("args.GetFlag(\"name\") = %q, want %q", args.GetFlag("name", ""), "my_tool")
 	}
-	if args.GetFlag("arg") != "value" {
-		t.Errorf("args.GetFlag(\"arg\") = %q, want %q", args.GetFlag("arg"), "value")
+	if args.GetFlag("arg", "") != "value" {
+		t.Errorf("args.GetFlag(\"arg\") = %q, want %q", args.GetFlag("arg", ""), "value")
 	}
 }
 
@@ -77,8 +146,8 @@ func TestParseArgs_ShortFlags(t *testing.T) {
 	if !args.HasFlag("v") {
 		t.Error("args.HasFlag(\"v\") = false, want true")
 	}
-	if args.GetFlag("f") != "file.txt" {
-		t.Errorf("args.GetFlag(\"f\") = %q, want %q", args.GetFlag("f"), "file.txt")
+	if args.GetFlag("f", "") != "file.txt" {
+		t.Errorf("args.GetFlag(\"f\") = %q, want %q", args.GetFlag("f", ""), "file.txt")
 	}
 }
 
@@ -94,8 +163,8 @@ func TestParseArgs_BooleanFlags(t *testing.T) {
 	if args.GetBoolFlag("nonexistent", false) {
 		t.Error("args.GetBoolFlag(\"nonexistent\", false) = true, want false")
 	}
-	if args.GetBoolFlag("nonexistent", true) {
-		t.Error("args.GetBoolFlag(\"nonexistent\", true) = true, want true")
+	if !args.GetBoolFlag("nonexistent", true) {
+		t.Error("args.GetBoolFlag(\"nonexistent\", true) = false, want true")
 	}
 }
 
@@ -119,6 +188,42 @@ func TestParseArgs_PositionalArgs(t *testing.T) {
 	}
 }
 
+func TestParseArgs_FlagValuesNotPositional(t *testing.T) {
+	args := ParseArgs([]string{"call", "echo", "--args", `{"a":1}`, "-o", "out.txt", "extra"})
+
+	if args.GetFlag("args", "") != `{"a":1}` {
+		t.Errorf("args.GetFlag(\"args\", \"\") = %q, want %q", args.GetFlag("args", ""), `{"a":1}`)
+	}
+	if args.GetFlag("o", "") != "out.txt" {
+		t.Errorf("args.GetFlag(\"o\", \"\") = %q, want %q", args.GetFlag("o", ""), "out.txt")
+	}
+	if len(args.Positional) != 1 || args.Positional[0] != "extra" {
+		t.Errorf("args.Positional = %v, want [extra]", args.Positional)
+	}
+}
+
+func TestParseArgs_JSONAndLenientTakeNoValue(t *testing.T) {
+	tests := []struct {
+		argv           []string
+		wantCommand    string
+		wantSubcommand string
+		wantFlag       string
+	}{
+		{argv: []string{"--json", "list"}, wantCommand: "list", wantFlag: FlagJSON},
+		{argv: []string{"call", "--lenient", "echo"}, wantCommand: "call", wantSubcommand: "echo", wantFlag: FlagLenient},
+	}
+
+	for _, tt := range tests {
+		args := ParseArgs(tt.argv)
+		if args.Command != tt.wantCommand || args.Subcommand != tt.wantSubcommand {
+			t.Errorf("ParseArgs(%q) = %q %q, want %q %q", tt.argv, args.Command, args.Subcommand, tt.wantCommand, tt.wantSubcommand)
+		}
+		if !args.GetBoolFlag(tt.wantFlag, false) {
+			t.Errorf("ParseArgs(%q) flag %q = %q, want true", tt.argv, tt.wantFlag, args.GetFlag(tt.wantFlag, ""))
+		}
+	}
+}
+
 func TestParseArgs_Empty(t *testing.T) {
 	args := ParseArgs([]string{})
 
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// FlagJSON makes commands write machine-readable JSON to stdout.
+// Human-oriented messages go to stderr.
+const FlagJSON = "json"
+
+// JSONOutput reports whether --json was given
+func JSONOutput(args *Args) bool {
+	return args.GetBoolFlag(FlagJSON, false)
+}
+
+// toolJSON is the --json representation of a tool
+type toolJSON struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema types.ToolSchema       `json:"inputSchema"`
+	Annotations *types.ToolAnnotations `json:"annotations,omitempty"`
+}
+
+func newToolJSON(info types.ToolInfo) toolJSON {
+	return toolJSON{
+		Name:        info.Name,
+		Description: info.Description,
+		InputSchema: info.Schema,
+		Annotations: info.Annotations,
+	}
+}
+
+// ListTools writes the server's tools to stdout, or a JSON array of tools
+// with --json
+func ListTools(server framework.MCPServer, args *Args, stdout, stderr io.Writer) error {
+	tools := server.ListTools()
+
+	if JSONOutput(args) {
+		list := make([]toolJSON, 0, len(tools))
+		for _, tool := range tools {
+			list = append(list, newToolJSON(tool))
+		}
+		return writeJSON(stdout, list)
+	}
+
+	if _, err := fmt.Fprintf(stdout, "Available tools (%d):\n\n", len(tools)); err != nil {
+		return err
+	}
+	for _, tool := range tools {
+		if _, err := fmt.Fprintf(stdout, "  %s - %s\n", tool.Name, tool.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DescribeTool writes the description and input schema of the tool named by
+// the subcommand ("describe <tool>"), or a JSON object with --json
+func DescribeTool(server framework.MCPServer, args *Args, stdout, stderr io.Writer) error {
+	toolName := args.Subcommand
+	if toolName == "" {
+		return usageErrorf("tool name required")
+	}
+
+	for _, tool := range server.ListTools() {
+		if tool.Name != toolName {
+			continue
+		}
+		if JSONOutput(args) {
+			return writeJSON(stdout, newToolJSON(tool))
+		}
+		schema, err := json.MarshalIndent(tool.Schema, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode schema: %w", err)
+		}
+		_, err = fmt.Fprintf(stdout, "%s\n\n%s\n\nInput schema:\n%s\n", tool.Name, tool.Description, schema)
+		return err
+	}
+	return &framework.ErrToolNotFound{ToolName: toolName}
+}
+
+// writeJSON writes v to w as indented JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
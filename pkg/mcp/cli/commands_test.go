@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+)
+
+func TestListTools(t *testing.T) {
+	var out bytes.Buffer
+	if err := ListTools(newFakeServer(), ParseArgs([]string{"list"}), &out, io.Discard); err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "echo - Echo arguments") {
+		t.Errorf("ListTools() output = %q, want human-readable list", out.String())
+	}
+}
+
+func TestListTools_JSON(t *testing.T) {
+	var out bytes.Buffer
+	if err := ListTools(newFakeServer(), ParseArgs([]string{"list", "--json"}), &out, io.Discard); err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+
+	var tools []map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &tools); err != nil {
+		t.Fatalf("list --json output is not a JSON array: %v\n%s", err, out.String())
+	}
+	if len(tools) != 2 || tools[0]["name"] != "echo" || tools[1]["description"] != "Always fails" {
+		t.Errorf("list --json = %v, want echo and fail tools", tools)
+	}
+	if _, ok := tools[0]["inputSchema"]; !ok {
+		t.Errorf("list --json tool = %v, want inputSchema", tools[0])
+	}
+}
+
+func TestDescribeTool(t *testing.T) {
+	var out bytes.Buffer
+	if err := DescribeTool(newFakeServer(), ParseArgs([]string{"describe", "echo", "--json"}), &out, io.Discard); err != nil {
+		t.Fatalf("DescribeTool() error = %v", err)
+	}
+	var tool map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &tool); err != nil || tool["name"] != "echo" {
+		t.Errorf("describe --json = %s (%v), want echo tool object", out.String(), err)
+	}
+
+	out.Reset()
+	if err := DescribeTool(newFakeServer(), ParseArgs([]string{"describe", "echo"}), &out, io.Discard); err != nil {
+		t.Fatalf("DescribeTool() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Input schema:") {
+		t.Errorf("describe output = %q, want schema", out.String())
+	}
+
+	err := DescribeTool(newFakeServer(), ParseArgs([]string{"describe", "missing"}), &out, io.Discard)
+	if !framework.IsToolNotFound(err) {
+		t.Errorf("DescribeTool(missing) error = %v, want tool not found", err)
+	}
+}
+
+func TestCallTool_JSON(t *testing.T) {
+	var out, errOut bytes.Buffer
+	argv := []string{"call", "echo", "--json", "--args", `{"a":1}`}
+	if err := CallTool(context.Background(), newFakeServer(), ParseArgs(argv), &out, &errOut); err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+
+	var got struct {
+		Tool    string `json:"tool"`
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("call --json output is not JSON: %v\n%s", err, out.String())
+	}
+	if got.Tool != "echo" || len(got.Content) != 1 || got.Content[0].Text != `{"a":1}` {
+		t.Errorf("call --json = %+v, want echo result", got)
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("stderr = %q, want empty", errOut.String())
+	}
+}
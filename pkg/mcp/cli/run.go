@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+)
+
+// Exit codes returned by Run
+const (
+	// ExitSuccess means the command completed
+	ExitSuccess = 0
+	// ExitToolError means the tool (or command) failed
+	ExitToolError = 1
+	// ExitUsageError means the command line was invalid
+	// (unknown command or tool, bad flags or arguments)
+	ExitUsageError = 2
+	// ExitConnectionError means the server could not be reached
+	ExitConnectionError = 3
+)
+
+// UsageError reports an invalid command line
+type UsageError struct {
+	Err error
+}
+
+func (e *UsageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// usageErrorf returns a UsageError with a formatted message
+func usageErrorf(format string, args ...interface{}) error {
+	return &UsageError{Err: fmt.Errorf(format, args...)}
+}
+
+// ConnectionError reports that the server could not be reached.
+// Wrap transport and dial errors in it so Run exits with ExitConnectionError.
+type ConnectionError struct {
+	Err error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("connection error: %v", e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode maps a command error to an exit code:
+//   - nil: ExitSuccess
+//   - UsageError or framework.ErrToolNotFound: ExitUsageError
+//   - ConnectionError, network errors, or a refused/reset connection: ExitConnectionError
+//   - anything else: ExitToolError
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+
+	var usageErr *UsageError
+	var notFound *framework.ErrToolNotFound
+	if errors.As(err, &usageErr) || errors.As(err, &notFound) {
+		return ExitUsageError
+	}
+
+	var connErr *ConnectionError
+	var opErr *net.OpError
+	if errors.As(err, &connErr) || errors.As(err, &opErr) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, net.ErrClosed) {
+		return ExitConnectionError
+	}
+
+	return ExitToolError
+}
+
+// Run dispatches a CLI command line against server and returns the process
+// exit code (see ExitCode). Errors are written to stderr; usage errors are
+// followed by a summary of the commands.
+//
+// Commands:
+//
+//	list [--json]
+//	describe <tool> [--json]
+//	call <tool> [--args <json|yaml>] [--args-format yaml] [--lenient] [--output <file>] [--json]
+//
+// -v/--verbose and -q/--quiet set the level of the server's logger (see
+// ConfigureLogging).
+//
+// Example:
+//
+//	func main() {
+//		// ... create server and register tools ...
+//		os.Exit(cli.Run(ctx, server, os.Args[1:], os.Stdout, os.Stderr))
+//	}
+func Run(ctx context.Context, server framework.MCPServer, argv []string, stdout, stderr io.Writer) int {
+	args := ParseArgs(argv)
+
+	// Apply -v/-q before running the command so its logging honors them
+	err := ConfigureLogging(serverLogger(server), args)
+	if err == nil {
+		switch args.Command {
+		case "list":
+			err = ListTools(server, args, stdout, stderr)
+		case "describe":
+			err = DescribeTool(server, args, stdout, stderr)
+		case "call":
+			err = CallTool(ctx, server, args, stdout, stderr)
+		case "", "help":
+			if args.Command == "" && !args.HasFlag("help") {
+				err = usageErrorf("command required")
+				break
+			}
+			writeUsage(stdout)
+			return ExitSuccess
+		default:
+			err = usageErrorf("unknown command %q", args.Command)
+		}
+	}
+
+	code := ExitCode(err)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		if code == ExitUsageError {
+			writeUsage(stderr)
+		}
+	}
+	return code
+}
+
+// writeUsage writes a summary of the commands accepted by Run
+func writeUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  list [--json]                  List all tools
+  describe <tool> [--json]       Show a tool's description and input schema
+  call <tool> [flags]            Call a tool
+      --args <json|yaml>         Tool arguments (default {})
+      --args-format json|yaml    Format of --args (default json)
+      --lenient                  Allow comments and trailing commas in JSON
+      --output <file>            Write the result to a file
+      --json                     Machine-readable output
+
+Global flags:
+  -v, --verbose                  Log debug messages
+  -q, --quiet                    Log errors only
+`)
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+func TestRun_ExitCodes(t *testing.T) {
+	server := newFakeServer()
+	server.tools["offline"] = func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	}
+
+	tests := []struct {
+		name      string
+		argv      []string
+		want      int
+		wantUsage bool
+	}{
+		{name: "list", argv: []string{"list"}, want: ExitSuccess},
+		{name: "call", argv: []string{"call", "echo", "--args", `{"a":1}`}, want: ExitSuccess},
+		{name: "help", argv: []string{"help"}, want: ExitSuccess},
+		{name: "tool error", argv: []string{"call", "fail"}, want: ExitToolError},
+		{name: "no command", argv: nil, want: ExitUsageError, wantUsage: true},
+		{name: "unknown command", argv: []string{"frobnicate"}, want: ExitUsageError, wantUsage: true},
+		{name: "missing tool name", argv: []string{"call"}, want: ExitUsageError, wantUsage: true},
+		{name: "unknown tool", argv: []string{"call", "missing"}, want: ExitUsageError, wantUsage: true},
+		{name: "invalid arguments", argv: []string{"call", "echo", "--args", "{"}, want: ExitUsageError, wantUsage: true},
+		{name: "connection error", argv: []string{"call", "offline"}, want: ExitConnectionError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			if got := Run(context.Background(), server, tt.argv, &out, &errOut); got != tt.want {
+				t.Errorf("Run() = %d, want %d (stderr: %s)", got, tt.want, errOut.String())
+			}
+			if tt.want != ExitSuccess && !strings.HasPrefix(errOut.String(), "Error: ") {
+				t.Errorf("stderr = %q, want error message", errOut.String())
+			}
+			if hasUsage := strings.Contains(errOut.String(), "Usage:"); hasUsage != tt.wantUsage {
+				t.Errorf("stderr contains usage = %v, want %v", hasUsage, tt.wantUsage)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: ExitSuccess},
+		{name: "plain error", err: fmt.Errorf("boom"), want: ExitToolError},
+		{name: "wrapped usage error", err: fmt.Errorf("ctx: %w", usageErrorf("bad flag")), want: ExitUsageError},
+		{name: "connection error", err: &ConnectionError{Err: fmt.Errorf("EOF")}, want: ExitConnectionError},
+		{name: "connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), want: ExitConnectionError},
+		{name: "closed connection", err: net.ErrClosed, want: ExitConnectionError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+)
+
+// Flags controlling log verbosity for a CLI invocation
+const (
+	// FlagQuiet (or -q) only logs errors
+	FlagQuiet = "quiet"
+	// FlagVerbose (or -v) logs everything, including debug messages
+	FlagVerbose = "verbose"
+)
+
+// ConfigureLogging applies --quiet (ERROR) or --verbose (DEBUG) to logger.
+// Without either flag the logger's level is left as configured by the
+// environment (see logging.NewLogger). Passing both is a usage error. A nil
+// logger only has the flags validated.
+//
+// Run calls it with the server's logger, for servers that have one.
+//
+// Example:
+//
+//	args := cli.ParseArgs(os.Args[1:])
+//	logger := logging.NewLogger()
+//	if err := cli.ConfigureLogging(logger, args); err != nil {
+//		return err
+//	}
+func ConfigureLogging(logger *logging.Logger, args *Args) error {
+	quiet := args.GetBoolFlag(FlagQuiet, false) || args.GetBoolFlag("q", false)
+	verbose := args.GetBoolFlag(FlagVerbose, false) || args.GetBoolFlag("v", false)
+
+	switch {
+	case quiet && verbose:
+		return usageErrorf("--%s and --%s cannot be used together", FlagQuiet, FlagVerbose)
+	case logger == nil:
+	case quiet:
+		logger.SetLevel(logging.LevelError)
+	case verbose:
+		logger.SetLevel(logging.LevelDebug)
+	}
+	return nil
+}
+
+// loggerProvider is implemented by servers that expose their logger, such as
+// the go-sdk adapter
+type loggerProvider interface {
+	Logger() *logging.Logger
+}
+
+// serverLogger returns server's logger, or nil if it does not expose one
+func serverLogger(server framework.MCPServer) *logging.Logger {
+	if provider, ok := server.(loggerProvider); ok {
+		return provider.Logger()
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+)
+
+func TestConfigureLogging(t *testing.T) {
+	tests := []struct {
+		name    string
+		argv    []string
+		want    logging.LogLevel
+		wantErr bool
+	}{
+		{name: "default", argv: []string{"list"}, want: logging.LevelInfo},
+		{name: "quiet", argv: []string{"list", "--quiet"}, want: logging.LevelError},
+		{name: "short quiet", argv: []string{"list", "-q"}, want: logging.LevelError},
+		{name: "verbose", argv: []string{"list", "--verbose"}, want: logging.LevelDebug},
+		{name: "short verbose", argv: []string{"list", "-v"}, want: logging.LevelDebug},
+		{name: "verbose false", argv: []string{"list", "--verbose=false"}, want: logging.LevelInfo},
+		{name: "both", argv: []string{"list", "--quiet", "--verbose"}, want: logging.LevelInfo, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MCP_DEBUG", "")
+			t.Setenv("GIT_HOOK", "")
+			logger := logging.NewLogger()
+
+			err := ConfigureLogging(logger, ParseArgs(tt.argv))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConfigureLogging() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := logger.Level(); got != tt.want {
+				t.Errorf("logger.Level() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseArgs_VerbosityFlagsTakeNoValue(t *testing.T) {
+	tests := []struct {
+		argv        []string
+		wantCommand string
+		wantFlag    string
+	}{
+		{argv: []string{"-v", "list"}, wantCommand: "list", wantFlag: "v"},
+		{argv: []string{"-q", "call", "echo"}, wantCommand: "call", wantFlag: "q"},
+		{argv: []string{"--verbose", "describe", "echo"}, wantCommand: "describe", wantFlag: FlagVerbose},
+		{argv: []string{"--quiet", "list"}, wantCommand: "list", wantFlag: FlagQuiet},
+	}
+
+	for _, tt := range tests {
+		args := ParseArgs(tt.argv)
+		if args.Command != tt.wantCommand {
+			t.Errorf("ParseArgs(%q).Command = %q, want %q", tt.argv, args.Command, tt.wantCommand)
+		}
+		if got := args.GetFlag(tt.wantFlag, ""); got != "true" {
+			t.Errorf("ParseArgs(%q) flag %q = %q, want true", tt.argv, tt.wantFlag, got)
+		}
+	}
+}
+
+// loggingServer is a fake server exposing its logger
+type loggingServer struct {
+	*fakeServer
+	logger *logging.Logger
+}
+
+func (s *loggingServer) Logger() *logging.Logger {
+	return s.logger
+}
+
+func TestRun_ConfiguresLogging(t *testing.T) {
+	t.Setenv("MCP_DEBUG", "")
+	t.Setenv("GIT_HOOK", "")
+
+	server := &loggingServer{fakeServer: newFakeServer(), logger: logging.NewLogger()}
+	var out, errOut bytes.Buffer
+	if code := Run(context.Background(), server, []string{"-v", "list"}, &out, &errOut); code != ExitSuccess {
+		t.Fatalf("Run(-v list) = %d, want %d (stderr: %s)", code, ExitSuccess, errOut.String())
+	}
+	if got := server.logger.Level(); got != logging.LevelDebug {
+		t.Errorf("logger.Level() after -v = %v, want %v", got, logging.LevelDebug)
+	}
+
+	// Conflicting flags are a usage error, even for servers without a logger
+	errOut.Reset()
+	if code := Run(context.Background(), newFakeServer(), []string{"-q", "-v", "list"}, &out, &errOut); code != ExitUsageError {
+		t.Errorf("Run(-q -v list) = %d, want %d", code, ExitUsageError)
+	}
+}
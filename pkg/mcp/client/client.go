@@ -1,7 +1,7 @@
-// Package client provides wrapper functions around existing MCP client libraries.
+// Package client provides an MCP client for testing and driving MCP servers.
 //
-// This package wraps external MCP client libraries (e.g., github.com/metoro-io/mcp-golang)
-// to provide:
+// The client speaks JSON-RPC 2.0 over a server process's stdin/stdout (or any
+// reader/writer pair, see NewClientWithIO) and uses mcp-go-core types:
 //   - Type integration with mcp-go-core types
 //   - Testing utilities for mcp-go-core servers
 //   - Consistent API using mcp-go-core types
@@ -24,26 +24,42 @@
 //
 // Dependencies:
 //
-// This package requires github.com/metoro-io/mcp-golang to be available.
-// To build without this package (to avoid the dependency), use:
+// The default build has no dependencies beyond mcp-go-core. To use
+// github.com/metoro-io/mcp-golang as the protocol implementation instead,
+// build with:
 //
-//	go build -tags no_mcp_client
+//	go build -tags mcp_golang
 package client
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
 	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
 )
 
-// Client wraps an external MCP client library to provide a consistent API
-// using mcp-go-core types.
+// serverExitTimeout is how long Close waits for the server process to exit
+// after its stdin is closed before killing it
+const serverExitTimeout = 2 * time.Second
+
+// Client is an MCP client connected to a single server.
 type Client struct {
-	// underlying is the wrapped client from the external library.
-	// Using interface{} to avoid direct dependency (can be made type-safe with build tags)
+	// underlying is the wrapped client when built with -tags mcp_golang
 	underlying interface{} // Expected to be *mcp.Client from github.com/metoro-io/mcp-golang
 
+	// conn is the JSON-RPC connection (default build; nil until Initialize)
+	conn *rpcConn
+
 	// clientInfo stores the client information
 	clientInfo protocol.ClientInfo
 
@@ -53,18 +69,51 @@ type Client struct {
 	// serverArgs are arguments to pass to the server
 	serverArgs []string
 
+	// in carries messages from the server (its stdout)
+	in io.Reader
+
+	// out carries messages to the server (its stdin)
+	out io.Writer
+
 	// initialized tracks whether the client has been initialized
 	initialized bool
+
+	// protocolVersion is the version agreed with the server by Initialize
+	protocolVersion string
+
+	// serverCapabilities are the capabilities the server advertised
+	serverCapabilities protocol.ServerCapabilities
+
+	// cmd is the running server process (nil for NewClientWithIO)
+	cmd *exec.Cmd
+
+	// mu guards handlers called from the connection's read loop
+	mu sync.Mutex
+
+	// sampling answers sampling/createMessage requests (nil: unsupported)
+	sampling SamplingHandler
+
+	// logger logs calls under their request IDs (nil: no logging)
+	logger *logging.Logger
 }
 
-// NewClient creates a new client wrapper that connects to an MCP server.
+// SamplingHandler produces an LLM completion for a server's
+// sampling/createMessage request. Returning a *protocol.JSONRPCError sets the
+// error code sent to the server (e.g. when the user declines the request).
+type SamplingHandler func(ctx context.Context, params protocol.CreateMessageParams) (protocol.CreateMessageResult, error)
+
+// NewClient starts the server and returns a client connected to its
+// stdin/stdout. Call Initialize before other methods and Close when done,
+// which also stops the server.
 //
 // The serverCommand should be the path to the server binary or command.
 // The clientInfo identifies this client to the server.
-//
-// Note: This function requires the mcp-golang library to be available.
-// See the package documentation for dependency requirements.
 func NewClient(serverCommand string, clientInfo protocol.ClientInfo) (*Client, error) {
+	return NewClientWithArgs(serverCommand, nil, clientInfo)
+}
+
+// NewClientWithArgs is like NewClient but passes serverArgs to the server.
+func NewClientWithArgs(serverCommand string, serverArgs []string, clientInfo protocol.ClientInfo) (*Client, error) {
 	if serverCommand == "" {
 		return nil, fmt.Errorf("server command cannot be empty")
 	}
@@ -72,22 +121,69 @@ func NewClient(serverCommand string, clientInfo protocol.ClientInfo) (*Client, e
 		return nil, fmt.Errorf("client info name cannot be empty")
 	}
 
+	cmd := exec.Command(serverCommand, serverArgs...)
+	cmd.Stderr = os.Stderr // Server logs go to stderr; stdout is the protocol
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start server %q: %w", serverCommand, err)
+	}
+
 	return &Client{
 		clientInfo:    clientInfo,
 		serverCommand: serverCommand,
-		serverArgs:    []string{},
-		initialized:   false,
+		serverArgs:    serverArgs,
+		in:            stdout,
+		out:           stdin,
+		cmd:           cmd,
 	}, nil
 }
 
-// NewClientWithArgs creates a new client wrapper with server arguments.
-func NewClientWithArgs(serverCommand string, serverArgs []string, clientInfo protocol.ClientInfo) (*Client, error) {
-	client, err := NewClient(serverCommand, clientInfo)
-	if err != nil {
-		return nil, err
+// NewClientWithIO returns a client that talks to a server over the given
+// reader (messages from the server) and writer (messages to the server),
+// e.g. pipes to an in-process server. If w is an io.Closer, Close closes it.
+func NewClientWithIO(r io.Reader, w io.Writer, clientInfo protocol.ClientInfo) (*Client, error) {
+	if r == nil || w == nil {
+		return nil, fmt.Errorf("reader and writer cannot be nil")
+	}
+	if clientInfo.Name == "" {
+		return nil, fmt.Errorf("client info name cannot be empty")
+	}
+
+	return &Client{
+		clientInfo: clientInfo,
+		in:         r,
+		out:        w,
+	}, nil
+}
+
+// stopServer waits for the server process to exit, killing it if it does
+// not exit within serverExitTimeout. The server's stdin must already be
+// closed. It is a no-op for clients without a process.
+func (c *Client) stopServer() {
+	if c.cmd == nil {
+		return
+	}
+	cmd := c.cmd
+	c.cmd = nil
+
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+	select {
+	case <-exited:
+	case <-time.After(serverExitTimeout):
+		_ = cmd.Process.Kill()
+		<-exited
 	}
-	client.serverArgs = serverArgs
-	return client, nil
 }
 
 // PromptInfo represents prompt metadata (similar to ToolInfo)
@@ -96,9 +192,142 @@ type PromptInfo struct {
 	Description string
 }
 
+// ToolError is returned by CallTool when the server reports that the tool
+// failed (a result with isError set). Content holds the error text.
+type ToolError struct {
+	Tool    string
+	Content []types.TextContent
+}
+
+// Error returns the tool's error text
+func (e *ToolError) Error() string {
+	texts := make([]string, 0, len(e.Content))
+	for _, content := range e.Content {
+		texts = append(texts, content.Text)
+	}
+	return fmt.Sprintf("tool %q returned an error: %s", e.Tool, strings.Join(texts, "; "))
+}
+
+// InitializeOptions configures the initialize request sent by
+// InitializeWithOptions.
+type InitializeOptions struct {
+	// Capabilities declares the optional features this client supports
+	Capabilities protocol.ClientCapabilities
+
+	// Experimental declares non-standard features. Entries are added to
+	// Capabilities.Experimental, replacing any with the same name.
+	Experimental map[string]interface{}
+}
+
+// SetSamplingHandler sets the handler for sampling/createMessage requests
+// from the server; nil removes it. Set it before Initialize so the sampling
+// capability is declared to the server.
+func (c *Client) SetSamplingHandler(handler SamplingHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sampling = handler
+}
+
+// SetLogger makes the client log each tool call at debug level under its
+// request ID, the correlation ID also sent to the server in the call's
+// _meta (see protocol.RequestIDMetaKey), so a call can be traced across
+// client and server logs. nil turns logging off (the default).
+func (c *Client) SetLogger(logger *logging.Logger) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger = logger
+}
+
+// requestContext returns ctx carrying the request ID for a call: the one
+// already set with logging.WithRequestID, or a new random one
+func requestContext(ctx context.Context) (context.Context, string, error) {
+	if id := logging.RequestIDFromContext(ctx); id != "" {
+		return ctx, id, nil
+	}
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, "", fmt.Errorf("failed to generate request ID: %w", err)
+	}
+	id := hex.EncodeToString(b[:])
+	return logging.WithRequestID(ctx, id), id, nil
+}
+
+// debugCtx logs at debug level with the request ID in ctx, if a logger
+// is set
+func (c *Client) debugCtx(ctx context.Context, format string, args ...interface{}) {
+	c.mu.Lock()
+	logger := c.logger
+	c.mu.Unlock()
+	if logger != nil {
+		logger.DebugCtx(ctx, format, args...)
+	}
+}
+
+// samplingHandler returns the current sampling handler, or nil
+func (c *Client) samplingHandler() SamplingHandler {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sampling
+}
+
+// clientCapabilities returns the capabilities to declare in the initialize
+// request: those in opts plus any implied by the handlers set on c
+func (c *Client) clientCapabilities(opts InitializeOptions) protocol.ClientCapabilities {
+	caps := opts.capabilities()
+	if caps.Sampling == nil && c.samplingHandler() != nil {
+		caps.Sampling = &protocol.SamplingCapability{}
+	}
+	return caps
+}
+
+// capabilities returns the client capabilities to declare
+func (o InitializeOptions) capabilities() protocol.ClientCapabilities {
+	caps := o.Capabilities
+	if len(o.Experimental) > 0 {
+		experimental := make(map[string]interface{}, len(caps.Experimental)+len(o.Experimental))
+		for name, value := range caps.Experimental {
+			experimental[name] = value
+		}
+		for name, value := range o.Experimental {
+			experimental[name] = value
+		}
+		caps.Experimental = experimental
+	}
+	return caps
+}
+
+// ProtocolVersionError is returned by Initialize when the server answers
+// with a protocol version this client does not support.
+type ProtocolVersionError struct {
+	Requested string   // version sent in the initialize request
+	Server    string   // version the server answered with
+	Supported []string // versions this client accepts
+}
+
+// Error describes the version mismatch
+func (e *ProtocolVersionError) Error() string {
+	return fmt.Sprintf("server protocol version %q is not supported (requested %q, supported: %s)",
+		e.Server, e.Requested, strings.Join(e.Supported, ", "))
+}
+
+// negotiateProtocolVersion accepts the server's answer to an initialize
+// request for requested: the same version, or an older one this client also
+// speaks. Anything else is a *ProtocolVersionError.
+func (c *Client) negotiateProtocolVersion(requested, server string) error {
+	if !protocol.IsSupportedProtocolVersion(server) {
+		return &ProtocolVersionError{
+			Requested: requested,
+			Server:    server,
+			Supported: protocol.SupportedProtocolVersions(),
+		}
+	}
+	c.protocolVersion = server
+	return nil
+}
+
 // Note: Method implementations (Initialize, ListTools, CallTool, etc.) are in:
-//   - client_impl.go (when building without -tags no_mcp_client)
-//   - client_stub.go (when building with -tags no_mcp_client)
+//   - client_native.go (default build)
+//   - client_impl.go (when building with -tags mcp_golang)
 
 // GetClientInfo returns the client information.
 func (c *Client) GetClientInfo() protocol.ClientInfo {
@@ -110,14 +339,14 @@ func (c *Client) IsInitialized() bool {
 	return c.initialized
 }
 
-// validateServerCommand checks if the server command exists and is executable.
-func validateServerCommand(command string) error {
-	// Check if command is executable
-	cmd := exec.Command(command, "--help")
-	if err := cmd.Run(); err != nil {
-		// Command might not support --help, try just checking if file exists
-		// This is a basic validation - actual execution will show real errors
-		return nil // Don't fail validation on --help check
-	}
-	return nil
+// ServerCapabilities returns the capabilities the server advertised in its
+// initialize response (all nil before Initialize).
+func (c *Client) ServerCapabilities() protocol.ServerCapabilities {
+	return c.serverCapabilities
+}
+
+// ProtocolVersion returns the protocol version agreed with the server, or ""
+// before Initialize.
+func (c *Client) ProtocolVersion() string {
+	return c.protocolVersion
 }
@@ -1,21 +1,20 @@
-// +build !no_mcp_client
+//go:build mcp_golang
+// +build mcp_golang
 
 // Package client implementation using github.com/metoro-io/mcp-golang
 //
-// This file contains the actual implementation using the external client library.
-// To use this package, ensure github.com/metoro-io/mcp-golang is available:
+// This file contains an alternative implementation using the external client
+// library. It is only compiled when building with:
 //
-//	go get github.com/metoro-io/mcp-golang
-//
-// To build without the client wrapper (to avoid the dependency), use:
-//
-//	go build -tags no_mcp_client
+//	go build -tags mcp_golang
 
 package client
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"io"
 
 	mcp "github.com/metoro-io/mcp-golang"
 	"github.com/metoro-io/mcp-golang/transport/stdio"
@@ -24,24 +23,38 @@ import (
 	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
 )
 
-// initUnderlyingClient creates and initializes the underlying mcp-golang client.
+// initUnderlyingClient creates the underlying mcp-golang client connected to
+// the server.
 func (c *Client) initUnderlyingClient() error {
 	if c.underlying != nil {
 		return nil // Already initialized
 	}
 
-	// Create stdio transport
-	transport := stdio.NewStdioClientTransport()
-	
-	// Create underlying client
-	underlyingClient := mcp.NewClient(transport)
-	
-	c.underlying = underlyingClient
+	// mcp-golang has no client-side stdio transport; its stdio transport is
+	// symmetric JSON-RPC over a reader/writer pair, so point it at the server
+	transport := stdio.NewStdioServerTransportWithIO(c.in, c.out)
+
+	c.underlying = mcp.NewClientWithInfo(transport, mcp.ClientInfo{
+		Name:    c.clientInfo.Name,
+		Version: c.clientInfo.Version,
+	})
 	return nil
 }
 
 // Initialize initializes the client session with the MCP server.
 func (c *Client) Initialize(ctx context.Context) (*protocol.InitializeResult, error) {
+	return c.InitializeWithOptions(ctx, InitializeOptions{})
+}
+
+// InitializeWithOptions is Initialize with client capabilities. mcp-golang
+// always declares no capabilities, so any set in opts (or implied by a
+// sampling handler) are an error.
+func (c *Client) InitializeWithOptions(ctx context.Context, opts InitializeOptions) (*protocol.InitializeResult, error) {
+	caps := c.clientCapabilities(opts)
+	if caps.Roots != nil || caps.Sampling != nil || len(caps.Experimental) > 0 {
+		return nil, fmt.Errorf("client capabilities are not supported when built with mcp_golang")
+	}
+
 	if err := c.initUnderlyingClient(); err != nil {
 		return nil, fmt.Errorf("failed to initialize underlying client: %w", err)
 	}
@@ -56,13 +69,21 @@ func (c *Client) Initialize(ctx context.Context) (*protocol.InitializeResult, er
 		return nil, fmt.Errorf("failed to initialize: %w", err)
 	}
 
+	// mcp-golang requests version "1.0" and accepts whatever the server
+	// answers (servers answer with their own version), so check it here
+	if err := c.negotiateProtocolVersion("1.0", response.ProtocolVersion); err != nil {
+		return nil, fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	capabilities, err := ConvertExternalServerCapabilities(response.Capabilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse server capabilities: %w", err)
+	}
+
 	// Convert response to protocol.InitializeResult
 	result := &protocol.InitializeResult{
 		ProtocolVersion: response.ProtocolVersion,
-		Capabilities: protocol.ServerCapabilities{
-			Tools:     convertToolsCapability(response.Capabilities),
-			Resources: convertResourcesCapability(response.Capabilities),
-		},
+		Capabilities:    capabilities,
 		ServerInfo: protocol.ServerInfo{
 			Name:    response.ServerInfo.Name,
 			Version: response.ServerInfo.Version,
@@ -70,6 +91,7 @@ func (c *Client) Initialize(ctx context.Context) (*protocol.InitializeResult, er
 	}
 
 	c.initialized = true
+	c.serverCapabilities = capabilities
 	return result, nil
 }
 
@@ -111,6 +133,13 @@ func (c *Client) CallTool(ctx context.Context, name string, args map[string]inte
 
 	client := c.underlying.(*mcp.Client)
 
+	// mcp-golang cannot send _meta, so the request ID is only logged here
+	ctx, _, err := requestContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.debugCtx(ctx, "Calling tool %s", name)
+
 	// Call underlying CallTool
 	response, err := client.CallTool(ctx, name, args)
 	if err != nil {
@@ -151,8 +180,8 @@ func (c *Client) ListResources(ctx context.Context) ([]protocol.Resource, error)
 		resources = append(resources, protocol.Resource{
 			URI:         resource.Uri,
 			Name:        resource.Name,
-			Description: resource.Description,
-			MimeType:    resource.MimeType,
+			Description: derefString(resource.Description),
+			MimeType:    derefString(resource.MimeType),
 		})
 	}
 
@@ -176,7 +205,24 @@ func (c *Client) ReadResource(ctx context.Context, uri string) ([]byte, string,
 		return nil, "", fmt.Errorf("failed to read resource %q: %w", uri, err)
 	}
 
-	return []byte(resource.Content), resource.MimeType, nil
+	// Return the first content item, text or base64-decoded blob
+	for _, content := range resource.Contents {
+		if content == nil {
+			continue
+		}
+		if text := content.TextResourceContents; text != nil {
+			return []byte(text.Text), derefString(text.MimeType), nil
+		}
+		if blob := content.BlobResourceContents; blob != nil {
+			data, err := base64.StdEncoding.DecodeString(blob.Blob)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to decode resource %q: %w", uri, err)
+			}
+			return data, derefString(blob.MimeType), nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("resource %q had no content", uri)
 }
 
 // ListPrompts lists all available prompts from the server.
@@ -236,29 +282,39 @@ func (c *Client) GetPrompt(ctx context.Context, name string, args map[string]int
 	return "", fmt.Errorf("prompt response had no text content")
 }
 
-// Close closes the client connection and cleans up resources.
-func (c *Client) Close() error {
-	if c.underlying == nil {
-		return nil
+// Ping sends a ping request and waits for the server's reply.
+func (c *Client) Ping(ctx context.Context) error {
+	if !c.initialized {
+		return fmt.Errorf("client must be initialized before pinging")
 	}
 
-	// The underlying client may have a Close method
-	// For stdio transport, cleanup is typically automatic
-	c.underlying = nil
-	c.initialized = false
+	client := c.underlying.(*mcp.Client)
+	if err := client.Ping(ctx); err != nil {
+		return fmt.Errorf("ping failed: %w", err)
+	}
 	return nil
 }
 
-// Helper functions for capability conversion
+// Close closes the client connection and cleans up resources.
+func (c *Client) Close() error {
 
-func convertToolsCapability(capabilities interface{}) *protocol.ToolsCapability {
-	// Check if tools capability exists in response
-	// This is a placeholder - actual implementation depends on mcp-golang's capability structure
-	return &protocol.ToolsCapability{}
+	c.underlying = nil
+	c.initialized = false
+	c.protocolVersion = ""
+	c.serverCapabilities = protocol.ServerCapabilities{}
+
+	// Closing the server's stdin tells it to exit
+	if closer, ok := c.out.(io.Closer); ok {
+		_ = closer.Close()
+	}
+	c.stopServer()
+	return nil
 }
 
-func convertResourcesCapability(capabilities interface{}) *protocol.ResourcesCapability {
-	// Check if resources capability exists in response
-	// This is a placeholder - actual implementation depends on mcp-golang's capability structure
-	return &protocol.ResourcesCapability{}
+// derefString returns the pointed-to string, or "" for nil
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
@@ -16,7 +16,6 @@ import (
 	"time"
 
 	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
-	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
 )
 
 // TestClientInitialization tests basic client initialization.
@@ -131,6 +130,7 @@ func TestCallTool(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to initialize: %v", err)
 	}
+	RequireCapability(t, c, CapabilityTools)
 
 	// List tools first to find one to call
 	tools, err := c.ListTools(ctx)
@@ -188,6 +188,7 @@ func TestListResources(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to initialize: %v", err)
 	}
+	RequireCapability(t, c, CapabilityResources)
 
 	// List resources
 	resources, err := c.ListResources(ctx)
@@ -292,8 +293,8 @@ func TestAssertToolExists(t *testing.T) {
 	}
 }
 
-// TestToolExecution tests the TestToolExecution utility.
-func TestToolExecution(t *testing.T) {
+// TestTestToolExecution tests the TestToolExecution utility.
+func TestTestToolExecution(t *testing.T) {
 	serverCommand := os.Getenv("MCP_TEST_SERVER")
 	if serverCommand == "" {
 		t.Skip("Skipping integration test: MCP_TEST_SERVER not set")
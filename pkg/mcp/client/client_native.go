@@ -0,0 +1,364 @@
+//go:build !mcp_golang
+// +build !mcp_golang
+
+// Package client native implementation: JSON-RPC 2.0 over the server's
+// stdin/stdout using the protocol package.
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// connection returns the JSON-RPC connection, opening it on first use
+func (c *Client) connection() (*rpcConn, error) {
+	if c.conn == nil {
+		if c.in == nil || c.out == nil {
+			return nil, fmt.Errorf("client is closed")
+		}
+		c.conn = newRPCConn(c.in, c.out)
+		c.conn.handle("sampling/createMessage", c.handleCreateMessage)
+	}
+	return c.conn, nil
+}
+
+// handleCreateMessage answers a sampling/createMessage request from the
+// server using the sampling handler
+func (c *Client) handleCreateMessage(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	handler := c.samplingHandler()
+	if handler == nil {
+		return nil, &protocol.JSONRPCError{Code: protocol.ErrCodeMethodNotFound, Message: "sampling is not supported by this client"}
+	}
+
+	var params protocol.CreateMessageParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, &protocol.JSONRPCError{Code: protocol.ErrCodeInvalidParams, Message: fmt.Sprintf("invalid sampling params: %v", err)}
+	}
+
+	result, err := handler(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Role == "" {
+		result.Role = protocol.RoleAssistant
+	}
+	return result, nil
+}
+
+// Initialize performs the MCP initialize handshake declaring no optional
+// client capabilities. See InitializeWithOptions.
+func (c *Client) Initialize(ctx context.Context) (*protocol.InitializeResult, error) {
+	return c.InitializeWithOptions(ctx, InitializeOptions{})
+}
+
+// InitializeWithOptions performs the MCP initialize handshake: it sends the
+// initialize request declaring the capabilities in opts (plus sampling if a
+// sampling handler is set), then the notifications/initialized notification.
+// If the server answers with an unsupported protocol version, it returns a
+// *ProtocolVersionError and the client should be closed.
+func (c *Client) InitializeWithOptions(ctx context.Context, opts InitializeOptions) (*protocol.InitializeResult, error) {
+	conn, err := c.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	params := protocol.InitializeParams{
+		ProtocolVersion: protocol.LatestProtocolVersion,
+		Capabilities:    c.clientCapabilities(opts),
+		ClientInfo:      c.clientInfo,
+	}
+	var result protocol.InitializeResult
+	if err := conn.call(ctx, "initialize", params, &result); err != nil {
+		return nil, fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	// The server answers with the requested version or one it prefers; the
+	// session can only proceed if this client speaks that version too
+	if err := c.negotiateProtocolVersion(params.ProtocolVersion, result.ProtocolVersion); err != nil {
+		return nil, fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	if err := conn.notify("notifications/initialized", struct{}{}); err != nil {
+		return nil, fmt.Errorf("failed to send initialized notification: %w", err)
+	}
+
+	c.initialized = true
+	c.serverCapabilities = result.Capabilities
+	return &result, nil
+}
+
+// ListTools lists all available tools from the server.
+func (c *Client) ListTools(ctx context.Context) ([]types.ToolInfo, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("client must be initialized before listing tools")
+	}
+
+	var tools []types.ToolInfo
+	err := c.paginate(ctx, "tools/list", func(page json.RawMessage) (string, error) {
+		var result struct {
+			Tools      []json.RawMessage `json:"tools"`
+			NextCursor string            `json:"nextCursor,omitempty"`
+		}
+		if err := json.Unmarshal(page, &result); err != nil {
+			return "", err
+		}
+		for _, tool := range result.Tools {
+			converted, err := ConvertExternalToolToToolInfo(tool)
+			if err != nil {
+				return "", err
+			}
+			tools = append(tools, converted)
+		}
+		return result.NextCursor, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	return tools, nil
+}
+
+// CallTool calls a tool on the server with the given arguments.
+// If the tool reports a failure (isError), the error is a *ToolError.
+// The call carries the request ID set on ctx with logging.WithRequestID, or
+// a generated one, for correlating client and server logs (see SetLogger).
+func (c *Client) CallTool(ctx context.Context, name string, args map[string]interface{}) ([]types.TextContent, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("client must be initialized before calling tools")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("tool name cannot be empty")
+	}
+
+	// Send the call's request ID so the server logs it under the same ID
+	ctx, requestID, err := requestContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := protocol.ToolCallParams{
+		Name:      name,
+		Arguments: args,
+		Meta:      map[string]interface{}{protocol.RequestIDMetaKey: requestID},
+	}
+	c.debugCtx(ctx, "Calling tool %s", name)
+	var response struct {
+		Content []json.RawMessage `json:"content"`
+		IsError bool              `json:"isError,omitempty"`
+	}
+	if err := c.conn.call(ctx, "tools/call", params, &response); err != nil {
+		return nil, fmt.Errorf("failed to call tool %q: %w", name, err)
+	}
+
+	// Convert text content; other content types are skipped
+	result := make([]types.TextContent, 0, len(response.Content))
+	for _, content := range response.Content {
+		converted, err := ConvertExternalTextContent(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert tool %q result: %w", name, err)
+		}
+		if converted.Type == "text" {
+			result = append(result, converted)
+		}
+	}
+
+	if response.IsError {
+		return result, &ToolError{Tool: name, Content: result}
+	}
+	return result, nil
+}
+
+// ListResources lists all available resources from the server.
+func (c *Client) ListResources(ctx context.Context) ([]protocol.Resource, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("client must be initialized before listing resources")
+	}
+
+	var resources []protocol.Resource
+	err := c.paginate(ctx, "resources/list", func(page json.RawMessage) (string, error) {
+		var result struct {
+			Resources  []protocol.Resource `json:"resources"`
+			NextCursor string              `json:"nextCursor,omitempty"`
+		}
+		if err := json.Unmarshal(page, &result); err != nil {
+			return "", err
+		}
+		resources = append(resources, result.Resources...)
+		return result.NextCursor, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+
+	return resources, nil
+}
+
+// ReadResource reads a resource from the server by URI.
+// It returns the first content item: text as-is, blobs base64-decoded.
+func (c *Client) ReadResource(ctx context.Context, uri string) ([]byte, string, error) {
+	if !c.initialized {
+		return nil, "", fmt.Errorf("client must be initialized before reading resources")
+	}
+	if uri == "" {
+		return nil, "", fmt.Errorf("resource URI cannot be empty")
+	}
+
+	params := protocol.ResourceReadParams{URI: uri}
+	var response struct {
+		Contents []struct {
+			MimeType string  `json:"mimeType,omitempty"`
+			Text     *string `json:"text,omitempty"`
+			Blob     *string `json:"blob,omitempty"`
+		} `json:"contents"`
+	}
+	if err := c.conn.call(ctx, "resources/read", params, &response); err != nil {
+		return nil, "", fmt.Errorf("failed to read resource %q: %w", uri, err)
+	}
+
+	for _, content := range response.Contents {
+		if content.Text != nil {
+			return []byte(*content.Text), content.MimeType, nil
+		}
+		if content.Blob != nil {
+			data, err := base64.StdEncoding.DecodeString(*content.Blob)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to decode resource %q: %w", uri, err)
+			}
+			return data, content.MimeType, nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("resource %q had no content", uri)
+}
+
+// ListPrompts lists all available prompts from the server.
+func (c *Client) ListPrompts(ctx context.Context) ([]PromptInfo, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("client must be initialized before listing prompts")
+	}
+
+	var prompts []PromptInfo
+	err := c.paginate(ctx, "prompts/list", func(page json.RawMessage) (string, error) {
+		var result struct {
+			Prompts []struct {
+				Name        string `json:"name"`
+				Description string `json:"description,omitempty"`
+			} `json:"prompts"`
+			NextCursor string `json:"nextCursor,omitempty"`
+		}
+		if err := json.Unmarshal(page, &result); err != nil {
+			return "", err
+		}
+		for _, prompt := range result.Prompts {
+			prompts = append(prompts, PromptInfo{Name: prompt.Name, Description: prompt.Description})
+		}
+		return result.NextCursor, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	return prompts, nil
+}
+
+// GetPrompt gets a prompt template from the server and returns the text of
+// its first message. MCP prompt arguments are strings; other values are
+// sent JSON-encoded.
+func (c *Client) GetPrompt(ctx context.Context, name string, args map[string]interface{}) (string, error) {
+	if !c.initialized {
+		return "", fmt.Errorf("client must be initialized before getting prompts")
+	}
+	if name == "" {
+		return "", fmt.Errorf("prompt name cannot be empty")
+	}
+
+	arguments := make(map[string]string, len(args))
+	for key, value := range args {
+		if str, ok := value.(string); ok {
+			arguments[key] = str
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid prompt argument %q: %w", key, err)
+		}
+		arguments[key] = string(data)
+	}
+
+	params := map[string]interface{}{"name": name, "arguments": arguments}
+	var response struct {
+		Messages []struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+	}
+	if err := c.conn.call(ctx, "prompts/get", params, &response); err != nil {
+		return "", fmt.Errorf("failed to get prompt %q: %w", name, err)
+	}
+
+	// Extract text from messages
+	if len(response.Messages) > 0 {
+		content, err := ConvertExternalTextContent(response.Messages[0].Content)
+		if err == nil && content.Type == "text" {
+			return content.Text, nil
+		}
+	}
+
+	return "", fmt.Errorf("prompt response had no text content")
+}
+
+// Ping sends a ping request and waits for the server's reply.
+func (c *Client) Ping(ctx context.Context) error {
+	if !c.initialized {
+		return fmt.Errorf("client must be initialized before pinging")
+	}
+	if err := c.conn.call(ctx, "ping", nil, nil); err != nil {
+		return fmt.Errorf("ping failed: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection and stops the server process, if the client
+// started one.
+func (c *Client) Close() error {
+	if c.conn != nil {
+		_ = c.conn.close()
+	} else if closer, ok := c.out.(io.Closer); ok {
+		_ = closer.Close()
+	}
+	c.in, c.out = nil, nil
+	c.initialized = false
+	c.protocolVersion = ""
+	c.serverCapabilities = protocol.ServerCapabilities{}
+	c.stopServer()
+	return nil
+}
+
+// paginate calls a list method until the server returns no cursor, passing
+// each result page to handlePage, which returns the next cursor
+func (c *Client) paginate(ctx context.Context, method string, handlePage func(page json.RawMessage) (string, error)) error {
+	cursor := ""
+	for {
+		params := map[string]interface{}{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+		var page json.RawMessage
+		if err := c.conn.call(ctx, method, params, &page); err != nil {
+			return err
+		}
+		next, err := handlePage(page)
+		if err != nil {
+			return fmt.Errorf("invalid %s result: %w", method, err)
+		}
+		if next == "" || next == cursor {
+			return nil
+		}
+		cursor = next
+	}
+}
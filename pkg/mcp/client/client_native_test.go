@@ -0,0 +1,556 @@
+//go:build !mcp_golang
+// +build !mcp_golang
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// TestMain lets the test binary act as an MCP server for NewClient tests
+func TestMain(m *testing.M) {
+	if os.Getenv("MCP_CLIENT_FAKE_SERVER") == "1" {
+		newFakeServer().serve(os.Stdin, os.Stdout)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// initializedClient connects a client to s and initializes it
+func initializedClient(t *testing.T, s *fakeServer) *Client {
+	t.Helper()
+	c := s.connect(t)
+	if _, err := c.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	return c
+}
+
+func TestClient_Initialize(t *testing.T) {
+	s := newFakeServer()
+	s.handle("initialize", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		return json.RawMessage(`{"protocolVersion":"2025-06-18","capabilities":{"tools":{"listChanged":true},"logging":{}},"serverInfo":{"name":"fake","version":"2.0.0"}}`), nil
+	})
+	c := s.connect(t)
+
+	result, err := c.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if !c.IsInitialized() {
+		t.Error("IsInitialized() = false after Initialize")
+	}
+	if result.ServerInfo.Name != "fake" || result.ServerInfo.Version != "2.0.0" {
+		t.Errorf("ServerInfo = %+v, want fake 2.0.0", result.ServerInfo)
+	}
+	if result.Capabilities.Tools == nil || !result.Capabilities.Tools.ListChanged || result.Capabilities.Logging == nil {
+		t.Errorf("Capabilities = %+v, want tools(listChanged) and logging", result.Capabilities)
+	}
+	if result.Capabilities.Resources != nil {
+		t.Errorf("Resources = %+v, want nil", result.Capabilities.Resources)
+	}
+
+	// The request carries the client info and protocol version
+	requests := s.messages("initialize")
+	if len(requests) != 1 {
+		t.Fatalf("server received %d initialize requests, want 1", len(requests))
+	}
+	var params protocol.InitializeParams
+	if err := json.Unmarshal(requests[0].Params, &params); err != nil {
+		t.Fatalf("invalid initialize params: %v", err)
+	}
+	if params.ClientInfo.Name != "test-client" || params.ProtocolVersion != protocol.LatestProtocolVersion {
+		t.Errorf("initialize params = %+v", params)
+	}
+
+	// ...and is followed by the initialized notification
+	deadline := time.Now().Add(2 * time.Second)
+	for len(s.messages("notifications/initialized")) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("server never received notifications/initialized")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestClient_InitializeProtocolVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverVersion string
+		wantErr       bool
+	}{
+		{"matching", protocol.LatestProtocolVersion, false},
+		{"older supported", "2024-11-05", false},
+		{"incompatible", "2099-01-01", true},
+		{"missing", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newFakeServer()
+			s.handle("initialize", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+				return protocol.InitializeResult{
+					ProtocolVersion: tt.serverVersion,
+					ServerInfo:      protocol.ServerInfo{Name: "fake-server"},
+				}, nil
+			})
+			c := s.connect(t)
+
+			_, err := c.Initialize(context.Background())
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Initialize() error = %v", err)
+				}
+				if c.ProtocolVersion() != tt.serverVersion {
+					t.Errorf("ProtocolVersion() = %q, want %q", c.ProtocolVersion(), tt.serverVersion)
+				}
+				return
+			}
+
+			var versionErr *ProtocolVersionError
+			if !errors.As(err, &versionErr) {
+				t.Fatalf("Initialize() error = %v, want *ProtocolVersionError", err)
+			}
+			if versionErr.Server != tt.serverVersion || versionErr.Requested != protocol.LatestProtocolVersion {
+				t.Errorf("ProtocolVersionError = %+v", versionErr)
+			}
+			if c.IsInitialized() || c.ProtocolVersion() != "" {
+				t.Error("client should not be initialized after a version mismatch")
+			}
+			if n := len(s.messages("notifications/initialized")); n != 0 {
+				t.Errorf("sent %d initialized notifications after a version mismatch", n)
+			}
+		})
+	}
+}
+
+func TestClient_InitializeWithOptions(t *testing.T) {
+	s := newFakeServer()
+	c := s.connect(t)
+
+	_, err := c.InitializeWithOptions(context.Background(), InitializeOptions{
+		Capabilities: protocol.ClientCapabilities{
+			Roots:        &protocol.RootsCapability{ListChanged: true},
+			Sampling:     &protocol.SamplingCapability{},
+			Experimental: map[string]interface{}{"featureA": true},
+		},
+		Experimental: map[string]interface{}{"featureB": map[string]interface{}{"level": 2}},
+	})
+	if err != nil {
+		t.Fatalf("InitializeWithOptions() error = %v", err)
+	}
+
+	requests := s.messages("initialize")
+	if len(requests) != 1 {
+		t.Fatalf("server received %d initialize requests, want 1", len(requests))
+	}
+	var params struct {
+		Capabilities map[string]json.RawMessage `json:"capabilities"`
+	}
+	if err := json.Unmarshal(requests[0].Params, &params); err != nil {
+		t.Fatalf("invalid initialize params: %v", err)
+	}
+	want := map[string]string{
+		"roots":        `{"listChanged":true}`,
+		"sampling":     `{}`,
+		"experimental": `{"featureA":true,"featureB":{"level":2}}`,
+	}
+	for name, value := range want {
+		if got := string(params.Capabilities[name]); got != value {
+			t.Errorf("capabilities.%s = %s, want %s", name, got, value)
+		}
+	}
+}
+
+func TestClient_InitializeDeclaresNoCapabilities(t *testing.T) {
+	s := newFakeServer()
+	initializedClient(t, s)
+
+	var params struct {
+		Capabilities map[string]json.RawMessage `json:"capabilities"`
+	}
+	if err := json.Unmarshal(s.messages("initialize")[0].Params, &params); err != nil {
+		t.Fatalf("invalid initialize params: %v", err)
+	}
+	if len(params.Capabilities) != 0 {
+		t.Errorf("capabilities = %v, want none", params.Capabilities)
+	}
+}
+
+func TestClient_RequiresInitialize(t *testing.T) {
+	c := newFakeServer().connect(t)
+	ctx := context.Background()
+
+	if _, err := c.ListTools(ctx); err == nil {
+		t.Error("ListTools() before Initialize error = nil")
+	}
+	if _, err := c.CallTool(ctx, "echo", nil); err == nil {
+		t.Error("CallTool() before Initialize error = nil")
+	}
+	if _, err := c.ListResources(ctx); err == nil {
+		t.Error("ListResources() before Initialize error = nil")
+	}
+	if _, _, err := c.ReadResource(ctx, "test://x"); err == nil {
+		t.Error("ReadResource() before Initialize error = nil")
+	}
+	if _, err := c.ListPrompts(ctx); err == nil {
+		t.Error("ListPrompts() before Initialize error = nil")
+	}
+	if _, err := c.GetPrompt(ctx, "greeting", nil); err == nil {
+		t.Error("GetPrompt() before Initialize error = nil")
+	}
+}
+
+func TestClient_ListToolsPaginated(t *testing.T) {
+	s := newFakeServer()
+	s.handle("tools/list", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		var p struct {
+			Cursor string `json:"cursor"`
+		}
+		_ = json.Unmarshal(params, &p)
+		if p.Cursor == "" {
+			return json.RawMessage(`{"tools":[{"name":"echo","description":"Echo","inputSchema":{"type":"object","properties":{"message":{"type":"string"}},"required":["message"]}}],"nextCursor":"page2"}`), nil
+		}
+		return json.RawMessage(`{"tools":[{"name":"math","inputSchema":{"type":"object"}}]}`), nil
+	})
+	c := initializedClient(t, s)
+
+	tools, err := c.ListTools(context.Background())
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	if len(tools) != 2 || tools[0].Name != "echo" || tools[1].Name != "math" {
+		t.Fatalf("ListTools() = %+v, want echo and math", tools)
+	}
+	if tools[0].Description != "Echo" || len(tools[0].Schema.Required) != 1 {
+		t.Errorf("echo tool = %+v", tools[0])
+	}
+}
+
+func TestClient_CallTool(t *testing.T) {
+	s := newFakeServer()
+	s.handle("tools/call", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		var p protocol.ToolCallParams
+		_ = json.Unmarshal(params, &p)
+		switch p.Name {
+		case "echo":
+			return map[string]interface{}{
+				"content": []map[string]interface{}{
+					{"type": "text", "text": "Echo: " + p.Arguments["message"].(string)},
+					{"type": "image", "data": "", "mimeType": "image/png"},
+				},
+			}, nil
+		case "fail":
+			return map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": "boom"}},
+				"isError": true,
+			}, nil
+		default:
+			return nil, &protocol.JSONRPCError{Code: protocol.ErrCodeInvalidParams, Message: "unknown tool " + p.Name}
+		}
+	})
+	c := initializedClient(t, s)
+	ctx := context.Background()
+
+	content, err := c.CallTool(ctx, "echo", map[string]interface{}{"message": "hi"})
+	if err != nil {
+		t.Fatalf("CallTool(echo) error = %v", err)
+	}
+	if len(content) != 1 || content[0] != (types.TextContent{Type: "text", Text: "Echo: hi"}) {
+		t.Errorf("CallTool(echo) = %+v, want one text item", content)
+	}
+
+	content, err = c.CallTool(ctx, "fail", nil)
+	var toolErr *ToolError
+	if !errors.As(err, &toolErr) {
+		t.Fatalf("CallTool(fail) error = %v, want *ToolError", err)
+	}
+	if toolErr.Tool != "fail" || !strings.Contains(err.Error(), "boom") || len(content) != 1 {
+		t.Errorf("CallTool(fail) = %+v, %v", content, err)
+	}
+
+	_, err = c.CallTool(ctx, "missing", nil)
+	var rpcErr *protocol.JSONRPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != protocol.ErrCodeInvalidParams {
+		t.Errorf("CallTool(missing) error = %v, want JSON-RPC invalid params error", err)
+	}
+}
+
+func TestClient_Resources(t *testing.T) {
+	s := newFakeServer()
+	s.handle("resources/list", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		return map[string]interface{}{"resources": []protocol.Resource{
+			{URI: "test://text", Name: "Text", MimeType: "text/plain"},
+			{URI: "test://blob", Name: "Blob"},
+		}}, nil
+	})
+	s.handle("resources/read", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		var p protocol.ResourceReadParams
+		_ = json.Unmarshal(params, &p)
+		if p.URI == "test://blob" {
+			return map[string]interface{}{"contents": []map[string]interface{}{
+				{"uri": p.URI, "mimeType": "application/octet-stream", "blob": base64.StdEncoding.EncodeToString([]byte{0, 1, 2})},
+			}}, nil
+		}
+		return map[string]interface{}{"contents": []map[string]interface{}{
+			{"uri": p.URI, "mimeType": "text/plain", "text": "hello"},
+		}}, nil
+	})
+	c := initializedClient(t, s)
+	ctx := context.Background()
+
+	resources, err := c.ListResources(ctx)
+	if err != nil {
+		t.Fatalf("ListResources() error = %v", err)
+	}
+	if len(resources) != 2 || resources[0].URI != "test://text" || resources[0].MimeType != "text/plain" {
+		t.Errorf("ListResources() = %+v", resources)
+	}
+
+	data, mimeType, err := c.ReadResource(ctx, "test://text")
+	if err != nil || string(data) != "hello" || mimeType != "text/plain" {
+		t.Errorf("ReadResource(text) = %q, %q, %v", data, mimeType, err)
+	}
+	data, mimeType, err = c.ReadResource(ctx, "test://blob")
+	if err != nil || string(data) != "\x00\x01\x02" || mimeType != "application/octet-stream" {
+		t.Errorf("ReadResource(blob) = %q, %q, %v", data, mimeType, err)
+	}
+}
+
+func TestClient_Prompts(t *testing.T) {
+	s := newFakeServer()
+	s.handle("prompts/list", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		return json.RawMessage(`{"prompts":[{"name":"greeting","description":"Say hello"}]}`), nil
+	})
+	s.handle("prompts/get", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		var p struct {
+			Name      string            `json:"name"`
+			Arguments map[string]string `json:"arguments"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &protocol.JSONRPCError{Code: protocol.ErrCodeInvalidParams, Message: err.Error()}
+		}
+		text := "Hello, " + p.Arguments["name"] + " x" + p.Arguments["count"]
+		return map[string]interface{}{"messages": []map[string]interface{}{
+			{"role": "user", "content": map[string]interface{}{"type": "text", "text": text}},
+		}}, nil
+	})
+	c := initializedClient(t, s)
+	ctx := context.Background()
+
+	prompts, err := c.ListPrompts(ctx)
+	if err != nil {
+		t.Fatalf("ListPrompts() error = %v", err)
+	}
+	if len(prompts) != 1 || prompts[0] != (PromptInfo{Name: "greeting", Description: "Say hello"}) {
+		t.Errorf("ListPrompts() = %+v", prompts)
+	}
+
+	// Non-string arguments are sent JSON-encoded
+	text, err := c.GetPrompt(ctx, "greeting", map[string]interface{}{"name": "Ada", "count": 2})
+	if err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+	if text != "Hello, Ada x2" {
+		t.Errorf("GetPrompt() = %q, want %q", text, "Hello, Ada x2")
+	}
+}
+
+func TestClient_AnswersServerPing(t *testing.T) {
+	s := newFakeServer()
+	initializedClient(t, s)
+
+	resp := s.request(t, "ping", nil)
+	if resp.Error != nil || string(resp.Result) != "{}" {
+		t.Errorf("ping response = result %s, error %v; want {}", resp.Result, resp.Error)
+	}
+}
+
+func TestClient_Sampling(t *testing.T) {
+	s := newFakeServer()
+	c := s.connect(t)
+
+	var got protocol.CreateMessageParams
+	c.SetSamplingHandler(func(ctx context.Context, params protocol.CreateMessageParams) (protocol.CreateMessageResult, error) {
+		got = params
+		return protocol.CreateMessageResult{
+			Content:    protocol.SamplingContent{Type: "text", Text: "Paris"},
+			Model:      "test-model",
+			StopReason: protocol.StopReasonEndTurn,
+		}, nil
+	})
+	if _, err := c.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	// The handler implies the sampling capability
+	var init struct {
+		Capabilities protocol.ClientCapabilities `json:"capabilities"`
+	}
+	_ = json.Unmarshal(s.messages("initialize")[0].Params, &init)
+	if init.Capabilities.Sampling == nil {
+		t.Error("sampling capability not declared")
+	}
+
+	resp := s.request(t, "sampling/createMessage", protocol.CreateMessageParams{
+		Messages:     []protocol.SamplingMessage{{Role: protocol.RoleUser, Content: protocol.SamplingContent{Type: "text", Text: "Capital of France?"}}},
+		SystemPrompt: "Be brief",
+		MaxTokens:    10,
+	})
+	if resp.Error != nil {
+		t.Fatalf("sampling response error = %v", resp.Error)
+	}
+	if len(got.Messages) != 1 || got.Messages[0].Content.Text != "Capital of France?" || got.SystemPrompt != "Be brief" || got.MaxTokens != 10 {
+		t.Errorf("handler params = %+v", got)
+	}
+
+	var result protocol.CreateMessageResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatalf("invalid sampling result: %v", err)
+	}
+	want := protocol.CreateMessageResult{
+		Role:       protocol.RoleAssistant,
+		Content:    protocol.SamplingContent{Type: "text", Text: "Paris"},
+		Model:      "test-model",
+		StopReason: protocol.StopReasonEndTurn,
+	}
+	if result != want {
+		t.Errorf("sampling result = %+v, want %+v", result, want)
+	}
+}
+
+func TestClient_SamplingErrors(t *testing.T) {
+	s := newFakeServer()
+	c := initializedClient(t, s)
+	params := protocol.CreateMessageParams{MaxTokens: 10}
+
+	// Without a handler sampling is not supported
+	resp := s.request(t, "sampling/createMessage", params)
+	if resp.Error == nil || resp.Error.Code != protocol.ErrCodeMethodNotFound {
+		t.Errorf("response without handler = %+v, want method not found", resp.Error)
+	}
+
+	// Handler errors are reported, keeping JSON-RPC error codes
+	c.SetSamplingHandler(func(ctx context.Context, params protocol.CreateMessageParams) (protocol.CreateMessageResult, error) {
+		return protocol.CreateMessageResult{}, &protocol.JSONRPCError{Code: -1, Message: "user rejected sampling request"}
+	})
+	resp = s.request(t, "sampling/createMessage", params)
+	if resp.Error == nil || resp.Error.Code != -1 || resp.Error.Message != "user rejected sampling request" {
+		t.Errorf("response for rejected request = %+v", resp.Error)
+	}
+
+	c.SetSamplingHandler(func(ctx context.Context, params protocol.CreateMessageParams) (protocol.CreateMessageResult, error) {
+		return protocol.CreateMessageResult{}, errors.New("model unavailable")
+	})
+	resp = s.request(t, "sampling/createMessage", params)
+	if resp.Error == nil || resp.Error.Code != protocol.ErrCodeInternalError {
+		t.Errorf("response for failed handler = %+v, want internal error", resp.Error)
+	}
+
+	resp = s.request(t, "sampling/createMessage", json.RawMessage(`{"messages":"bad"}`))
+	if resp.Error == nil || resp.Error.Code != protocol.ErrCodeInvalidParams {
+		t.Errorf("response for invalid params = %+v, want invalid params", resp.Error)
+	}
+}
+
+func TestClient_ContextCancel(t *testing.T) {
+	s := newFakeServer()
+	block := make(chan struct{})
+	defer close(block)
+	s.handle("tools/call", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		<-block
+		return nil, nil
+	})
+	c := initializedClient(t, s)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.CallTool(ctx, "slow", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CallTool() error = %v, want deadline exceeded", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(s.messages("notifications/cancelled")) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("server never received notifications/cancelled")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestClient_ServerHangsUp(t *testing.T) {
+	s := newFakeServer()
+	s.handle("tools/list", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		s.out.Close() // hang up without answering
+		return nil, nil
+	})
+	c := initializedClient(t, s)
+
+	if _, err := c.ListTools(context.Background()); !errors.Is(err, errConnClosed) {
+		t.Errorf("ListTools() error = %v, want connection closed", err)
+	}
+}
+
+func TestClient_Close(t *testing.T) {
+	c := initializedClient(t, newFakeServer())
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if c.IsInitialized() {
+		t.Error("IsInitialized() = true after Close")
+	}
+	if _, err := c.Initialize(context.Background()); err == nil {
+		t.Error("Initialize() after Close error = nil")
+	}
+}
+
+func TestNewClient_Process(t *testing.T) {
+	t.Setenv("MCP_CLIENT_FAKE_SERVER", "1")
+	c, err := NewClientWithArgs(os.Args[0], []string{"-test.run=^$"}, protocol.ClientInfo{Name: "test-client", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("NewClientWithArgs() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := c.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if result.ServerInfo.Name != "fake-server" {
+		t.Errorf("ServerInfo.Name = %q, want fake-server", result.ServerInfo.Name)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if c.cmd != nil {
+		t.Error("server process not reaped by Close")
+	}
+}
+
+func TestNewClient_Errors(t *testing.T) {
+	info := protocol.ClientInfo{Name: "test-client"}
+	if _, err := NewClient("", info); err == nil {
+		t.Error("NewClient(\"\") error = nil")
+	}
+	if _, err := NewClient(os.Args[0], protocol.ClientInfo{}); err == nil {
+		t.Error("NewClient() without client name error = nil")
+	}
+	if _, err := NewClient("/nonexistent/mcp-server", info); err == nil {
+		t.Error("NewClient() with missing binary error = nil")
+	}
+	if _, err := NewClientWithIO(nil, nil, info); err == nil {
+		t.Error("NewClientWithIO(nil, nil) error = nil")
+	}
+}
@@ -0,0 +1,259 @@
+// Package conformance checks that an MCP server follows the protocol, for
+// validating third-party servers with the mcp-go-core client.
+//
+// Example usage:
+//
+//	c, err := client.NewClient("/path/to/server", clientInfo)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer c.Close()
+//
+//	report := conformance.RunConformanceTests(ctx, c)
+//	fmt.Print(report)
+//	if !report.Passed() {
+//	    os.Exit(1)
+//	}
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/client"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
+)
+
+// Check names, in the order RunConformanceTests runs them
+const (
+	CheckInitialize    = "initialize"
+	CheckPing          = "ping"
+	CheckListTools     = "tools/list"
+	CheckListResources = "resources/list"
+	CheckListPrompts   = "prompts/list"
+	CheckUnknownTool   = "unknown tool"
+)
+
+// unknownToolName is called to check that servers reject unknown tools
+const unknownToolName = "mcp-go-core-conformance-nonexistent-tool"
+
+// Status is the outcome of a single check
+type Status string
+
+// Check outcomes
+const (
+	StatusPass Status = "PASS"
+	StatusFail Status = "FAIL"
+	StatusSkip Status = "SKIP" // not applicable, e.g. capability not advertised
+)
+
+// CheckResult is the outcome of one conformance check
+type CheckResult struct {
+	Name    string
+	Status  Status
+	Message string // why the check failed or was skipped
+}
+
+// ConformanceReport lists the outcome of every conformance check
+type ConformanceReport struct {
+	ProtocolVersion string // version agreed with the server ("" if initialize failed)
+	Results         []CheckResult
+}
+
+// Passed reports whether no check failed
+func (r ConformanceReport) Passed() bool {
+	return len(r.Failures()) == 0
+}
+
+// Failures returns the failed checks
+func (r ConformanceReport) Failures() []CheckResult {
+	var failed []CheckResult
+	for _, result := range r.Results {
+		if result.Status == StatusFail {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Result returns the result of the named check, or false if it did not run
+func (r ConformanceReport) Result(name string) (CheckResult, bool) {
+	for _, result := range r.Results {
+		if result.Name == name {
+			return result, true
+		}
+	}
+	return CheckResult{}, false
+}
+
+// String formats the report with one line per check
+func (r ConformanceReport) String() string {
+	var b strings.Builder
+	for _, result := range r.Results {
+		fmt.Fprintf(&b, "%s %s", result.Status, result.Name)
+		if result.Message != "" {
+			fmt.Fprintf(&b, ": %s", result.Message)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%d checks, %d failed\n", len(r.Results), len(r.Failures()))
+	return b.String()
+}
+
+// RunConformanceTests checks the server behind c and reports the outcome of
+// each check. It initializes c if needed; if that fails, the remaining
+// checks are skipped. The checks are:
+//   - initialize: succeeds with a supported protocol version and server name
+//   - ping: the server answers
+//   - tools/list, resources/list, prompts/list: succeed for each advertised
+//     capability, returning well-formed entries
+//   - unknown tool: calling a tool that does not exist fails with a
+//     JSON-RPC invalid params error
+func RunConformanceTests(ctx context.Context, c *client.Client) ConformanceReport {
+	var report ConformanceReport
+	add := func(name string, err error) {
+		if err != nil {
+			report.Results = append(report.Results, CheckResult{Name: name, Status: StatusFail, Message: err.Error()})
+			return
+		}
+		report.Results = append(report.Results, CheckResult{Name: name, Status: StatusPass})
+	}
+	skip := func(name, reason string) {
+		report.Results = append(report.Results, CheckResult{Name: name, Status: StatusSkip, Message: reason})
+	}
+
+	err := checkInitialize(ctx, c)
+	add(CheckInitialize, err)
+	if err != nil {
+		for _, name := range []string{CheckPing, CheckListTools, CheckListResources, CheckListPrompts, CheckUnknownTool} {
+			skip(name, "initialize failed")
+		}
+		return report
+	}
+	report.ProtocolVersion = c.ProtocolVersion()
+
+	add(CheckPing, c.Ping(ctx))
+
+	caps := c.ServerCapabilities()
+	if caps.Tools != nil {
+		add(CheckListTools, checkListTools(ctx, c))
+	} else {
+		skip(CheckListTools, "tools capability not advertised")
+	}
+	if caps.Resources != nil {
+		add(CheckListResources, checkListResources(ctx, c))
+	} else {
+		skip(CheckListResources, "resources capability not advertised")
+	}
+	if caps.Prompts != nil {
+		add(CheckListPrompts, checkListPrompts(ctx, c))
+	} else {
+		skip(CheckListPrompts, "prompts capability not advertised")
+	}
+	if caps.Tools != nil {
+		add(CheckUnknownTool, checkUnknownTool(ctx, c))
+	} else {
+		skip(CheckUnknownTool, "tools capability not advertised")
+	}
+
+	return report
+}
+
+// checkInitialize initializes c if needed and validates the result
+func checkInitialize(ctx context.Context, c *client.Client) error {
+	if c.IsInitialized() {
+		return nil
+	}
+	result, err := c.Initialize(ctx)
+	if err != nil {
+		return err
+	}
+	if result.ServerInfo.Name == "" {
+		return fmt.Errorf("serverInfo.name is empty")
+	}
+	if !protocol.IsSupportedProtocolVersion(result.ProtocolVersion) {
+		return fmt.Errorf("unsupported protocol version %q", result.ProtocolVersion)
+	}
+	return nil
+}
+
+// checkListTools checks that tools/list succeeds with named tools taking
+// object arguments
+func checkListTools(ctx context.Context, c *client.Client) error {
+	tools, err := c.ListTools(ctx)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(tools))
+	for i, tool := range tools {
+		if tool.Name == "" {
+			return fmt.Errorf("tool %d has no name", i)
+		}
+		if seen[tool.Name] {
+			return fmt.Errorf("tool %q is listed twice", tool.Name)
+		}
+		seen[tool.Name] = true
+		if tool.Schema.Type != "object" {
+			return fmt.Errorf("tool %q input schema type is %q, want \"object\"", tool.Name, tool.Schema.Type)
+		}
+	}
+	return nil
+}
+
+// checkListResources checks that resources/list succeeds with resources
+// that have a URI and name
+func checkListResources(ctx context.Context, c *client.Client) error {
+	resources, err := c.ListResources(ctx)
+	if err != nil {
+		return err
+	}
+	for i, resource := range resources {
+		if resource.URI == "" {
+			return fmt.Errorf("resource %d has no URI", i)
+		}
+		if resource.Name == "" {
+			return fmt.Errorf("resource %q has no name", resource.URI)
+		}
+	}
+	return nil
+}
+
+// checkListPrompts checks that prompts/list succeeds with named prompts
+func checkListPrompts(ctx context.Context, c *client.Client) error {
+	prompts, err := c.ListPrompts(ctx)
+	if err != nil {
+		return err
+	}
+	for i, prompt := range prompts {
+		if prompt.Name == "" {
+			return fmt.Errorf("prompt %d has no name", i)
+		}
+	}
+	return nil
+}
+
+// checkUnknownTool checks that calling a tool that does not exist fails with
+// the JSON-RPC invalid params error the protocol specifies. Any other
+// failure, such as a tool error result or a broken connection, fails the
+// check.
+func checkUnknownTool(ctx context.Context, c *client.Client) error {
+	_, err := c.CallTool(ctx, unknownToolName, map[string]interface{}{})
+	if err == nil {
+		return fmt.Errorf("calling unknown tool %q succeeded", unknownToolName)
+	}
+	// A timeout is not a rejection
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	var rpcErr *protocol.JSONRPCError
+	if !errors.As(err, &rpcErr) {
+		return fmt.Errorf("calling unknown tool %q did not return a JSON-RPC error: %w", unknownToolName, err)
+	}
+	if rpcErr.Code != protocol.ErrCodeInvalidParams {
+		return fmt.Errorf("calling unknown tool %q returned JSON-RPC error %d, want %d (invalid params)",
+			unknownToolName, rpcErr.Code, protocol.ErrCodeInvalidParams)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+//go:build !mcp_golang
+// +build !mcp_golang
+
+package conformance
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/client"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
+)
+
+// mockHandler answers one request method for mockServer
+type mockHandler func(params json.RawMessage) (interface{}, *protocol.JSONRPCError)
+
+// mockServer is a scripted MCP server speaking newline-delimited JSON-RPC.
+// Methods without a handler get a method-not-found error.
+type mockServer map[string]mockHandler
+
+// serve answers requests read from r on w until r is exhausted
+func (s mockServer) serve(r io.Reader, w io.WriteCloser) {
+	defer w.Close()
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var msg struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &msg) != nil || len(msg.ID) == 0 {
+			continue // notification
+		}
+
+		var response interface{}
+		if handler, ok := s[msg.Method]; !ok {
+			response = protocol.NewMethodNotFoundError(msg.ID, msg.Method)
+		} else if result, rpcErr := handler(msg.Params); rpcErr != nil {
+			response = protocol.NewErrorResponse(msg.ID, rpcErr.Code, rpcErr.Message, rpcErr.Data)
+		} else {
+			response = protocol.NewSuccessResponse(msg.ID, result)
+		}
+		data, _ := json.Marshal(response)
+		_, _ = w.Write(append(data, '\n'))
+	}
+}
+
+// connect runs the server on in-memory pipes and returns a client for it
+func (s mockServer) connect(t *testing.T) *client.Client {
+	t.Helper()
+	clientToServerR, clientToServerW := io.Pipe()
+	serverToClientR, serverToClientW := io.Pipe()
+	go s.serve(clientToServerR, serverToClientW)
+
+	c, err := client.NewClientWithIO(serverToClientR, clientToServerW, protocol.ClientInfo{Name: "conformance-test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("NewClientWithIO() error = %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// result returns a handler answering with result
+func result(result interface{}) mockHandler {
+	return func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		return result, nil
+	}
+}
+
+// compliantServer returns a server that passes every check
+func compliantServer() mockServer {
+	return mockServer{
+		"initialize": result(protocol.InitializeResult{
+			ProtocolVersion: protocol.LatestProtocolVersion,
+			Capabilities: protocol.ServerCapabilities{
+				Tools:     &protocol.ToolsCapability{},
+				Resources: &protocol.ResourcesCapability{},
+			},
+			ServerInfo: protocol.ServerInfo{Name: "compliant", Version: "1.0.0"},
+		}),
+		"ping": result(struct{}{}),
+		"tools/list": result(map[string]interface{}{
+			"tools": []map[string]interface{}{
+				{"name": "echo", "inputSchema": map[string]interface{}{"type": "object"}},
+			},
+		}),
+		"tools/call": func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+			return nil, &protocol.JSONRPCError{Code: protocol.ErrCodeInvalidParams, Message: "unknown tool"}
+		},
+		"resources/list": result(map[string]interface{}{
+			"resources": []map[string]interface{}{{"uri": "example://info", "name": "Info"}},
+		}),
+	}
+}
+
+func TestRunConformanceTests_Compliant(t *testing.T) {
+	c := compliantServer().connect(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	report := RunConformanceTests(ctx, c)
+	if !report.Passed() {
+		t.Fatalf("compliant server failed:\n%s", report)
+	}
+	if report.ProtocolVersion != protocol.LatestProtocolVersion {
+		t.Errorf("ProtocolVersion = %q, want %q", report.ProtocolVersion, protocol.LatestProtocolVersion)
+	}
+
+	want := map[string]Status{
+		CheckInitialize:    StatusPass,
+		CheckPing:          StatusPass,
+		CheckListTools:     StatusPass,
+		CheckListResources: StatusPass,
+		CheckListPrompts:   StatusSkip, // prompts not advertised
+		CheckUnknownTool:   StatusPass,
+	}
+	if len(report.Results) != len(want) {
+		t.Errorf("got %d results, want %d:\n%s", len(report.Results), len(want), report)
+	}
+	for name, status := range want {
+		if got, ok := report.Result(name); !ok || got.Status != status {
+			t.Errorf("%s: got %+v, want %s", name, got, status)
+		}
+	}
+}
+
+func TestRunConformanceTests_NonCompliant(t *testing.T) {
+	s := compliantServer()
+	delete(s, "ping")
+	s["tools/list"] = result(map[string]interface{}{
+		"tools": []map[string]interface{}{
+			{"name": "", "inputSchema": map[string]interface{}{"type": "object"}},
+		},
+	})
+	s["tools/call"] = result(map[string]interface{}{
+		"content": []map[string]interface{}{{"type": "text", "text": "ok"}},
+	})
+	c := s.connect(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	report := RunConformanceTests(ctx, c)
+	if report.Passed() {
+		t.Fatalf("non-compliant server passed:\n%s", report)
+	}
+
+	failed := make(map[string]bool)
+	for _, result := range report.Failures() {
+		failed[result.Name] = true
+	}
+	for _, name := range []string{CheckPing, CheckListTools, CheckUnknownTool} {
+		if !failed[name] {
+			t.Errorf("%s did not fail:\n%s", name, report)
+		}
+	}
+	if failed[CheckInitialize] || failed[CheckListResources] {
+		t.Errorf("unexpected failures:\n%s", report)
+	}
+	if !strings.Contains(report.String(), "3 failed") {
+		t.Errorf("report summary missing failure count:\n%s", report)
+	}
+}
+
+func TestRunConformanceTests_UnknownToolRejection(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  mockHandler
+		wantPass bool
+	}{
+		{
+			name: "invalid params error",
+			handler: func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+				return nil, &protocol.JSONRPCError{Code: protocol.ErrCodeInvalidParams, Message: "unknown tool"}
+			},
+			wantPass: true,
+		},
+		{
+			name: "internal error",
+			handler: func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+				return nil, &protocol.JSONRPCError{Code: protocol.ErrCodeInternalError, Message: "boom"}
+			},
+		},
+		{
+			name: "tool error result",
+			handler: result(map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": "unknown tool"}},
+				"isError": true,
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := compliantServer()
+			s["tools/call"] = tt.handler
+			c := s.connect(t)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			report := RunConformanceTests(ctx, c)
+			got, _ := report.Result(CheckUnknownTool)
+			if (got.Status == StatusPass) != tt.wantPass {
+				t.Errorf("unknown tool: got %+v, want pass = %v", got, tt.wantPass)
+			}
+		})
+	}
+}
+
+func TestRunConformanceTests_InitializeFails(t *testing.T) {
+	s := compliantServer()
+	s["initialize"] = result(protocol.InitializeResult{
+		ProtocolVersion: protocol.LatestProtocolVersion,
+		ServerInfo:      protocol.ServerInfo{Version: "1.0.0"}, // no name
+	})
+	c := s.connect(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	report := RunConformanceTests(ctx, c)
+	if got, _ := report.Result(CheckInitialize); got.Status != StatusFail {
+		t.Errorf("initialize: got %+v, want FAIL", got)
+	}
+	for _, result := range report.Results[1:] {
+		if result.Status != StatusSkip {
+			t.Errorf("%s: got %s after initialize failed, want SKIP", result.Name, result.Status)
+		}
+	}
+}
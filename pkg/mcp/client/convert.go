@@ -127,6 +127,28 @@ func ConvertExternalTextContentSlice(externalContents []interface{}) ([]types.Te
 	return result, nil
 }
 
+// ConvertExternalServerCapabilities converts the server capabilities from an
+// external client library's initialize response (or the raw JSON object) to
+// mcp-go-core protocol.ServerCapabilities.
+//
+// A capability the server did not advertise is left nil.
+func ConvertExternalServerCapabilities(externalCapabilities interface{}) (protocol.ServerCapabilities, error) {
+	var capabilities protocol.ServerCapabilities
+	if externalCapabilities == nil {
+		return capabilities, nil
+	}
+
+	jsonData, err := json.Marshal(externalCapabilities)
+	if err != nil {
+		return capabilities, fmt.Errorf("failed to marshal external capabilities: %w", err)
+	}
+	if err := json.Unmarshal(jsonData, &capabilities); err != nil {
+		return capabilities, fmt.Errorf("failed to unmarshal capabilities: %w", err)
+	}
+
+	return capabilities, nil
+}
+
 // ConvertClientInfoToExternal converts mcp-go-core protocol.ClientInfo to a format
 // suitable for external client libraries.
 func ConvertClientInfoToExternal(clientInfo protocol.ClientInfo) map[string]interface{} {
@@ -144,10 +166,18 @@ func ConvertInitializeParamsToExternal(params protocol.InitializeParams) map[str
 		"clientInfo":      ConvertClientInfoToExternal(params.ClientInfo),
 	}
 
-	if params.Capabilities.Experimental != nil && len(params.Capabilities.Experimental) > 0 {
-		result["capabilities"] = map[string]interface{}{
-			"experimental": params.Capabilities.Experimental,
-		}
+	capabilities := map[string]interface{}{}
+	if params.Capabilities.Roots != nil {
+		capabilities["roots"] = map[string]interface{}{"listChanged": params.Capabilities.Roots.ListChanged}
+	}
+	if params.Capabilities.Sampling != nil {
+		capabilities["sampling"] = map[string]interface{}{}
+	}
+	if len(params.Capabilities.Experimental) > 0 {
+		capabilities["experimental"] = params.Capabilities.Experimental
+	}
+	if len(capabilities) > 0 {
+		result["capabilities"] = capabilities
 	}
 
 	return result
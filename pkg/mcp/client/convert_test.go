@@ -323,6 +323,80 @@ func TestConvertClientInfoToExternal(t *testing.T) {
 	}
 }
 
+func TestConvertExternalServerCapabilities(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+
+	// Shaped like mcp-golang's ServerCapabilities (pointer flags)
+	type externalTools struct {
+		ListChanged *bool `json:"listChanged,omitempty"`
+	}
+	type externalResources struct {
+		ListChanged *bool `json:"listChanged,omitempty"`
+		Subscribe   *bool `json:"subscribe,omitempty"`
+	}
+	type externalCapabilities struct {
+		Logging   map[string]interface{} `json:"logging,omitempty"`
+		Prompts   *externalTools         `json:"prompts,omitempty"`
+		Resources *externalResources     `json:"resources,omitempty"`
+		Tools     *externalTools         `json:"tools,omitempty"`
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		caps, err := ConvertExternalServerCapabilities(nil)
+		if err != nil {
+			t.Fatalf("ConvertExternalServerCapabilities() error = %v", err)
+		}
+		if caps.Tools != nil || caps.Resources != nil || caps.Prompts != nil {
+			t.Errorf("capabilities = %+v, want none", caps)
+		}
+	})
+
+	t.Run("external struct", func(t *testing.T) {
+		caps, err := ConvertExternalServerCapabilities(externalCapabilities{
+			Logging:   map[string]interface{}{"level": "info"},
+			Resources: &externalResources{Subscribe: boolPtr(true)},
+			Tools:     &externalTools{ListChanged: boolPtr(true)},
+		})
+		if err != nil {
+			t.Fatalf("ConvertExternalServerCapabilities() error = %v", err)
+		}
+		if caps.Tools == nil || !caps.Tools.ListChanged {
+			t.Errorf("Tools = %+v, want listChanged", caps.Tools)
+		}
+		if caps.Resources == nil || !caps.Resources.Subscribe || caps.Resources.ListChanged {
+			t.Errorf("Resources = %+v, want subscribe only", caps.Resources)
+		}
+		if caps.Logging == nil {
+			t.Error("Logging = nil, want present")
+		}
+		if caps.Prompts != nil {
+			t.Errorf("Prompts = %+v, want nil (not advertised)", caps.Prompts)
+		}
+	})
+
+	t.Run("raw JSON", func(t *testing.T) {
+		caps, err := ConvertExternalServerCapabilities(json.RawMessage(`{"prompts":{"listChanged":true},"completions":{}}`))
+		if err != nil {
+			t.Fatalf("ConvertExternalServerCapabilities() error = %v", err)
+		}
+		if caps.Prompts == nil || !caps.Prompts.ListChanged {
+			t.Errorf("Prompts = %+v, want listChanged", caps.Prompts)
+		}
+		if caps.Completions == nil {
+			t.Error("Completions = nil, want present")
+		}
+		if caps.Tools != nil || caps.Resources != nil || caps.Logging != nil {
+			t.Errorf("unexpected capability: %+v", caps)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := ConvertExternalServerCapabilities(json.RawMessage(`{"tools":[]}`)); err == nil {
+			t.Error("ConvertExternalServerCapabilities() with tools array error = nil, want error")
+		}
+	})
+}
+
 // Helper function for tests
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
+)
+
+// fakeHandler answers one request method for fakeServer
+type fakeHandler func(params json.RawMessage) (interface{}, *protocol.JSONRPCError)
+
+// fakeServer is a scripted MCP server speaking newline-delimited JSON-RPC
+type fakeServer struct {
+	mu       sync.Mutex
+	handlers map[string]fakeHandler
+	received []rpcMessage               // every message from the client, in order
+	waiting  map[string]chan rpcMessage // request ID -> response waiter
+	nextID   int
+
+	writeMu sync.Mutex
+	out     io.WriteCloser
+}
+
+// newFakeServer returns a server answering initialize and ping
+func newFakeServer() *fakeServer {
+	s := &fakeServer{
+		handlers: make(map[string]fakeHandler),
+		waiting:  make(map[string]chan rpcMessage),
+	}
+	s.handle("initialize", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		return protocol.InitializeResult{
+			ProtocolVersion: protocol.LatestProtocolVersion,
+			Capabilities:    protocol.ServerCapabilities{Tools: &protocol.ToolsCapability{}},
+			ServerInfo:      protocol.ServerInfo{Name: "fake-server", Version: "1.0.0"},
+		}, nil
+	})
+	s.handle("ping", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		return struct{}{}, nil
+	})
+	return s
+}
+
+// handle sets the handler for method
+func (s *fakeServer) handle(method string, handler fakeHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// serve answers requests read from r on w until r is exhausted, then
+// closes w
+func (s *fakeServer) serve(r io.Reader, w io.WriteCloser) {
+	s.writeMu.Lock()
+	s.out = w
+	s.writeMu.Unlock()
+	defer w.Close()
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var msg rpcMessage
+			if json.Unmarshal(line, &msg) == nil {
+				s.mu.Lock()
+				s.received = append(s.received, msg)
+				handler := s.handlers[msg.Method]
+				waiter := s.waiting[string(msg.ID)]
+				if msg.Method == "" {
+					delete(s.waiting, string(msg.ID))
+				}
+				s.mu.Unlock()
+
+				if msg.Method == "" && waiter != nil {
+					waiter <- msg
+				}
+
+				if msg.Method != "" && len(msg.ID) > 0 {
+					go s.answer(msg, handler)
+				}
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// answer runs handler for a request and writes the response
+func (s *fakeServer) answer(msg rpcMessage, handler fakeHandler) {
+	id := json.RawMessage(msg.ID)
+	if handler == nil {
+		s.write(protocol.NewMethodNotFoundError(id, msg.Method))
+		return
+	}
+	result, rpcErr := handler(msg.Params)
+	if rpcErr != nil {
+		s.write(protocol.NewErrorResponse(id, rpcErr.Code, rpcErr.Message, rpcErr.Data))
+		return
+	}
+	s.write(protocol.NewSuccessResponse(id, result))
+}
+
+// write sends a message to the client
+func (s *fakeServer) write(message interface{}) {
+	data, _ := json.Marshal(message)
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_, _ = s.out.Write(append(data, '\n'))
+}
+
+// request sends a request to the client and returns its response
+func (s *fakeServer) request(t *testing.T, method string, params interface{}) rpcMessage {
+	t.Helper()
+	s.mu.Lock()
+	s.nextID++
+	id := fmt.Sprintf(`"srv-%d"`, s.nextID)
+	response := make(chan rpcMessage, 1)
+	s.waiting[id] = response
+	s.mu.Unlock()
+
+	req := map[string]interface{}{"jsonrpc": "2.0", "id": json.RawMessage(id), "method": method}
+	if params != nil {
+		req["params"] = params
+	}
+	s.write(req)
+
+	select {
+	case msg := <-response:
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatalf("client did not answer the %s request", method)
+		return rpcMessage{}
+	}
+}
+
+// messages returns the messages received with method
+func (s *fakeServer) messages(method string) []rpcMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matched []rpcMessage
+	for _, msg := range s.received {
+		if msg.Method == method {
+			matched = append(matched, msg)
+		}
+	}
+	return matched
+}
+
+// waitMessages waits until n messages with method have been received and
+// returns them; it fails the test if more arrive or the wait times out
+func (s *fakeServer) waitMessages(t *testing.T, method string, n int) []rpcMessage {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		msgs := s.messages(method)
+		if len(msgs) > n {
+			t.Fatalf("server received %d %s messages, want %d", len(msgs), method, n)
+		}
+		if len(msgs) == n {
+			return msgs
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("server received %d %s messages, want %d", len(msgs), method, n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// connect starts the server on in-memory pipes and returns a client for it.
+// The client is closed when the test ends.
+func (s *fakeServer) connect(t *testing.T) *Client {
+	t.Helper()
+	clientToServerR, clientToServerW := io.Pipe()
+	serverToClientR, serverToClientW := io.Pipe()
+	go s.serve(clientToServerR, serverToClientW)
+
+	c, err := NewClientWithIO(serverToClientR, clientToServerW, protocol.ClientInfo{Name: "test-client", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("NewClientWithIO() error = %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
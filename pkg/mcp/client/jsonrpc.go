@@ -0,0 +1,280 @@
+// Package client JSON-RPC 2.0 connection used by the default (native) client.
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
+)
+
+// errConnClosed is returned for calls on a closed connection
+var errConnClosed = errors.New("connection closed")
+
+// requestHandler answers a request sent by the server to the client
+type requestHandler func(ctx context.Context, params json.RawMessage) (interface{}, error)
+
+// rpcMessage is any JSON-RPC 2.0 message: a request (Method and ID), a
+// notification (Method only) or a response (ID with Result or Error)
+type rpcMessage struct {
+	JSONRPC string                 `json:"jsonrpc"`
+	ID      json.RawMessage        `json:"id,omitempty"`
+	Method  string                 `json:"method,omitempty"`
+	Params  json.RawMessage        `json:"params,omitempty"`
+	Result  json.RawMessage        `json:"result,omitempty"`
+	Error   *protocol.JSONRPCError `json:"error,omitempty"`
+}
+
+// rpcConn is a JSON-RPC 2.0 connection using the MCP stdio framing: one JSON
+// message per line. Requests from the server are answered by the handlers
+// registered with handle ("ping" is built in).
+type rpcConn struct {
+	reader *bufio.Reader
+	writer io.Writer
+	closer io.Closer // closes the writer side; may be nil
+
+	writeMu   sync.Mutex // serializes message writes
+	closeOnce sync.Once
+
+	mu       sync.Mutex
+	nextID   int64
+	pending  map[int64]chan *rpcMessage
+	handlers map[string]requestHandler
+	closed   bool
+	err      error // why the connection ended
+
+	done chan struct{} // closed when the read loop exits
+}
+
+// newRPCConn starts a connection reading from r and writing to w.
+// If w is an io.Closer it is closed by close.
+func newRPCConn(r io.Reader, w io.Writer) *rpcConn {
+	c := &rpcConn{
+		reader:   bufio.NewReader(r),
+		writer:   w,
+		pending:  make(map[int64]chan *rpcMessage),
+		handlers: make(map[string]requestHandler),
+		done:     make(chan struct{}),
+	}
+	if closer, ok := w.(io.Closer); ok {
+		c.closer = closer
+	}
+	c.handlers["ping"] = func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		return struct{}{}, nil
+	}
+	go c.readLoop()
+	return c
+}
+
+// handle registers the handler for requests from the server with method
+func (c *rpcConn) handle(method string, handler requestHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers[method] = handler
+}
+
+// call sends a request and decodes its result into result (if non-nil).
+// A JSON-RPC error response is returned as *protocol.JSONRPCError.
+func (c *rpcConn) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	c.mu.Lock()
+	if c.closed {
+		err := c.closedErrLocked()
+		c.mu.Unlock()
+		return err
+	}
+	c.nextID++
+	id := c.nextID
+	respCh := make(chan *rpcMessage, 1)
+	c.pending[id] = respCh
+	c.mu.Unlock()
+
+	if err := c.send(id, method, params); err != nil {
+		c.forget(id)
+		return fmt.Errorf("failed to send %s request: %w", method, err)
+	}
+
+	select {
+	case resp := <-respCh:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result != nil {
+			if err := json.Unmarshal(resp.Result, result); err != nil {
+				return fmt.Errorf("invalid %s result: %w", method, err)
+			}
+		}
+		return nil
+	case <-c.done:
+		return c.closedErr()
+	case <-ctx.Done():
+		c.forget(id)
+		// Let the server stop working on it (best effort)
+		_ = c.notify("notifications/cancelled", map[string]interface{}{
+			"requestId": id,
+			"reason":    ctx.Err().Error(),
+		})
+		return ctx.Err()
+	}
+}
+
+// notify sends a notification (a request without an ID)
+func (c *rpcConn) notify(method string, params interface{}) error {
+	return c.send(0, method, params)
+}
+
+// send writes a request, or a notification if id is 0
+func (c *rpcConn) send(id int64, method string, params interface{}) error {
+	req := protocol.JSONRPCRequest{JSONRPC: "2.0", Method: method}
+	if id != 0 {
+		req.ID = id
+	}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		req.Params = data
+	}
+	return c.write(req)
+}
+
+// write sends one message followed by a newline
+func (c *rpcConn) write(message interface{}) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err = c.writer.Write(append(data, '\n'))
+	return err
+}
+
+// forget drops a pending request whose response is no longer wanted
+func (c *rpcConn) forget(id int64) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+// readLoop dispatches incoming messages until the reader fails
+func (c *rpcConn) readLoop() {
+	var err error
+	for {
+		var line []byte
+		line, err = c.reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			c.dispatch(line)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	c.mu.Lock()
+	c.closed = true
+	if c.err == nil {
+		if err == io.EOF {
+			err = errConnClosed
+		}
+		c.err = err
+	}
+	c.mu.Unlock()
+	close(c.done)
+}
+
+// dispatch routes one incoming message; malformed messages are ignored
+func (c *rpcConn) dispatch(line []byte) {
+	var msg rpcMessage
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return
+	}
+
+	switch {
+	case msg.Method != "" && len(msg.ID) > 0:
+		go c.answer(msg)
+	case msg.Method != "":
+		// Notifications from the server are not used yet
+	default:
+		id, err := strconv.ParseInt(string(msg.ID), 10, 64)
+		if err != nil {
+			return
+		}
+		c.mu.Lock()
+		respCh, ok := c.pending[id]
+		delete(c.pending, id)
+		c.mu.Unlock()
+		if ok {
+			respCh <- &msg
+		}
+	}
+}
+
+// answer runs the handler for a request from the server and sends its response
+func (c *rpcConn) answer(msg rpcMessage) {
+	id := json.RawMessage(msg.ID)
+
+	c.mu.Lock()
+	handler, ok := c.handlers[msg.Method]
+	c.mu.Unlock()
+	if !ok {
+		_ = c.write(protocol.NewMethodNotFoundError(id, msg.Method))
+		return
+	}
+
+	result, err := handler(context.Background(), msg.Params)
+	if err != nil {
+		var rpcErr *protocol.JSONRPCError
+		if errors.As(err, &rpcErr) {
+			_ = c.write(protocol.NewErrorResponse(id, rpcErr.Code, rpcErr.Message, rpcErr.Data))
+		} else {
+			_ = c.write(protocol.NewInternalError(id, err.Error()))
+		}
+		return
+	}
+	if result == nil {
+		result = struct{}{} // a response must carry a result
+	}
+	_ = c.write(protocol.NewSuccessResponse(id, result))
+}
+
+// close closes the writer side; the read loop ends when the peer hangs up
+func (c *rpcConn) close() error {
+	c.mu.Lock()
+	c.closed = true
+	if c.err == nil {
+		c.err = errConnClosed
+	}
+	c.mu.Unlock()
+
+	var err error
+	c.closeOnce.Do(func() {
+		if c.closer != nil {
+			err = c.closer.Close()
+		}
+	})
+	return err
+}
+
+// closedErr describes why the connection is unusable
+func (c *rpcConn) closedErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closedErrLocked()
+}
+
+// closedErrLocked is closedErr for callers holding c.mu
+func (c *rpcConn) closedErrLocked() error {
+	if c.err != nil && c.err != errConnClosed {
+		return fmt.Errorf("%w: %v", errConnClosed, c.err)
+	}
+	return errConnClosed
+}
@@ -4,7 +4,10 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
 	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
@@ -49,18 +52,86 @@ func NewTestClient(config TestServerConfig) (*Client, error) {
 //
 // This is useful for integration tests.
 func TestToolExecution(ctx context.Context, c *Client, toolName string, args map[string]interface{}) ([]types.TextContent, error) {
+	return TestToolExecutionWithOptions(ctx, c, toolName, args, ExecOptions{})
+}
+
+// ExecOptions controls TestToolExecutionWithOptions.
+type ExecOptions struct {
+	// Timeout bounds each attempt; 0 means only ctx bounds it
+	Timeout time.Duration
+
+	// Retries is how many more attempts to make after a failed one
+	Retries int
+}
+
+// TestToolExecutionWithOptions is TestToolExecution with a per-attempt
+// timeout and retries, for tools that are slow or flaky. An attempt fails if
+// the call errors, the tool reports an error, or it times out. Retrying
+// stops early once ctx is done; the last attempt's error is returned.
+func TestToolExecutionWithOptions(ctx context.Context, c *Client, toolName string, args map[string]interface{}, opts ExecOptions) ([]types.TextContent, error) {
 	if !c.IsInitialized() {
 		if _, err := c.Initialize(ctx); err != nil {
 			return nil, fmt.Errorf("failed to initialize client: %w", err)
 		}
 	}
 
-	result, err := c.CallTool(ctx, toolName, args)
-	if err != nil {
-		return nil, fmt.Errorf("tool execution failed: %w", err)
+	attempts := 0
+	var lastErr error
+	for attempts <= opts.Retries {
+		attempts++
+		result, err := callToolWithTimeout(ctx, c, toolName, args, opts.Timeout)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if attempts > 1 {
+		return nil, fmt.Errorf("tool execution failed after %d attempts: %w", attempts, lastErr)
 	}
+	return nil, fmt.Errorf("tool execution failed: %w", lastErr)
+}
 
-	return result, nil
+// callToolWithTimeout calls a tool, giving up after timeout if it is positive
+func callToolWithTimeout(ctx context.Context, c *Client, toolName string, args map[string]interface{}, timeout time.Duration) ([]types.TextContent, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return c.CallTool(ctx, toolName, args)
+}
+
+// DefaultAliveTimeout is how long AssertServerAlive waits for a ping reply
+// when ctx has no deadline
+const DefaultAliveTimeout = 5 * time.Second
+
+// AssertServerAlive asserts that the server answers an MCP ping, initializing
+// the client first if needed. It waits until ctx's deadline, or
+// DefaultAliveTimeout if ctx has none.
+//
+// This is useful as a precondition in integration tests.
+func AssertServerAlive(ctx context.Context, c *Client) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultAliveTimeout)
+		defer cancel()
+	}
+
+	if !c.IsInitialized() {
+		if _, err := c.Initialize(ctx); err != nil {
+			return fmt.Errorf("server not alive: failed to initialize client: %w", err)
+		}
+	}
+
+	if err := c.Ping(ctx); err != nil {
+		return fmt.Errorf("server not alive: %w", err)
+	}
+
+	return nil
 }
 
 // AssertToolExists asserts that a tool exists in the server's tool list
@@ -106,6 +177,43 @@ func AssertToolExists(ctx context.Context, c *Client, toolName string, expectedS
 	return nil
 }
 
+// AssertToolErrorContains calls a tool and asserts that it reports an error
+// (a result with isError set) whose text contains substr, initializing the
+// client first if needed.
+//
+// Returns an error if:
+// - The call fails for another reason, e.g. an unknown tool
+// - The tool succeeds
+// - The error text doesn't contain substr
+func AssertToolErrorContains(ctx context.Context, c *Client, toolName string, args map[string]interface{}, substr string) error {
+	if !c.IsInitialized() {
+		if _, err := c.Initialize(ctx); err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+	}
+
+	result, err := c.CallTool(ctx, toolName, args)
+	if err == nil {
+		return fmt.Errorf("tool %q succeeded, expected an error containing %q (result: %v)", toolName, substr, result)
+	}
+
+	var toolErr *ToolError
+	if !errors.As(err, &toolErr) {
+		return fmt.Errorf("tool %q call failed without a tool error: %w", toolName, err)
+	}
+
+	texts := make([]string, 0, len(toolErr.Content))
+	for _, content := range toolErr.Content {
+		texts = append(texts, content.Text)
+	}
+	message := strings.Join(texts, "\n")
+	if !strings.Contains(message, substr) {
+		return fmt.Errorf("tool %q error %q does not contain %q", toolName, message, substr)
+	}
+
+	return nil
+}
+
 // TestServerCapabilities tests basic server capabilities.
 //
 // This function tests:
@@ -157,3 +265,69 @@ func TestServerCapabilities(ctx context.Context, c *Client) (*ServerCapabilities
 
 	return capabilities, nil
 }
+
+// Capability names a server capability for RequireCapability. Names other
+// than the constants below refer to experimental capabilities.
+type Capability string
+
+// Standard server capabilities
+const (
+	CapabilityTools       Capability = "tools"
+	CapabilityResources   Capability = "resources"
+	CapabilitySubscribe   Capability = "resources.subscribe"
+	CapabilityPrompts     Capability = "prompts"
+	CapabilityLogging     Capability = "logging"
+	CapabilityCompletions Capability = "completions"
+)
+
+// HasCapability reports whether caps advertises capability
+func HasCapability(caps protocol.ServerCapabilities, capability Capability) bool {
+	switch capability {
+	case CapabilityTools:
+		return caps.Tools != nil
+	case CapabilityResources:
+		return caps.Resources != nil
+	case CapabilitySubscribe:
+		return caps.Resources != nil && caps.Resources.Subscribe
+	case CapabilityPrompts:
+		return caps.Prompts != nil
+	case CapabilityLogging:
+		return caps.Logging != nil
+	case CapabilityCompletions:
+		return caps.Completions != nil
+	default:
+		_, ok := caps.Experimental[string(capability)]
+		return ok
+	}
+}
+
+// SkipT is the part of testing.TB used by RequireCapability, so this
+// package doesn't import testing into the binaries that use the client.
+// *testing.T and *testing.B implement it.
+type SkipT interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+	Skipf(format string, args ...interface{})
+}
+
+// RequireCapability skips the test unless the server advertises capability,
+// initializing the client first if needed. It fails the test if
+// initialization fails.
+//
+// Example:
+//
+//	client.RequireCapability(t, c, client.CapabilityPrompts)
+//	prompts, err := c.ListPrompts(ctx)
+func RequireCapability(t SkipT, c *Client, capability Capability) {
+	t.Helper()
+	if !c.IsInitialized() {
+		if _, err := c.Initialize(context.Background()); err != nil {
+			t.Fatalf("failed to initialize client: %v", err)
+			return
+		}
+	}
+
+	if !HasCapability(c.ServerCapabilities(), capability) {
+		t.Skipf("server does not advertise the %q capability", capability)
+	}
+}
@@ -0,0 +1,260 @@
+//go:build !mcp_golang
+// +build !mcp_golang
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
+)
+
+func TestAssertServerAlive(t *testing.T) {
+	s := newFakeServer()
+	c := s.connect(t)
+
+	// Initializes the client if needed
+	if err := AssertServerAlive(context.Background(), c); err != nil {
+		t.Fatalf("AssertServerAlive() error = %v", err)
+	}
+	if !c.IsInitialized() {
+		t.Error("AssertServerAlive() did not initialize the client")
+	}
+	if n := len(s.messages("ping")); n != 1 {
+		t.Errorf("server received %d pings, want 1", n)
+	}
+}
+
+func TestAssertServerAlive_Unresponsive(t *testing.T) {
+	s := newFakeServer()
+	block := make(chan struct{})
+	defer close(block)
+	s.handle("ping", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		<-block
+		return struct{}{}, nil
+	})
+	c := initializedClient(t, s)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := AssertServerAlive(ctx, c)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("AssertServerAlive() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("AssertServerAlive() took %v, want about 50ms", elapsed)
+	}
+}
+
+func TestAssertServerAlive_ServerGone(t *testing.T) {
+	s := newFakeServer()
+	c := initializedClient(t, s)
+	s.out.Close()
+
+	if err := AssertServerAlive(context.Background(), c); !errors.Is(err, errConnClosed) {
+		t.Errorf("AssertServerAlive() error = %v, want connection closed", err)
+	}
+}
+
+func TestTestToolExecutionWithOptions_Retries(t *testing.T) {
+	s := newFakeServer()
+	var calls atomic.Int32
+	s.handle("tools/call", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		if calls.Add(1) < 3 {
+			return map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": "temporarily unavailable"}},
+				"isError": true,
+			}, nil
+		}
+		return map[string]interface{}{
+			"content": []map[string]interface{}{{"type": "text", "text": "ok"}},
+		}, nil
+	})
+	c := s.connect(t)
+
+	// Not enough retries
+	_, err := TestToolExecutionWithOptions(context.Background(), c, "flaky", nil, ExecOptions{Retries: 1})
+	var toolErr *ToolError
+	if !errors.As(err, &toolErr) || !strings.Contains(err.Error(), "after 2 attempts") {
+		t.Fatalf("TestToolExecutionWithOptions() error = %v, want ToolError after 2 attempts", err)
+	}
+
+	calls.Store(0)
+	result, err := TestToolExecutionWithOptions(context.Background(), c, "flaky", nil, ExecOptions{Retries: 2})
+	if err != nil {
+		t.Fatalf("TestToolExecutionWithOptions() error = %v", err)
+	}
+	if len(result) != 1 || result[0].Text != "ok" {
+		t.Errorf("result = %+v, want ok", result)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("tool called %d times, want 3", n)
+	}
+}
+
+func TestTestToolExecutionWithOptions_Timeout(t *testing.T) {
+	s := newFakeServer()
+	block := make(chan struct{})
+	defer close(block)
+	s.handle("tools/call", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		<-block
+		return nil, nil
+	})
+	c := initializedClient(t, s)
+
+	start := time.Now()
+	_, err := TestToolExecutionWithOptions(context.Background(), c, "slow", nil, ExecOptions{Timeout: 30 * time.Millisecond, Retries: 2})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("TestToolExecutionWithOptions() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("took %v, want about 90ms", elapsed)
+	}
+	s.waitMessages(t, "tools/call", 3)
+}
+
+func TestTestToolExecutionWithOptions_ContextDone(t *testing.T) {
+	s := newFakeServer()
+	block := make(chan struct{})
+	defer close(block)
+	s.handle("tools/call", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		<-block
+		return nil, nil
+	})
+	c := initializedClient(t, s)
+
+	// Retries stop once the caller's context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	_, err := TestToolExecutionWithOptions(ctx, c, "slow", nil, ExecOptions{Retries: 5})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("TestToolExecutionWithOptions() error = %v, want deadline exceeded", err)
+	}
+	s.waitMessages(t, "tools/call", 1)
+}
+
+func TestAssertToolErrorContains(t *testing.T) {
+	s := newFakeServer()
+	s.handle("tools/call", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		var req struct {
+			Name string `json:"name"`
+		}
+		_ = json.Unmarshal(params, &req)
+		switch req.Name {
+		case "fail":
+			return map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": "division by zero"}},
+				"isError": true,
+			}, nil
+		case "ok":
+			return map[string]interface{}{
+				"content": []map[string]interface{}{{"type": "text", "text": "fine"}},
+			}, nil
+		default:
+			return nil, &protocol.JSONRPCError{Code: protocol.ErrCodeInvalidParams, Message: "unknown tool"}
+		}
+	})
+	c := s.connect(t)
+	ctx := context.Background()
+
+	if err := AssertToolErrorContains(ctx, c, "fail", nil, "by zero"); err != nil {
+		t.Errorf("AssertToolErrorContains(fail, by zero) error = %v", err)
+	}
+
+	tests := []struct {
+		tool, substr, wantErr string
+	}{
+		{"fail", "overflow", `does not contain "overflow"`},
+		{"ok", "by zero", "succeeded"},
+		{"missing", "unknown", "without a tool error"},
+	}
+	for _, tt := range tests {
+		err := AssertToolErrorContains(ctx, c, tt.tool, nil, tt.substr)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("AssertToolErrorContains(%s, %s) error = %v, want %q", tt.tool, tt.substr, err, tt.wantErr)
+		}
+	}
+}
+
+// recordingTB records Skipf and Fatalf calls instead of stopping the test
+type recordingTB struct {
+	testing.TB
+	skipped string
+	fatal   string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Skipf(format string, args ...interface{}) {
+	r.skipped = fmt.Sprintf(format, args...)
+}
+
+func (r *recordingTB) Fatalf(format string, args ...interface{}) {
+	r.fatal = fmt.Sprintf(format, args...)
+}
+
+func TestRequireCapability(t *testing.T) {
+	s := newFakeServer()
+	s.handle("initialize", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		return json.RawMessage(`{"protocolVersion":"2025-06-18","capabilities":{"tools":{},"resources":{"subscribe":false},"experimental":{"x-batch":{}}},"serverInfo":{"name":"fake"}}`), nil
+	})
+	c := s.connect(t)
+
+	tests := []struct {
+		capability Capability
+		proceed    bool
+	}{
+		{CapabilityTools, true},
+		{CapabilityResources, true},
+		{CapabilitySubscribe, false},
+		{CapabilityPrompts, false},
+		{CapabilityLogging, false},
+		{CapabilityCompletions, false},
+		{"x-batch", true},
+		{"x-other", false},
+	}
+	for _, tt := range tests {
+		rec := &recordingTB{TB: t}
+		RequireCapability(rec, c, tt.capability)
+		if rec.fatal != "" {
+			t.Fatalf("RequireCapability(%q) failed: %s", tt.capability, rec.fatal)
+		}
+		if proceeded := rec.skipped == ""; proceeded != tt.proceed {
+			t.Errorf("RequireCapability(%q) proceeded = %v, want %v", tt.capability, proceeded, tt.proceed)
+		}
+		if !tt.proceed && !strings.Contains(rec.skipped, string(tt.capability)) {
+			t.Errorf("skip message %q does not name the capability", rec.skipped)
+		}
+	}
+
+	// Initialized once, on first use
+	s.waitMessages(t, "initialize", 1)
+}
+
+func TestRequireCapability_InitializeFails(t *testing.T) {
+	s := newFakeServer()
+	s.handle("initialize", func(params json.RawMessage) (interface{}, *protocol.JSONRPCError) {
+		return nil, &protocol.JSONRPCError{Code: protocol.ErrCodeInternalError, Message: "boom"}
+	})
+	c := s.connect(t)
+
+	rec := &recordingTB{TB: t}
+	RequireCapability(rec, c, CapabilityTools)
+	if !strings.Contains(rec.fatal, "boom") || rec.skipped != "" {
+		t.Errorf("RequireCapability() fatal = %q, skipped = %q; want a failure", rec.fatal, rec.skipped)
+	}
+}
+
+func TestRequireCapability_Skips(t *testing.T) {
+	c := newFakeServer().connect(t)
+	RequireCapability(t, c, CapabilityPrompts)
+	t.Error("RequireCapability did not skip for a missing capability")
+}
@@ -9,6 +9,9 @@
 //	// Load from environment
 //	cfg, err := config.LoadBaseConfig()
 //
+//	// Or from mcp.yaml, with environment overrides
+//	cfg, err := config.LoadConfigFromFile("")
+//
 //	// Or use builder pattern
 //	cfg, err := config.NewConfigBuilder().
 //		WithName("my-server").
@@ -19,6 +22,11 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
 )
 
 // FrameworkType represents the type of MCP framework
@@ -29,23 +37,83 @@ const (
 	FrameworkGoSDK FrameworkType = "go-sdk"
 )
 
+// Transport types for BaseConfig.Transport
+const (
+	TransportStdio     = "stdio"
+	TransportSSE       = "sse"
+	TransportWebSocket = "websocket"
+)
+
 // BaseConfig holds the base server configuration
 // Projects can embed this and add their own fields
 type BaseConfig struct {
 	Framework FrameworkType `yaml:"framework" env:"MCP_FRAMEWORK"`
 	Name      string        `yaml:"name" env:"MCP_SERVER_NAME"`
 	Version   string        `yaml:"version" env:"MCP_VERSION"`
+
+	// SlowThreshold is the duration above which the logger reports
+	// operations as slow (0 = logger default)
+	SlowThreshold time.Duration `yaml:"slow_threshold" env:"MCP_SLOW_THRESHOLD"`
+
+	// Transport is how clients connect: TransportStdio (the default when
+	// empty), TransportSSE or TransportWebSocket. TransportPort and Endpoint
+	// apply to the HTTP-based transports; zero values use the transport's
+	// defaults (endpoint "/sse" or "/ws"; port 0 lets the OS choose a free
+	// port).
+	Transport     string `yaml:"transport" env:"MCP_TRANSPORT"`
+	TransportPort int    `yaml:"transport_port" env:"MCP_TRANSPORT_PORT"`
+	Endpoint      string `yaml:"endpoint" env:"MCP_ENDPOINT"`
+
+	// Security policy applied by factory.NewServerFromConfig.
+	// Zero values leave the corresponding control disabled.
+	Security SecurityConfig `yaml:"security"`
+}
+
+// SecurityConfig holds the default security policies for a server
+type SecurityConfig struct {
+	// RateLimit is the maximum tool calls per client per RateLimitWindow (0 = unlimited)
+	RateLimit int `yaml:"rate_limit" env:"MCP_RATE_LIMIT"`
+	// RateLimitWindow is the rate limit window (defaults to DefaultRateLimitWindow)
+	RateLimitWindow time.Duration `yaml:"rate_limit_window" env:"MCP_RATE_LIMIT_WINDOW"`
+	// AllowedTools restricts calls to the listed tools; empty allows all
+	AllowedTools []string `yaml:"allowed_tools" env:"MCP_ALLOWED_TOOLS"`
+	// DeniedTools rejects calls to the listed tools
+	DeniedTools []string `yaml:"denied_tools" env:"MCP_DENIED_TOOLS"`
+	// MaxRequestBytes caps the size of tool call arguments (0 = unlimited)
+	MaxRequestBytes int `yaml:"max_request_bytes" env:"MCP_MAX_REQUEST_BYTES"`
+}
+
+// DefaultRateLimitWindow is used when RateLimit is set without a window
+const DefaultRateLimitWindow = time.Minute
+
+// Enabled reports whether any security control is configured
+func (s SecurityConfig) Enabled() bool {
+	return s.RateLimit > 0 || len(s.AllowedTools) > 0 || len(s.DeniedTools) > 0 || s.MaxRequestBytes > 0
 }
 
 // LoadBaseConfig loads base configuration from environment or defaults
 func LoadBaseConfig() (*BaseConfig, error) {
-	cfg := &BaseConfig{
+	cfg := defaultBaseConfig()
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+	if err := validateConfig(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// defaultBaseConfig returns the configuration used when nothing is set
+func defaultBaseConfig() *BaseConfig {
+	return &BaseConfig{
 		Framework: FrameworkGoSDK, // Default to go-sdk
 		Name:      "mcp-server",   // Default name (projects should override)
 		Version:   "1.0.0",        // Default version
 	}
+}
 
-	// Override from environment
+// applyEnv overrides cfg with the environment variables that are set
+func applyEnv(cfg *BaseConfig) error {
 	if frameworkStr := os.Getenv("MCP_FRAMEWORK"); frameworkStr != "" {
 		cfg.Framework = FrameworkType(frameworkStr)
 	}
@@ -55,11 +123,101 @@ func LoadBaseConfig() (*BaseConfig, error) {
 	if version := os.Getenv("MCP_VERSION"); version != "" {
 		cfg.Version = version
 	}
+	if thresholdStr := os.Getenv("MCP_SLOW_THRESHOLD"); thresholdStr != "" {
+		threshold, err := logging.ParseSlowThreshold(thresholdStr)
+		if err != nil {
+			return err
+		}
+		cfg.SlowThreshold = threshold
+	}
+	if transport := os.Getenv("MCP_TRANSPORT"); transport != "" {
+		cfg.Transport = transport
+	}
+	if portStr := os.Getenv("MCP_TRANSPORT_PORT"); portStr != "" {
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return fmt.Errorf("invalid MCP_TRANSPORT_PORT %q: must be an integer", portStr)
+		}
+		cfg.TransportPort = port
+	}
+	if endpoint := os.Getenv("MCP_ENDPOINT"); endpoint != "" {
+		cfg.Endpoint = endpoint
+	}
+	return loadSecurityConfig(&cfg.Security)
+}
 
-	// Validate framework
+// validateConfig rejects frameworks other than the supported one and
+// invalid transport settings
+func validateConfig(cfg *BaseConfig) error {
 	if cfg.Framework != FrameworkGoSDK {
-		return nil, fmt.Errorf("unsupported framework: %s", cfg.Framework)
+		return fmt.Errorf("unsupported framework: %s", cfg.Framework)
 	}
+	return validateTransport(cfg)
+}
 
-	return cfg, nil
+// validateTransport checks the transport type, and that HTTP-based
+// transports have a port in range (0 for the default)
+func validateTransport(cfg *BaseConfig) error {
+	switch cfg.Transport {
+	case "", TransportStdio:
+		return nil
+	case TransportSSE, TransportWebSocket:
+		if cfg.TransportPort < 0 || cfg.TransportPort > 65535 {
+			return &ConfigError{
+				Field:   "transport_port",
+				Value:   fmt.Sprint(cfg.TransportPort),
+				Message: "port must be between 1 and 65535 (or 0 for the default)",
+			}
+		}
+		return nil
+	default:
+		return &ConfigError{
+			Field:   "transport",
+			Value:   cfg.Transport,
+			Message: "unsupported transport",
+		}
+	}
+}
+
+// loadSecurityConfig overrides security settings from environment
+func loadSecurityConfig(sec *SecurityConfig) error {
+	if limitStr := os.Getenv("MCP_RATE_LIMIT"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			return fmt.Errorf("invalid MCP_RATE_LIMIT %q: must be a non-negative integer", limitStr)
+		}
+		sec.RateLimit = limit
+	}
+	if windowStr := os.Getenv("MCP_RATE_LIMIT_WINDOW"); windowStr != "" {
+		window, err := time.ParseDuration(windowStr)
+		if err != nil || window <= 0 {
+			return fmt.Errorf("invalid MCP_RATE_LIMIT_WINDOW %q: must be a positive duration", windowStr)
+		}
+		sec.RateLimitWindow = window
+	}
+	if tools := os.Getenv("MCP_ALLOWED_TOOLS"); tools != "" {
+		sec.AllowedTools = splitList(tools)
+	}
+	if tools := os.Getenv("MCP_DENIED_TOOLS"); tools != "" {
+		sec.DeniedTools = splitList(tools)
+	}
+	if sizeStr := os.Getenv("MCP_MAX_REQUEST_BYTES"); sizeStr != "" {
+		size, err := strconv.Atoi(sizeStr)
+		if err != nil || size < 0 {
+			return fmt.Errorf("invalid MCP_MAX_REQUEST_BYTES %q: must be a non-negative integer", sizeStr)
+		}
+		sec.MaxRequestBytes = size
+	}
+	return nil
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestLoadBaseConfig(t *testing.T) {
@@ -110,3 +111,68 @@ func TestLoadBaseConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadBaseConfig_SlowThreshold(t *testing.T) {
+	t.Run("unset", func(t *testing.T) {
+		t.Setenv("MCP_SLOW_THRESHOLD", "")
+		cfg, err := LoadBaseConfig()
+		if err != nil {
+			t.Fatalf("LoadBaseConfig() error = %v", err)
+		}
+		if cfg.SlowThreshold != 0 {
+			t.Errorf("LoadBaseConfig().SlowThreshold = %v, want 0", cfg.SlowThreshold)
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Setenv("MCP_SLOW_THRESHOLD", "750ms")
+		cfg, err := LoadBaseConfig()
+		if err != nil {
+			t.Fatalf("LoadBaseConfig() error = %v", err)
+		}
+		if cfg.SlowThreshold != 750*time.Millisecond {
+			t.Errorf("LoadBaseConfig().SlowThreshold = %v, want %v", cfg.SlowThreshold, 750*time.Millisecond)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Setenv("MCP_SLOW_THRESHOLD", "soon")
+		if _, err := LoadBaseConfig(); err == nil {
+			t.Error("LoadBaseConfig() expected error for invalid MCP_SLOW_THRESHOLD")
+		}
+	})
+}
+
+func TestLoadBaseConfig_Security(t *testing.T) {
+	t.Setenv("MCP_RATE_LIMIT", "30")
+	t.Setenv("MCP_RATE_LIMIT_WINDOW", "10s")
+	t.Setenv("MCP_ALLOWED_TOOLS", "read, list,,")
+	t.Setenv("MCP_DENIED_TOOLS", "")
+	t.Setenv("MCP_MAX_REQUEST_BYTES", "4096")
+
+	cfg, err := LoadBaseConfig()
+	if err != nil {
+		t.Fatalf("LoadBaseConfig() error = %v", err)
+	}
+	sec := cfg.Security
+	if sec.RateLimit != 30 || sec.RateLimitWindow != 10*time.Second {
+		t.Errorf("rate limit = %d per %v, want 30 per 10s", sec.RateLimit, sec.RateLimitWindow)
+	}
+	if len(sec.AllowedTools) != 2 || sec.AllowedTools[0] != "read" || sec.AllowedTools[1] != "list" {
+		t.Errorf("AllowedTools = %q, want [read list]", sec.AllowedTools)
+	}
+	if sec.DeniedTools != nil {
+		t.Errorf("DeniedTools = %q, want nil", sec.DeniedTools)
+	}
+	if sec.MaxRequestBytes != 4096 {
+		t.Errorf("MaxRequestBytes = %d, want 4096", sec.MaxRequestBytes)
+	}
+	if !sec.Enabled() {
+		t.Error("Enabled() = false, want true")
+	}
+
+	t.Setenv("MCP_RATE_LIMIT", "lots")
+	if _, err := LoadBaseConfig(); err == nil {
+		t.Error("LoadBaseConfig() expected error for invalid MCP_RATE_LIMIT")
+	}
+}
@@ -1,10 +1,14 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // ConfigBuilder builds BaseConfig with fluent API
 type ConfigBuilder struct {
-	config *BaseConfig
+	config     *BaseConfig
+	validators []Validator
 }
 
 // NewConfigBuilder creates a new config builder with default values
@@ -36,6 +40,79 @@ func (b *ConfigBuilder) WithVersion(version string) *ConfigBuilder {
 	return b
 }
 
+// WithSlowThreshold sets the slow-operation logging threshold
+func (b *ConfigBuilder) WithSlowThreshold(threshold time.Duration) *ConfigBuilder {
+	b.config.SlowThreshold = threshold
+	return b
+}
+
+// WithTransport sets the transport type: TransportStdio, TransportSSE or
+// TransportWebSocket
+func (b *ConfigBuilder) WithTransport(transport string) *ConfigBuilder {
+	b.config.Transport = transport
+	return b
+}
+
+// WithPort sets the port for the HTTP-based transports
+func (b *ConfigBuilder) WithPort(port int) *ConfigBuilder {
+	b.config.TransportPort = port
+	return b
+}
+
+// WithEndpoint sets the HTTP path for the HTTP-based transports
+func (b *ConfigBuilder) WithEndpoint(endpoint string) *ConfigBuilder {
+	b.config.Endpoint = endpoint
+	return b
+}
+
+// WithRateLimit limits each client to maxCalls tool calls per window
+func (b *ConfigBuilder) WithRateLimit(maxCalls int, window time.Duration) *ConfigBuilder {
+	b.config.Security.RateLimit = maxCalls
+	b.config.Security.RateLimitWindow = window
+	return b
+}
+
+// WithAllowedTools restricts tool calls to the given tools
+func (b *ConfigBuilder) WithAllowedTools(tools ...string) *ConfigBuilder {
+	b.config.Security.AllowedTools = tools
+	return b
+}
+
+// WithDeniedTools rejects calls to the given tools
+func (b *ConfigBuilder) WithDeniedTools(tools ...string) *ConfigBuilder {
+	b.config.Security.DeniedTools = tools
+	return b
+}
+
+// WithMaxRequestBytes caps the size of tool call arguments
+func (b *ConfigBuilder) WithMaxRequestBytes(maxBytes int) *ConfigBuilder {
+	b.config.Security.MaxRequestBytes = maxBytes
+	return b
+}
+
+// AddValidator adds an application-specific check run by Build, after the
+// built-in ones. Validators run in the order they were added; Build stops
+// at the first failure.
+//
+// Example:
+//
+//	cfg, err := config.NewConfigBuilder().
+//		WithVersion(version).
+//		AddValidator(config.RequireSemVer).
+//		AddValidator(func(cfg *config.BaseConfig) error {
+//			if cfg.Transport != config.TransportSSE {
+//				return errors.New("this server only supports SSE")
+//			}
+//			return nil
+//		}).
+//		Build()
+func (b *ConfigBuilder) AddValidator(fn Validator) *ConfigBuilder {
+	if fn != nil {
+		b.validators = append(b.validators, fn)
+	}
+	return b
+}
+
 // Build returns the built configuration
 // Returns an error if the configuration is invalid
 func (b *ConfigBuilder) Build() (*BaseConfig, error) {
@@ -66,6 +143,50 @@ func (b *ConfigBuilder) Build() (*BaseConfig, error) {
 		}
 	}
 
+	// Validate slow threshold (zero means default)
+	if b.config.SlowThreshold < 0 {
+		return nil, &ConfigError{
+			Field:   "slow_threshold",
+			Value:   b.config.SlowThreshold.String(),
+			Message: "slow threshold cannot be negative",
+		}
+	}
+
+	// Validate transport type and port
+	if err := validateTransport(b.config); err != nil {
+		return nil, err
+	}
+
+	// Validate security limits (zero means disabled)
+	if b.config.Security.RateLimit < 0 {
+		return nil, &ConfigError{
+			Field:   "security.rate_limit",
+			Value:   fmt.Sprint(b.config.Security.RateLimit),
+			Message: "rate limit cannot be negative",
+		}
+	}
+	if b.config.Security.RateLimitWindow < 0 {
+		return nil, &ConfigError{
+			Field:   "security.rate_limit_window",
+			Value:   b.config.Security.RateLimitWindow.String(),
+			Message: "rate limit window cannot be negative",
+		}
+	}
+	if b.config.Security.MaxRequestBytes < 0 {
+		return nil, &ConfigError{
+			Field:   "security.max_request_bytes",
+			Value:   fmt.Sprint(b.config.Security.MaxRequestBytes),
+			Message: "max request bytes cannot be negative",
+		}
+	}
+
+	// Run application validators
+	for i, validate := range b.validators {
+		if err := validate(b.config); err != nil {
+			return nil, fmt.Errorf("config validator %d failed: %w", i+1, err)
+		}
+	}
+
 	return b.config, nil
 }
 
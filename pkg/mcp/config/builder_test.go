@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 )
 
 func TestNewConfigBuilder(t *testing.T) {
@@ -70,6 +71,20 @@ func TestConfigBuilder_WithVersion(t *testing.T) {
 	}
 }
 
+func TestConfigBuilder_WithSlowThreshold(t *testing.T) {
+	cfg, err := NewConfigBuilder().WithSlowThreshold(500 * time.Millisecond).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if cfg.SlowThreshold != 500*time.Millisecond {
+		t.Errorf("SlowThreshold = %v, want %v", cfg.SlowThreshold, 500*time.Millisecond)
+	}
+
+	if _, err := NewConfigBuilder().WithSlowThreshold(-time.Second).Build(); err == nil {
+		t.Error("Build() with negative slow threshold should fail")
+	}
+}
+
 func TestConfigBuilder_Build(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -158,6 +173,69 @@ func TestConfigBuilder_FluentAPI(t *testing.T) {
 	}
 }
 
+func TestConfigBuilder_Transport(t *testing.T) {
+	cfg, err := NewConfigBuilder().
+		WithName("sse-server").
+		WithTransport(TransportSSE).
+		WithPort(9090).
+		WithEndpoint("/events").
+		Build()
+	if err != nil {
+		t.Fatalf("ConfigBuilder.Build() error = %v", err)
+	}
+	if cfg.Name != "sse-server" || cfg.Transport != TransportSSE || cfg.TransportPort != 9090 || cfg.Endpoint != "/events" {
+		t.Errorf("config = %+v, want the chained transport settings", cfg)
+	}
+
+	tests := []struct {
+		name      string
+		transport string
+		port      int
+		wantErr   bool
+	}{
+		{name: "stdio ignores port", transport: TransportStdio, port: -1, wantErr: false},
+		{name: "default transport", transport: "", port: 0, wantErr: false},
+		{name: "sse default port", transport: TransportSSE, port: 0, wantErr: false},
+		{name: "sse lowest port", transport: TransportSSE, port: 1, wantErr: false},
+		{name: "sse highest port", transport: TransportSSE, port: 65535, wantErr: false},
+		{name: "sse negative port", transport: TransportSSE, port: -1, wantErr: true},
+		{name: "sse port too large", transport: TransportSSE, port: 65536, wantErr: true},
+		{name: "websocket port too large", transport: TransportWebSocket, port: 70000, wantErr: true},
+		{name: "unknown transport", transport: "carrier-pigeon", port: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfigBuilder().WithTransport(tt.transport).WithPort(tt.port).Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := err.(*ConfigError); err != nil && !ok {
+				t.Errorf("Build() error type = %T, want *ConfigError", err)
+			}
+		})
+	}
+}
+
+func TestLoadBaseConfig_Transport(t *testing.T) {
+	t.Setenv("MCP_TRANSPORT", TransportWebSocket)
+	t.Setenv("MCP_TRANSPORT_PORT", "8443")
+	t.Setenv("MCP_ENDPOINT", "/mcp")
+	cfg, err := LoadBaseConfig()
+	if err != nil {
+		t.Fatalf("LoadBaseConfig() error = %v", err)
+	}
+	if cfg.Transport != TransportWebSocket || cfg.TransportPort != 8443 || cfg.Endpoint != "/mcp" {
+		t.Errorf("config = %+v, want the environment's transport settings", cfg)
+	}
+
+	for _, port := range []string{"abc", "99999"} {
+		t.Setenv("MCP_TRANSPORT_PORT", port)
+		if _, err := LoadBaseConfig(); err == nil {
+			t.Errorf("LoadBaseConfig() with MCP_TRANSPORT_PORT=%s should fail", port)
+		}
+	}
+}
+
 func TestConfigError(t *testing.T) {
 	err := &ConfigError{
 		Field:   "framework",
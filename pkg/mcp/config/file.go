@@ -0,0 +1,116 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConfigFile is the file LoadConfigFromFile looks for in the
+// working directory and, under an "mcp" directory, in $XDG_CONFIG_HOME
+const DefaultConfigFile = "mcp.yaml"
+
+// LoadConfigFromFile loads configuration from a YAML or JSON file, then
+// applies environment variable overrides as for LoadBaseConfig, so the
+// environment wins over the file. Fields missing from the file keep their
+// defaults.
+//
+// The file is path if it is not empty, which must exist. Otherwise the first
+// of ./mcp.yaml and $XDG_CONFIG_HOME/mcp/mcp.yaml (~/.config when
+// XDG_CONFIG_HOME is unset) that exists is used; with neither, only the
+// defaults and environment apply.
+//
+// Files ending in .json must be JSON; anything else is read as YAML. Both
+// use the yaml field names (e.g. "slow_threshold") and durations such as
+// "250ms". Unknown fields are rejected so typos are not silently ignored.
+//
+// Example mcp.yaml:
+//
+//	name: my-server
+//	version: 1.2.0
+//	slow_threshold: 250ms
+//	security:
+//	  rate_limit: 100
+//	  denied_tools: [shell]
+func LoadConfigFromFile(path string) (*BaseConfig, error) {
+	if path == "" {
+		found, err := findConfigFile()
+		if err != nil {
+			return nil, err
+		}
+		path = found
+	}
+
+	cfg := defaultBaseConfig()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		if err := parseConfigFile(path, data, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+	if err := validateConfig(cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// configSearchPaths returns the files LoadConfigFromFile tries, in order,
+// when no path is given
+func configSearchPaths() []string {
+	paths := []string{DefaultConfigFile}
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			configHome = filepath.Join(home, ".config")
+		}
+	}
+	if configHome != "" {
+		paths = append(paths, filepath.Join(configHome, "mcp", DefaultConfigFile))
+	}
+	return paths
+}
+
+// findConfigFile returns the first existing file in configSearchPaths, or
+// "" if there is none
+func findConfigFile() (string, error) {
+	for _, path := range configSearchPaths() {
+		_, err := os.Stat(path)
+		if err == nil {
+			return path, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to check config file: %w", err)
+		}
+	}
+	return "", nil
+}
+
+// parseConfigFile decodes a config file's contents over cfg
+func parseConfigFile(path string, data []byte, cfg *BaseConfig) error {
+	// JSON is valid YAML, so both are decoded with the yaml tags; .json
+	// files are checked to really be JSON first
+	if strings.EqualFold(filepath.Ext(path), ".json") && !json.Valid(data) {
+		return fmt.Errorf("invalid config file %s: not valid JSON", path)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// clearConfigEnv unsets the environment variables LoadConfigFromFile reads
+func clearConfigEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{
+		"MCP_FRAMEWORK", "MCP_SERVER_NAME", "MCP_VERSION", "MCP_SLOW_THRESHOLD",
+		"MCP_RATE_LIMIT", "MCP_RATE_LIMIT_WINDOW", "MCP_ALLOWED_TOOLS", "MCP_DENIED_TOOLS", "MCP_MAX_REQUEST_BYTES",
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfigFromFile(t *testing.T) {
+	clearConfigEnv(t)
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "server.yaml")
+	writeFile(t, yamlPath, `name: file-server
+version: 2.0.0
+slow_threshold: 250ms
+security:
+  rate_limit: 10
+  denied_tools: [shell]
+`)
+
+	cfg, err := LoadConfigFromFile(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadConfigFromFile() error = %v", err)
+	}
+	if cfg.Name != "file-server" || cfg.Version != "2.0.0" || cfg.SlowThreshold != 250*time.Millisecond {
+		t.Errorf("config = %+v, want the file's values", cfg)
+	}
+	if cfg.Framework != FrameworkGoSDK {
+		t.Errorf("Framework = %v, want the default %v", cfg.Framework, FrameworkGoSDK)
+	}
+	if cfg.Security.RateLimit != 10 || len(cfg.Security.DeniedTools) != 1 || cfg.Security.DeniedTools[0] != "shell" {
+		t.Errorf("Security = %+v, want the file's values", cfg.Security)
+	}
+
+	// The environment wins over the file
+	t.Setenv("MCP_SERVER_NAME", "env-server")
+	t.Setenv("MCP_RATE_LIMIT", "5")
+	cfg, err = LoadConfigFromFile(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadConfigFromFile() error = %v", err)
+	}
+	if cfg.Name != "env-server" || cfg.Security.RateLimit != 5 {
+		t.Errorf("Name, RateLimit = %q, %d; want the environment's env-server, 5", cfg.Name, cfg.Security.RateLimit)
+	}
+	if cfg.Version != "2.0.0" {
+		t.Errorf("Version = %q, want the file's 2.0.0", cfg.Version)
+	}
+}
+
+func TestLoadConfigFromFile_JSON(t *testing.T) {
+	clearConfigEnv(t)
+	path := filepath.Join(t.TempDir(), "server.json")
+	writeFile(t, path, `{"name": "json-server", "slow_threshold": "2s", "security": {"max_request_bytes": 1024}}`)
+
+	cfg, err := LoadConfigFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFromFile() error = %v", err)
+	}
+	if cfg.Name != "json-server" || cfg.SlowThreshold != 2*time.Second || cfg.Security.MaxRequestBytes != 1024 {
+		t.Errorf("config = %+v, want the file's values", cfg)
+	}
+}
+
+func TestLoadConfigFromFile_Invalid(t *testing.T) {
+	clearConfigEnv(t)
+	dir := t.TempDir()
+	tests := map[string]string{
+		"framework.yaml": "framework: other\n",
+		"unknown.yaml":   "nmae: typo\n",
+		"syntax.yaml":    "name: [unclosed\n",
+		"yaml.json":      "name: not-json\n",
+	}
+	for file, content := range tests {
+		t.Run(file, func(t *testing.T) {
+			path := filepath.Join(dir, file)
+			writeFile(t, path, content)
+			if cfg, err := LoadConfigFromFile(path); err == nil {
+				t.Errorf("LoadConfigFromFile() = %+v, want an error", cfg)
+			}
+		})
+	}
+
+	if _, err := LoadConfigFromFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("LoadConfigFromFile() of a missing explicit path should fail")
+	}
+}
+
+func TestLoadConfigFromFile_SearchOrder(t *testing.T) {
+	clearConfigEnv(t)
+	workDir := t.TempDir()
+	configHome := t.TempDir()
+	t.Chdir(workDir)
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	load := func() *BaseConfig {
+		t.Helper()
+		cfg, err := LoadConfigFromFile("")
+		if err != nil {
+			t.Fatalf("LoadConfigFromFile() error = %v", err)
+		}
+		return cfg
+	}
+
+	// No file anywhere: defaults
+	if cfg := load(); cfg.Name != "mcp-server" {
+		t.Errorf("Name = %q, want the default", cfg.Name)
+	}
+
+	writeFile(t, filepath.Join(configHome, "mcp", DefaultConfigFile), "name: xdg-server\n")
+	if cfg := load(); cfg.Name != "xdg-server" {
+		t.Errorf("Name = %q, want xdg-server from $XDG_CONFIG_HOME", cfg.Name)
+	}
+
+	// ./mcp.yaml comes before $XDG_CONFIG_HOME
+	writeFile(t, filepath.Join(workDir, DefaultConfigFile), "name: local-server\n")
+	if cfg := load(); cfg.Name != "local-server" {
+		t.Errorf("Name = %q, want local-server from ./mcp.yaml", cfg.Name)
+	}
+
+	// An explicit path comes first
+	explicit := filepath.Join(t.TempDir(), "explicit.yaml")
+	writeFile(t, explicit, "name: explicit-server\n")
+	cfg, err := LoadConfigFromFile(explicit)
+	if err != nil {
+		t.Fatalf("LoadConfigFromFile() error = %v", err)
+	}
+	if cfg.Name != "explicit-server" {
+		t.Errorf("Name = %q, want explicit-server", cfg.Name)
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Validator checks an application invariant on a configuration; see
+// ConfigBuilder.AddValidator
+type Validator func(*BaseConfig) error
+
+// semVerPattern matches a Semantic Versioning 2.0.0 version, e.g. "1.2.3",
+// "1.0.0-rc.1" or "2.0.0+build.5"
+var semVerPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// RequireSemVer is a Validator requiring Version to be a semantic version
+// (https://semver.org), such as "1.2.3" or "2.0.0-beta.1". A leading "v"
+// is not allowed.
+func RequireSemVer(cfg *BaseConfig) error {
+	if !semVerPattern.MatchString(cfg.Version) {
+		return &ConfigError{
+			Field:   "version",
+			Value:   cfg.Version,
+			Message: "version must be a semantic version (MAJOR.MINOR.PATCH)",
+		}
+	}
+	return nil
+}
+
+// NonEmptyName is a Validator requiring Name to contain more than
+// whitespace
+func NonEmptyName(cfg *BaseConfig) error {
+	if strings.TrimSpace(cfg.Name) == "" {
+		return &ConfigError{
+			Field:   "name",
+			Value:   cfg.Name,
+			Message: "server name cannot be blank",
+		}
+	}
+	return nil
+}
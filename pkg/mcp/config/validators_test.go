@@ -0,0 +1,106 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConfigBuilder_AddValidator(t *testing.T) {
+	errNoSSE := errors.New("this server only supports SSE")
+	requireSSE := func(cfg *BaseConfig) error {
+		if cfg.Transport != TransportSSE {
+			return errNoSSE
+		}
+		return nil
+	}
+
+	t.Run("failing validator", func(t *testing.T) {
+		cfg, err := NewConfigBuilder().
+			AddValidator(NonEmptyName).
+			AddValidator(requireSSE).
+			Build()
+		if cfg != nil {
+			t.Errorf("Build() = %+v, want nil", cfg)
+		}
+		if !errors.Is(err, errNoSSE) {
+			t.Fatalf("Build() error = %v, want the validator's error", err)
+		}
+		if !strings.Contains(err.Error(), "validator 2") {
+			t.Errorf("Build() error = %q, want it to name the failing validator", err)
+		}
+	})
+
+	t.Run("passing chain", func(t *testing.T) {
+		var order []string
+		record := func(name string) Validator {
+			return func(cfg *BaseConfig) error {
+				order = append(order, name)
+				return nil
+			}
+		}
+		cfg, err := NewConfigBuilder().
+			WithTransport(TransportSSE).
+			WithVersion("2.1.0-rc.1").
+			AddValidator(record("first")).
+			AddValidator(RequireSemVer).
+			AddValidator(requireSSE).
+			AddValidator(record("last")).
+			Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		if cfg.Version != "2.1.0-rc.1" {
+			t.Errorf("Version = %q, want 2.1.0-rc.1", cfg.Version)
+		}
+		if strings.Join(order, ",") != "first,last" {
+			t.Errorf("validators ran in order %v, want first, last", order)
+		}
+	})
+
+	t.Run("built-in checks run first", func(t *testing.T) {
+		called := false
+		_, err := NewConfigBuilder().
+			WithName("").
+			AddValidator(func(cfg *BaseConfig) error {
+				called = true
+				return nil
+			}).
+			Build()
+		if err == nil {
+			t.Fatal("Build() with an empty name should fail")
+		}
+		if called {
+			t.Error("validator ran although a built-in check failed")
+		}
+	})
+}
+
+func TestRequireSemVer(t *testing.T) {
+	tests := map[string]bool{
+		"1.0.0":            true,
+		"0.10.2":           true,
+		"2.0.0-rc.1":       true,
+		"1.0.0-alpha+b.12": true,
+		"1.0":              false,
+		"v1.0.0":           false,
+		"01.0.0":           false,
+		"1.0.0-":           false,
+		"":                 false,
+	}
+	for version, valid := range tests {
+		err := RequireSemVer(&BaseConfig{Version: version})
+		if (err == nil) != valid {
+			t.Errorf("RequireSemVer(%q) error = %v, want valid=%v", version, err, valid)
+		}
+	}
+}
+
+func TestNonEmptyName(t *testing.T) {
+	if err := NonEmptyName(&BaseConfig{Name: "server"}); err != nil {
+		t.Errorf("NonEmptyName(server) error = %v", err)
+	}
+	if err := NonEmptyName(&BaseConfig{Name: "  "}); err == nil {
+		t.Error("NonEmptyName() with a blank name should fail")
+	}
+}
@@ -10,6 +10,7 @@
 //	// or
 //	cfg, _ := config.LoadBaseConfig()
 //	server, err := factory.NewServerFromConfig(cfg)
+//	transport, err := factory.NewTransportFromConfig(cfg)
 package factory
 
 import (
@@ -18,6 +19,8 @@ import (
 	"github.com/davidl71/mcp-go-core/pkg/mcp/config"
 	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
 	"github.com/davidl71/mcp-go-core/pkg/mcp/framework/adapters/gosdk"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/security"
 )
 
 // NewServer creates a new MCP server using the specified framework
@@ -32,5 +35,93 @@ func NewServer(frameworkType config.FrameworkType, name, version string) (framew
 
 // NewServerFromConfig creates server from configuration
 func NewServerFromConfig(cfg *config.BaseConfig) (framework.MCPServer, error) {
-	return NewServer(cfg.Framework, cfg.Name, cfg.Version)
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	switch cfg.Framework {
+	case config.FrameworkGoSDK:
+		return gosdk.NewGoSDKAdapter(cfg.Name, cfg.Version, goSDKOptions(cfg)...), nil
+	default:
+		return nil, fmt.Errorf("unknown framework: %s", cfg.Framework)
+	}
+}
+
+// NewTransportFromConfig creates the transport selected by cfg.Transport,
+// with its port and endpoint, ready to pass to MCPServer.Run. An empty
+// transport means stdio.
+//
+// Example:
+//
+//	server, err := factory.NewServerFromConfig(cfg)
+//	...
+//	transport, err := factory.NewTransportFromConfig(cfg)
+//	...
+//	err = server.Run(ctx, transport)
+func NewTransportFromConfig(cfg *config.BaseConfig) (framework.Transport, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	switch cfg.Transport {
+	case "", config.TransportStdio:
+		return &framework.StdioTransport{}, nil
+	case config.TransportSSE, config.TransportWebSocket:
+		if cfg.TransportPort < 0 || cfg.TransportPort > 65535 {
+			return nil, fmt.Errorf("invalid %s port: %d", cfg.Transport, cfg.TransportPort)
+		}
+		if cfg.Transport == config.TransportSSE {
+			return framework.NewSSETransport(cfg.Endpoint, cfg.TransportPort), nil
+		}
+		return framework.NewWSTransport(cfg.Endpoint, cfg.TransportPort), nil
+	default:
+		return nil, fmt.Errorf("unknown transport: %s", cfg.Transport)
+	}
+}
+
+// goSDKOptions translates configuration into go-sdk adapter options
+func goSDKOptions(cfg *config.BaseConfig) []gosdk.AdapterOption {
+	var opts []gosdk.AdapterOption
+	if cfg.SlowThreshold > 0 {
+		logger := logging.NewLogger()
+		logger.SetSlowThreshold(cfg.SlowThreshold)
+		opts = append(opts, gosdk.WithLogger(logger))
+	}
+	if cfg.Security.Enabled() {
+		policy := securityPolicy(cfg.Security)
+		opts = append(opts, gosdk.WithMiddleware(func(chain *gosdk.MiddlewareChain) {
+			chain.AddToolMiddleware(gosdk.PolicyMiddleware(policy))
+		}))
+		if policy.RateLimit != nil {
+			// The adapter owns the limiter: stop its cleanup goroutine when
+			// the adapter is closed
+			opts = append(opts, gosdk.WithCloser(policy.RateLimit.Stop))
+		}
+	}
+	return opts
+}
+
+// securityPolicy translates a security config into a security.Policy
+func securityPolicy(sec config.SecurityConfig) *security.Policy {
+	policy := &security.Policy{MaxArgBytes: sec.MaxRequestBytes}
+	if len(sec.AllowedTools) > 0 || len(sec.DeniedTools) > 0 {
+		defaultPolicy := security.PermissionAllow
+		if len(sec.AllowedTools) > 0 {
+			defaultPolicy = security.PermissionDeny
+		}
+		ac := security.NewAccessControl(defaultPolicy)
+		for _, tool := range sec.AllowedTools {
+			ac.AllowTool(tool)
+		}
+		for _, tool := range sec.DeniedTools {
+			ac.DenyTool(tool)
+		}
+		policy.Access = ac
+	}
+	if sec.RateLimit > 0 {
+		window := sec.RateLimitWindow
+		if window <= 0 {
+			window = config.DefaultRateLimitWindow
+		}
+		policy.RateLimit = security.NewRateLimiter(window, sec.RateLimit)
+	}
+	return policy
 }
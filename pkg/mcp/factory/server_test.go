@@ -1,9 +1,17 @@
 package factory
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/davidl71/mcp-go-core/pkg/mcp/config"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
 )
 
 func TestNewServer(t *testing.T) {
@@ -134,3 +142,168 @@ func TestNewServerFromConfig_ServerName(t *testing.T) {
 		t.Errorf("server.GetName() = %q, want %q", server.GetName(), "custom-server")
 	}
 }
+
+func TestNewServerFromConfig_SlowThreshold(t *testing.T) {
+	cfg := &config.BaseConfig{
+		Framework:     config.FrameworkGoSDK,
+		Name:          "test-server",
+		Version:       "1.0.0",
+		SlowThreshold: time.Second,
+	}
+
+	if opts := goSDKOptions(cfg); len(opts) != 1 {
+		t.Errorf("goSDKOptions() returned %d options, want 1 (logger)", len(opts))
+	}
+	server, err := NewServerFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewServerFromConfig() error = %v", err)
+	}
+	if server == nil {
+		t.Fatal("NewServerFromConfig() returned nil server")
+	}
+}
+
+func TestNewServerFromConfig_Security(t *testing.T) {
+	cfg, err := config.NewConfigBuilder().
+		WithName("secure-server").
+		WithRateLimit(2, time.Minute).
+		WithDeniedTools("forbidden").
+		WithMaxRequestBytes(64).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	server, err := NewServerFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewServerFromConfig() error = %v", err)
+	}
+
+	var calls int
+	handler := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		calls++
+		return []types.TextContent{{Type: "text", Text: "ok"}}, nil
+	}
+	for _, name := range []string{"echo", "forbidden"} {
+		if err := server.RegisterTool(name, "Test tool", types.ToolSchema{Type: "object"}, handler); err != nil {
+			t.Fatalf("RegisterTool(%s) error = %v", name, err)
+		}
+	}
+	ctx := context.Background()
+	call := func(name, args string) error {
+		_, err := server.CallTool(ctx, name, json.RawMessage(args))
+		return err
+	}
+
+	if err := call("forbidden", `{}`); err == nil {
+		t.Error("denied tool call error = nil, want rejection")
+	}
+	if err := call("echo", `{"data":"`+strings.Repeat("x", 100)+`"}`); err == nil {
+		t.Error("oversized call error = nil, want rejection")
+	}
+	if calls != 0 {
+		t.Fatalf("handler ran %d times for rejected calls, want 0", calls)
+	}
+
+	// Rejected calls above don't consume rate limit capacity
+	for i := 0; i < 2; i++ {
+		if err := call("echo", `{}`); err != nil {
+			t.Fatalf("call %d error = %v, want within rate limit", i+1, err)
+		}
+	}
+	if err := call("echo", `{}`); err == nil || !strings.Contains(err.Error(), "rate limit exceeded") {
+		t.Errorf("third call error = %v, want rate limited", err)
+	}
+	if calls != 2 {
+		t.Errorf("handler calls = %d, want 2", calls)
+	}
+}
+
+func TestNewServerFromConfig_ClosesRateLimiter(t *testing.T) {
+	cfg, err := config.NewConfigBuilder().WithName("limited").WithRateLimit(10, time.Minute).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	// Each server's rate limiter runs a cleanup goroutine until the server
+	// is closed
+	const servers = 20
+	before := runtime.NumGoroutine()
+	for i := 0; i < servers; i++ {
+		server, err := NewServerFromConfig(cfg)
+		if err != nil {
+			t.Fatalf("NewServerFromConfig() error = %v", err)
+		}
+		closer, ok := server.(io.Closer)
+		if !ok {
+			t.Fatalf("server %T does not implement io.Closer", server)
+		}
+		if err := closer.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() >= before+servers {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines grew from %d to %d after closing %d servers", before, runtime.NumGoroutine(), servers)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNewTransportFromConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          *config.BaseConfig
+		wantType     string
+		wantPort     int
+		wantEndpoint string
+		wantErr      bool
+	}{
+		{name: "default", cfg: &config.BaseConfig{}, wantType: "stdio"},
+		{name: "stdio", cfg: &config.BaseConfig{Transport: config.TransportStdio}, wantType: "stdio"},
+		{
+			name:     "sse",
+			cfg:      &config.BaseConfig{Transport: config.TransportSSE, TransportPort: 9090, Endpoint: "/events"},
+			wantType: "sse", wantPort: 9090, wantEndpoint: "/events",
+		},
+		{
+			name:     "sse defaults",
+			cfg:      &config.BaseConfig{Transport: config.TransportSSE},
+			wantType: "sse", wantPort: 0, wantEndpoint: "/sse",
+		},
+		{
+			name:     "websocket",
+			cfg:      &config.BaseConfig{Transport: config.TransportWebSocket, TransportPort: 8443},
+			wantType: "websocket", wantPort: 8443, wantEndpoint: "/ws",
+		},
+		{name: "bad port", cfg: &config.BaseConfig{Transport: config.TransportSSE, TransportPort: 65536}, wantErr: true},
+		{name: "unknown", cfg: &config.BaseConfig{Transport: "udp"}, wantErr: true},
+		{name: "nil", cfg: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, err := NewTransportFromConfig(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTransportFromConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if transport.Type() != tt.wantType {
+				t.Errorf("Type() = %q, want %q", transport.Type(), tt.wantType)
+			}
+			switch tr := transport.(type) {
+			case *framework.SSETransport:
+				if tr.ListenPort != tt.wantPort || tr.Endpoint != tt.wantEndpoint {
+					t.Errorf("SSE transport = %d %q, want %d %q", tr.ListenPort, tr.Endpoint, tt.wantPort, tt.wantEndpoint)
+				}
+			case *framework.WSTransport:
+				if tr.Port != tt.wantPort || tr.Endpoint != tt.wantEndpoint {
+					t.Errorf("WebSocket transport = %d %q, want %d %q", tr.Port, tr.Endpoint, tt.wantPort, tt.wantEndpoint)
+				}
+			}
+		})
+	}
+}
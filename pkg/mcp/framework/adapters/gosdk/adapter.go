@@ -3,10 +3,17 @@ package gosdk
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
 	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/platform"
 	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -15,41 +22,332 @@ import (
 type GoSDKAdapter struct {
 	server       *mcp.Server
 	name         string
+	mu           sync.RWMutex                     // Guards the handler and info maps
 	toolHandlers map[string]framework.ToolHandler // Pre-allocated map for O(1) lookups
 	toolInfo     map[string]types.ToolInfo        // Pre-allocated map for O(1) lookups
+	promptInfo   map[string]types.PromptInfo
+	resourceInfo map[string]types.ResourceInfo
 	logger       *logging.Logger
 	middleware   *MiddlewareChain
+
+	// resourceTemplateInfo is keyed by URI template (see RegisterResourceTemplate)
+	resourceTemplateInfo map[string]types.ResourceInfo
+
+	// subscriptions counts subscribers per resource URI (see SubscribeResource)
+	subscriptions map[string]int
+
+	// Result size limits in bytes (0 = unlimited)
+	maxPromptBytes   int
+	maxResourceBytes int
+
+	// utf8Policy controls handling of invalid UTF-8 in text results
+	utf8Policy UTF8Policy
+
+	// resolveSchemaRefs inlines local $refs in tool schemas at registration
+	resolveSchemaRefs bool
+
+	// Plugin support (see LoadPlugins)
+	pluginRegistry *PluginRegistry
+	pluginTools    map[string]bool // names of tools registered from plugins
+
+	// debugEcho registers DebugEchoTool (defaults to MCP_DEBUG=1)
+	debugEcho bool
+
+	// shutdownSignals makes Run stop gracefully on platform.ShutdownSignals
+	shutdownSignals bool
+
+	// toolTimeout bounds each tool call (0 = none, see WithToolTimeout)
+	toolTimeout time.Duration
+
+	// recovery installs RecoverMiddleware (see WithRecovery)
+	recovery bool
+
+	// metrics records tool calls (nil unless WithMetrics is used)
+	metrics *MetricsMiddleware
+
+	// initializedSessions counts client sessions that completed the
+	// initialize handshake, to tell whether Run served anyone
+	initializedSessions atomic.Int64
+
+	// canary is the health check tool (nil unless WithCanaryTool is used)
+	canary *canaryProbe
+
+	// closers release resources owned by the adapter (see WithCloser)
+	closers   []func()
+	closeOnce sync.Once
 }
 
 // NewGoSDKAdapter creates a new Go SDK adapter
 // Options can be provided to configure the adapter (e.g., WithLogger, WithMiddleware)
 func NewGoSDKAdapter(name, version string, opts ...AdapterOption) *GoSDKAdapter {
 	adapter := &GoSDKAdapter{
-		server: mcp.NewServer(&mcp.Implementation{
-			Name:    name,
-			Version: version,
-		}, nil),
-		name:         name,
-		toolHandlers: make(map[string]framework.ToolHandler),
-		toolInfo:     make(map[string]types.ToolInfo),
-		logger:       logging.NewLogger(), // Default logger
-		middleware:   NewMiddlewareChain(), // Default empty middleware chain
+		name:           name,
+		toolHandlers:   make(map[string]framework.ToolHandler),
+		toolInfo:       make(map[string]types.ToolInfo),
+		promptInfo:     make(map[string]types.PromptInfo),
+		resourceInfo:   make(map[string]types.ResourceInfo),
+		pluginRegistry: DefaultPluginRegistry,
+		pluginTools:    make(map[string]bool),
+		subscriptions:  make(map[string]int),
+		logger:         logging.NewLogger(),  // Default logger
+		middleware:     NewMiddlewareChain(), // Default empty middleware chain
+		debugEcho:      debugModeEnabled(),
 	}
+	adapter.server = mcp.NewServer(&mcp.Implementation{
+		Name:    name,
+		Version: version,
+	}, &mcp.ServerOptions{
+		SubscribeHandler: func(ctx context.Context, req *mcp.SubscribeRequest) error {
+			adapter.SubscribeResource(req.Params.URI)
+			return nil
+		},
+		UnsubscribeHandler: func(ctx context.Context, req *mcp.UnsubscribeRequest) error {
+			adapter.UnsubscribeResource(req.Params.URI)
+			return nil
+		},
+		InitializedHandler: func(ctx context.Context, req *mcp.InitializedRequest) {
+			adapter.initializedSessions.Add(1)
+		},
+	})
 
 	// Apply options
 	for _, opt := range opts {
 		opt(adapter)
 	}
+	// Installed after the options so it uses the final logger and wraps
+	// all other middleware
+	if adapter.recovery {
+		adapter.middleware.prependMiddleware(NewRecoverMiddleware(adapter.logger))
+	}
+
+	if adapter.debugEcho {
+		adapter.registerDebugEcho()
+	}
 
 	return adapter
 }
 
-// RegisterTool registers a tool with the server using the new v1.2.0 API
-func (a *GoSDKAdapter) RegisterTool(name, description string, schema types.ToolSchema, handler framework.ToolHandler) error {
+// RegisterTool registers a tool with the server using the new v1.2.0 API.
+// Registering a name again replaces the tool (including one loaded from a
+// plugin, which later plugin reloads then leave alone).
+//
+// opts set optional settings:
+//   - framework.ToolAnnotations: advertised to clients and reported by ListTools
+//   - framework.ToolOutputSchema: each result's text is checked against the
+//     schema (see ValidateAgainstSchema) and sent as structured content too.
+//     A result that is not valid JSON or does not match is reported to the
+//     client as a tool error.
+//   - framework.ToolTimeout: overrides the adapter's WithToolTimeout default
+//
+// Example:
+//
+//	adapter.RegisterTool("delete", "Delete a file", schema, handler,
+//		framework.ToolAnnotations(types.ToolAnnotations{DestructiveHint: true}),
+//		framework.ToolTimeout(5*time.Second))
+func (a *GoSDKAdapter) RegisterTool(name, description string, schema types.ToolSchema, handler framework.ToolHandler, opts ...framework.ToolOption) error {
+	if err := a.registerTool(name, description, schema, framework.NewToolConfig(opts...), handler); err != nil {
+		return err
+	}
+	a.releasePluginTool(name)
+	return nil
+}
+
+// releasePluginTool stops treating name as a plugin tool once it has been
+// registered directly, so LoadPlugins does not unregister it
+func (a *GoSDKAdapter) releasePluginTool(name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.pluginTools, name)
+}
+
+// UnregisterTool removes a tool, e.g. when a dynamically loaded capability
+// goes away. Connected clients receive a tools/list_changed notification.
+// Returns *framework.ErrToolNotFound if no tool has that name.
+func (a *GoSDKAdapter) UnregisterTool(name string) error {
+	a.mu.Lock()
+	if _, exists := a.toolInfo[name]; !exists {
+		a.mu.Unlock()
+		return &framework.ErrToolNotFound{ToolName: name}
+	}
+	a.forgetTool(name)
+	a.mu.Unlock()
+
+	a.server.RemoveTools(name)
+	a.logger.Info("", "Tool unregistered: %s", name)
+	return nil
+}
+
+// forgetTool drops name from the tool maps; the caller must hold a.mu and
+// remove the tool from the server
+func (a *GoSDKAdapter) forgetTool(name string) {
+	delete(a.toolHandlers, name)
+	delete(a.toolInfo, name)
+	delete(a.pluginTools, name)
+}
+
+// RegisterRichTool registers a tool whose results may include images and
+// embedded resources as well as text. Results are converted with
+// ContentToMCP. In CLI mode (CallTool) non-text content is summarized as text,
+// see types.ContentToText.
+//
+// Example:
+//
+//	adapter.RegisterRichTool("chart", "Render a chart", schema,
+//		func(ctx context.Context, args json.RawMessage) ([]types.Content, error) {
+//			png, err := render(args)
+//			if err != nil {
+//				return nil, err
+//			}
+//			return []types.Content{types.Text("Sales by month"), types.Image(png, "image/png")}, nil
+//		})
+func (a *GoSDKAdapter) RegisterRichTool(name, description string, schema types.ToolSchema, handler framework.RichToolHandler) error {
+	if err := ValidateRegistration(name, description, handler); err != nil {
+		return fmt.Errorf("tool registration: %w", err)
+	}
+
+	run := func(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		// Enforce UTF-8 policy on text content
+		result, err = SanitizeContent(result, a.utf8Policy)
+		if err != nil {
+			return nil, &resultError{err}
+		}
+		contents, err := ContentToMCP(result)
+		if err != nil {
+			return nil, &resultError{err}
+		}
+		return &mcp.CallToolResult{Content: contents}, nil
+	}
+	cli := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		result, err := handler(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		return types.ContentToText(result), nil
+	}
+
+	if err := a.addTool(name, description, schema, framework.ToolConfig{}, run, cli); err != nil {
+		return err
+	}
+	a.releasePluginTool(name)
+	return nil
+}
+
+// toolRunner runs a tool handler and converts its result for MCP.
+// Handler errors are returned as is; invalid results as *resultError.
+type toolRunner func(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error)
+
+// resultError reports a tool result that could not be sent to the client
+type resultError struct {
+	err error
+}
+
+func (e *resultError) Error() string { return e.err.Error() }
+
+// cliCall records the outcome of a CallTool call passing through the tool's
+// middleware chain, so CallTool can return the handler's own output and error
+type cliCall struct {
+	ran    bool // the middleware let the call reach the handler
+	output []types.TextContent
+	err    error
+}
+
+// cliCallKey is the context key carrying the *cliCall of a CallTool call
+type cliCallKey struct{}
+
+// registerTool implements RegisterTool
+func (a *GoSDKAdapter) registerTool(name, description string, schema types.ToolSchema, cfg framework.ToolConfig, handler framework.ToolHandler) error {
 	// Input validation
 	if err := ValidateRegistration(name, description, handler); err != nil {
 		return fmt.Errorf("tool registration: %w", err)
 	}
+
+	// checkOutput validates a result against the output schema, returning
+	// it decoded for use as structured content
+	outputSchema := cfg.OutputSchema
+	checkOutput := func(result []types.TextContent) (interface{}, error) { return nil, nil }
+	if outputSchema != nil {
+		if outputSchema.Type == "" {
+			outputSchema.Type = "object"
+		}
+		if outputSchema.Type != "object" {
+			return fmt.Errorf("tool output schema type must be 'object', got %q", outputSchema.Type)
+		}
+		resolved, err := ResolveSchemaRefs(ToolSchemaToMCP(*outputSchema))
+		if err != nil {
+			return fmt.Errorf("tool %q output schema: %w", name, err)
+		}
+		checkOutput = func(result []types.TextContent) (interface{}, error) {
+			output, err := validateToolOutput(result, resolved)
+			if err != nil {
+				return nil, fmt.Errorf("tool %q output does not match its schema: %w", name, err)
+			}
+			return output, nil
+		}
+	}
+
+	run := func(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		// Enforce UTF-8 policy on text content
+		result, err = SanitizeTextContent(result, a.utf8Policy)
+		if err != nil {
+			return nil, &resultError{err}
+		}
+		structured, err := checkOutput(result)
+		if err != nil {
+			return nil, &resultError{err}
+		}
+		// Convert framework TextContent to go-sdk Content
+		return &mcp.CallToolResult{
+			Content:           TextContentToMCP(result),
+			StructuredContent: structured,
+		}, nil
+	}
+	cli := handler
+	if outputSchema != nil {
+		cli = func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+			result, err := handler(ctx, args)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := checkOutput(result); err != nil {
+				return nil, err
+			}
+			return result, nil
+		}
+	}
+
+	return a.addTool(name, description, schema, cfg, run, cli)
+}
+
+// validateToolOutput decodes the text of a tool result as JSON and checks it
+// against schema
+func validateToolOutput(result []types.TextContent, schema map[string]interface{}) (interface{}, error) {
+	texts := make([]string, 0, len(result))
+	for _, content := range result {
+		texts = append(texts, content.Text)
+	}
+	var output interface{}
+	if err := json.Unmarshal([]byte(strings.Join(texts, "")), &output); err != nil {
+		return nil, fmt.Errorf("result is not valid JSON: %w", err)
+	}
+	if err := ValidateAgainstSchema(output, schema); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// addTool registers a tool with the server: run serves MCP calls and cli
+// serves CallTool. Calls time out after cfg.Timeout, or the adapter's
+// default when it is 0, or never when it is negative.
+func (a *GoSDKAdapter) addTool(name, description string, schema types.ToolSchema, cfg framework.ToolConfig, run toolRunner, cli framework.ToolHandler) error {
+	annotations, outputSchema := cfg.Annotations, cfg.OutputSchema
 	if schema.Type == "" {
 		schema.Type = "object" // Default to object type
 	}
@@ -57,17 +355,57 @@ func (a *GoSDKAdapter) RegisterTool(name, description string, schema types.ToolS
 		return fmt.Errorf("tool schema type must be 'object', got %q", schema.Type)
 	}
 
-	a.logger.Debug("", "Registering tool: %s", name)
+	a.mu.RLock()
+	_, replacing := a.toolInfo[name]
+	a.mu.RUnlock()
+	if replacing {
+		a.logger.Debug("", "Replacing tool: %s", name)
+	} else {
+		a.logger.Debug("", "Registering tool: %s", name)
+	}
 
 	// Convert framework ToolSchema to go-sdk InputSchema
 	// The schema must be a JSON object with type "object"
 	inputSchemaMap := ToolSchemaToMCP(schema)
+	if a.resolveSchemaRefs {
+		resolved, err := ResolveSchemaRefs(inputSchemaMap)
+		if err != nil {
+			return fmt.Errorf("tool %q schema: %w", name, err)
+		}
+		inputSchemaMap = resolved
+	}
 
 	// Create tool definition with input schema
 	tool := &mcp.Tool{
 		Name:        name,
 		Description: description,
 		InputSchema: inputSchemaMap,
+		Annotations: ToolAnnotationsToMCP(annotations),
+	}
+	if outputSchema != nil {
+		outputSchemaMap := ToolSchemaToMCP(*outputSchema)
+		if a.resolveSchemaRefs {
+			resolved, err := ResolveSchemaRefs(outputSchemaMap)
+			if err != nil {
+				return fmt.Errorf("tool %q output schema: %w", name, err)
+			}
+			outputSchemaMap = resolved
+		}
+		tool.OutputSchema = outputSchemaMap
+	}
+
+	// Bound each call by the tool's timeout; the derived context is
+	// canceled when the call returns or times out
+	timeout := a.toolTimeoutFor(cfg.Timeout)
+	runTimed := func(ctx context.Context, args json.RawMessage) (*mcp.CallToolResult, error) {
+		return callWithTimeout(ctx, timeout, ErrToolTimeout, func(ctx context.Context) (*mcp.CallToolResult, error) {
+			return run(ctx, args)
+		})
+	}
+	cliTimed := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		return callWithTimeout(ctx, timeout, ErrToolTimeout, func(ctx context.Context) ([]types.TextContent, error) {
+			return cli(ctx, args)
+		})
 	}
 
 	// Create handler function that matches ToolHandler signature
@@ -84,51 +422,108 @@ func (a *GoSDKAdapter) RegisterTool(name, description string, schema types.ToolS
 		}
 
 		// Call framework handler with raw arguments
-		result, err := handler(ctx, req.Params.Arguments)
+		result, err := runTimed(ctx, req.Params.Arguments)
+		var resultErr *resultError
+		if errors.As(err, &resultErr) {
+			return toolErrorResult("Tool result error: %v", resultErr.err), nil
+		}
 		if err != nil {
 			// Return error as tool error (not protocol error)
-			return &mcp.CallToolResult{
-				IsError: true,
-				Content: []mcp.Content{
-					&mcp.TextContent{
-						Text: fmt.Sprintf("Tool execution error: %v", err),
-					},
-				},
-			}, nil
+			return toolErrorResult("Tool execution error: %v", err), nil
 		}
 
-		// Validate result
-		if result == nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{},
-			}, nil
-		}
-
-		// Convert framework TextContent to go-sdk Content
-		contents := TextContentToMCP(result)
+		return result, nil
+	}
 
-		return &mcp.CallToolResult{
-			Content: contents,
-		}, nil
+	info := types.ToolInfo{
+		Name:         name,
+		Description:  description,
+		Schema:       schema,
+		Annotations:  annotations,
+		OutputSchema: outputSchema,
 	}
 
+	// Wrap with middleware chain
+	wrappedToolHandler := a.middleware.WrapToolHandler(toolHandler)
+
 	// Use server.AddTool (low-level API) since we're using ToolHandler
-	a.server.AddTool(tool, toolHandler)
+	// The tool's info, dry-run flag, progress reporter and the client's
+	// request ID are attached to the context so middleware and the handler
+	// can use them
+	a.server.AddTool(tool, func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		req, dryRun, err := extractDryRun(req)
+		if err != nil {
+			return toolErrorResult("Invalid arguments: %v", err), nil
+		}
+		ctx = framework.WithDryRun(withToolInfo(ctx, info), dryRun)
+		ctx = withProgress(ctx, newProgressReporter(ctx, req))
+		if req != nil && req.Params != nil {
+			ctx = withRequestID(ctx, req.Params.Meta)
+		}
+		a.logger.DebugCtx(ctx, "Tool call: %s", name)
+		return wrappedToolHandler(ctx, req)
+	})
 
-	// Store handler and info for CLI access
-	a.toolHandlers[name] = handler
-	a.toolInfo[name] = types.ToolInfo{
-		Name:        name,
-		Description: description,
-		Schema:      schema,
+	// CallTool goes through the same middleware chain as MCP calls, so
+	// policies, rate limits and the like apply to it too
+	wrappedCLIHandler := a.middleware.WrapToolHandler(func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		call := ctx.Value(cliCallKey{}).(*cliCall)
+		call.ran = true
+		call.output, call.err = cliTimed(ctx, req.Params.Arguments)
+		if call.err != nil {
+			return toolErrorResult("Tool execution error: %v", call.err), nil
+		}
+		return &mcp.CallToolResult{Content: TextContentToMCP(call.output)}, nil
+	})
+	cliHandler := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		call := &cliCall{}
+		ctx = context.WithValue(withToolInfo(ctx, info), cliCallKey{}, call)
+		result, err := wrappedCLIHandler(ctx, &mcp.CallToolRequest{
+			Params: &mcp.CallToolParamsRaw{Name: name, Arguments: args},
+		})
+		switch {
+		case err != nil:
+			return nil, err
+		case call.err != nil:
+			return nil, call.err
+		case result != nil && result.IsError:
+			// Rejected by middleware
+			return nil, errors.New(resultText(result))
+		case call.ran:
+			return call.output, nil
+		default:
+			// Answered by middleware without calling the handler
+			return textContentFromMCP(result), nil
+		}
 	}
 
+	// Store handler and info for CLI access
+	a.mu.Lock()
+	a.toolHandlers[name] = cliHandler
+	a.toolInfo[name] = info
+	a.mu.Unlock()
+
 	a.logger.Info("", "Tool registered successfully: %s", name)
 	return nil
 }
 
 // RegisterPrompt registers a prompt with the server
 func (a *GoSDKAdapter) RegisterPrompt(name, description string, handler framework.PromptHandler) error {
+	return a.registerPrompt(name, description, nil, handler)
+}
+
+// RegisterPromptWithArgs registers a prompt that declares its arguments
+// The arguments are advertised to clients and returned by ListPrompts. A
+// GetPrompt missing a required argument fails before handler is called.
+func (a *GoSDKAdapter) RegisterPromptWithArgs(name, description string, args []types.PromptArgument, handler framework.PromptHandler) error {
+	if err := ValidatePromptArguments(args); err != nil {
+		return fmt.Errorf("prompt registration: %w", err)
+	}
+	return a.registerPrompt(name, description, args, handler)
+}
+
+// registerPrompt implements RegisterPrompt and RegisterPromptWithArgs
+func (a *GoSDKAdapter) registerPrompt(name, description string, args []types.PromptArgument, handler framework.PromptHandler) error {
 	a.logger.Debug("", "Registering prompt: %s", name)
 
 	// Input validation
@@ -140,6 +535,7 @@ func (a *GoSDKAdapter) RegisterPrompt(name, description string, handler framewor
 	prompt := &mcp.Prompt{
 		Name:        name,
 		Description: description,
+		Arguments:   PromptArgumentsToMCP(args),
 	}
 
 	// Create base prompt handler that matches the new API
@@ -154,6 +550,11 @@ func (a *GoSDKAdapter) RegisterPrompt(name, description string, handler framewor
 		if err := ValidateGetPromptRequest(req); err != nil {
 			return nil, err
 		}
+		for _, arg := range args {
+			if _, ok := req.Params.Arguments[arg.Name]; arg.Required && !ok {
+				return nil, fmt.Errorf("prompt %q: missing required argument %q", name, arg.Name)
+			}
+		}
 
 		// Convert req.Params.Arguments (map[string]any) to map[string]interface{}
 		argsInterface := make(map[string]interface{})
@@ -166,6 +567,13 @@ func (a *GoSDKAdapter) RegisterPrompt(name, description string, handler framewor
 		if err != nil {
 			return nil, fmt.Errorf("prompt handler failed: %w", err)
 		}
+		result, err = sanitizeText(result, a.utf8Policy)
+		if err != nil {
+			return nil, fmt.Errorf("prompt %q result: %w", name, err)
+		}
+		if a.maxPromptBytes > 0 && len(result) > a.maxPromptBytes {
+			return nil, fmt.Errorf("prompt %q result size %d bytes exceeds limit of %d bytes", name, len(result), a.maxPromptBytes)
+		}
 
 		return &mcp.GetPromptResult{
 			Messages: []*mcp.PromptMessage{
@@ -187,6 +595,13 @@ func (a *GoSDKAdapter) RegisterPrompt(name, description string, handler framewor
 
 	// Use server.AddPrompt with the new API
 	a.server.AddPrompt(prompt, promptHandler)
+	a.mu.Lock()
+	a.promptInfo[name] = types.PromptInfo{
+		Name:        name,
+		Description: description,
+		Arguments:   args,
+	}
+	a.mu.Unlock()
 
 	a.logger.Info("", "Prompt registered successfully: %s", name)
 	return nil
@@ -209,6 +624,114 @@ func (a *GoSDKAdapter) RegisterResource(uri, name, description, mimeType string,
 		MIMEType:    mimeType,
 	}
 
+	// Use server.AddResource with the new API
+	a.server.AddResource(resource, a.resourceHandler(handler))
+	a.mu.Lock()
+	a.resourceInfo[uri] = types.ResourceInfo{
+		URI:         uri,
+		Name:        name,
+		Description: description,
+		MimeType:    mimeType,
+	}
+	a.mu.Unlock()
+
+	a.logger.Info("", "Resource registered successfully: %s", uri)
+	return nil
+}
+
+// RegisterResourceTemplate registers a family of resources whose URIs match
+// an RFC 6570 level 1 template, e.g. "file:///logs/{date}.log". Reads of a
+// matching URI call handler with the value of each variable, e.g.
+// {"date": "2024-01-02"} for "file:///logs/2024-01-02.log".
+func (a *GoSDKAdapter) RegisterResourceTemplate(uriTemplate, name, description, mimeType string, handler framework.ResourceTemplateHandler) error {
+	a.logger.Debug("", "Registering resource template: %s", uriTemplate)
+
+	// Input validation
+	if err := ValidateResourceRegistration(uriTemplate, name, description, handler); err != nil {
+		return fmt.Errorf("resource template registration: %w", err)
+	}
+	tmpl, err := ParseURITemplate(uriTemplate)
+	if err != nil {
+		return fmt.Errorf("resource template registration: %w", err)
+	}
+
+	// Create resource template definition
+	resourceTemplate := &mcp.ResourceTemplate{
+		URITemplate: uriTemplate,
+		Name:        name,
+		Description: description,
+		MIMEType:    mimeType,
+	}
+
+	read := func(ctx context.Context, uri string) ([]byte, string, error) {
+		vars, ok := tmpl.Match(uri)
+		if !ok {
+			return nil, "", fmt.Errorf("URI %q does not match template %q", uri, uriTemplate)
+		}
+		return handler(ctx, uri, vars)
+	}
+
+	a.server.AddResourceTemplate(resourceTemplate, a.resourceHandler(read))
+	a.mu.Lock()
+	if a.resourceTemplateInfo == nil {
+		a.resourceTemplateInfo = make(map[string]types.ResourceInfo)
+	}
+	a.resourceTemplateInfo[uriTemplate] = types.ResourceInfo{
+		URI:         uriTemplate,
+		Name:        name,
+		Description: description,
+		MimeType:    mimeType,
+	}
+	a.mu.Unlock()
+
+	a.logger.Info("", "Resource template registered successfully: %s", uriTemplate)
+	return nil
+}
+
+// SubscribeResource records a subscriber to changes of the resource at uri.
+// Client resources/subscribe requests call it; servers with other ways of
+// tracking interest may call it directly. Each call should be matched by an
+// UnsubscribeResource.
+func (a *GoSDKAdapter) SubscribeResource(uri string) {
+	a.mu.Lock()
+	a.subscriptions[uri]++
+	a.mu.Unlock()
+	a.logger.Debug("", "Resource subscribed: %s", uri)
+}
+
+// UnsubscribeResource removes a subscriber added by SubscribeResource.
+// Unsubscribing from a URI without subscribers does nothing.
+func (a *GoSDKAdapter) UnsubscribeResource(uri string) {
+	a.mu.Lock()
+	if a.subscriptions[uri] > 1 {
+		a.subscriptions[uri]--
+	} else {
+		delete(a.subscriptions, uri)
+	}
+	a.mu.Unlock()
+	a.logger.Debug("", "Resource unsubscribed: %s", uri)
+}
+
+// IsResourceSubscribed reports whether the resource at uri has subscribers
+func (a *GoSDKAdapter) IsResourceSubscribed(uri string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.subscriptions[uri] > 0
+}
+
+// NotifyResourceUpdated tells subscribed clients that the content of the
+// resource at uri changed, with a notifications/resources/updated message, so
+// they can read it again. It does nothing if the resource has no subscribers.
+func (a *GoSDKAdapter) NotifyResourceUpdated(uri string) error {
+	if !a.IsResourceSubscribed(uri) {
+		return nil
+	}
+	return a.server.ResourceUpdated(context.Background(), &mcp.ResourceUpdatedNotificationParams{URI: uri})
+}
+
+// resourceHandler adapts a framework resource handler to the SDK, applying
+// validation, size limits and the middleware chain
+func (a *GoSDKAdapter) resourceHandler(handler framework.ResourceHandler) mcp.ResourceHandler {
 	// Create base resource handler that matches the new API
 	// The new API uses: func(context.Context, *ReadResourceRequest) (*ReadResourceResult, error)
 	baseResourceHandler := func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
@@ -232,6 +755,9 @@ func (a *GoSDKAdapter) RegisterResource(uri, name, description, mimeType string,
 		if data == nil {
 			data = []byte{} // Empty data is valid
 		}
+		if a.maxResourceBytes > 0 && len(data) > a.maxResourceBytes {
+			return nil, fmt.Errorf("resource %q result size %d bytes exceeds limit of %d bytes", req.Params.URI, len(data), a.maxResourceBytes)
+		}
 
 		return &mcp.ReadResourceResult{
 			Contents: []*mcp.ResourceContents{
@@ -248,19 +774,16 @@ func (a *GoSDKAdapter) RegisterResource(uri, name, description, mimeType string,
 	wrappedResourceHandler := a.middleware.WrapResourceHandler(baseResourceHandler)
 
 	// Convert ResourceHandlerFunc to mcp.ResourceHandler by wrapping (function signatures match)
-	resourceHandler := mcp.ResourceHandler(func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return mcp.ResourceHandler(func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
 		return wrappedResourceHandler(ctx, req)
 	})
-
-	// Use server.AddResource with the new API
-	a.server.AddResource(resource, resourceHandler)
-
-	a.logger.Info("", "Resource registered successfully: %s", uri)
-	return nil
 }
 
-// Run starts the server with the given transport
+// Run starts the server with the given transport. When it returns the
+// adapter is closed (see Close).
 func (a *GoSDKAdapter) Run(ctx context.Context, transport framework.Transport) error {
+	defer a.Close()
+
 	// Check context cancellation
 	if err := ValidateContext(ctx); err != nil {
 		return err
@@ -276,46 +799,71 @@ func (a *GoSDKAdapter) Run(ctx context.Context, transport framework.Transport) e
 		transport = &framework.StdioTransport{}
 	}
 
+	// Stop serving on SIGINT/SIGTERM if requested; transports are still
+	// started and stopped with the caller's context
+	runCtx := ctx
+	if a.shutdownSignals {
+		var cancel context.CancelFunc
+		runCtx, cancel = platform.NotifyShutdown(ctx)
+		defer cancel()
+	}
+
 	// Convert framework transport to go-sdk transport based on type
 	var mcpTransport mcp.Transport
 	switch transport.Type() {
 	case "stdio":
 		mcpTransport = &mcp.StdioTransport{}
 	case "sse":
-		// For SSE transport, we need to use the framework's SSETransport
-		// The MCP SDK doesn't have a built-in SSE transport, so we'll use
-		// the HTTP transport with SSE support
-		// Note: The actual SSE handling is done by the framework transport
-		// The adapter just needs to know that SSE is being used
 		sseTransport, ok := transport.(*framework.SSETransport)
 		if !ok {
 			return fmt.Errorf("SSE transport must be of type *framework.SSETransport")
 		}
-		// The framework SSETransport manages the HTTP server
-		// The MCP SDK will use stdio for now, but the framework transport
-		// handles the SSE connection management
-		// TODO: When MCP SDK adds SSE support, integrate it here
-		a.logger.Warn("", "SSE transport: MCP SDK SSE support not yet available, using framework transport")
-		// For now, we'll use stdio as a fallback, but the framework transport
-		// will handle the actual SSE connections
-		mcpTransport = &mcp.StdioTransport{}
-		_ = sseTransport // Acknowledge SSE transport is provided
+		// Each SSE client gets its own session
+		return a.serveSessions(ctx, runCtx, sseSessions{sseTransport})
+	case "websocket":
+		wsTransport, ok := transport.(*framework.WSTransport)
+		if !ok {
+			return fmt.Errorf("WebSocket transport must be of type *framework.WSTransport")
+		}
+		// Each WebSocket client gets its own session
+		return a.serveSessions(ctx, runCtx, wsSessions{wsTransport})
 	default:
 		return fmt.Errorf("unsupported transport type: %s", transport.Type())
 	}
 
+	return a.serve(ctx, runCtx, transport, mcpTransport)
+}
+
+// serve starts transport, runs the server on mcpTransport until it stops or
+// runCtx is done, then stops transport
+func (a *GoSDKAdapter) serve(ctx, runCtx context.Context, transport framework.Transport, mcpTransport mcp.Transport) error {
 	// Start the transport
 	if err := transport.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start transport: %w", err)
 	}
 
 	// Run the server with the transport
-	if err := a.server.Run(ctx, mcpTransport); err != nil {
+	sessionsBefore := a.initializedSessions.Load()
+	if err := a.server.Run(runCtx, mcpTransport); err != nil {
+		if runCtx.Err() != nil && ctx.Err() == nil {
+			// Shutdown signal received: a clean exit
+			a.logger.Info("", "Shutdown signal received, stopping server")
+			return transport.Stop(ctx)
+		}
 		// Try to stop transport on error
 		_ = transport.Stop(ctx)
 		return fmt.Errorf("server run failed: %w", err)
 	}
 
+	// A nil return means the connection closed. If no client ever
+	// initialized, nothing was served (e.g. stdin was already closed at
+	// startup), which supervisors should not mistake for a clean shutdown.
+	if a.initializedSessions.Load() == sessionsBefore {
+		a.logger.Warn("", "Server stopped before any client initialized a session (%s connection closed at startup?); nothing was served", transport.Type())
+	} else {
+		a.logger.Info("", "Client disconnected, stopping server")
+	}
+
 	// Stop the transport when done
 	if err := transport.Stop(ctx); err != nil {
 		return fmt.Errorf("failed to stop transport: %w", err)
@@ -324,25 +872,68 @@ func (a *GoSDKAdapter) Run(ctx context.Context, transport framework.Transport) e
 	return nil
 }
 
+// Close releases the resources registered with WithCloser, in reverse order.
+// Run calls it when it returns; call it directly when the adapter is used
+// without Run (e.g. for CLI mode). Only the first call has any effect.
+func (a *GoSDKAdapter) Close() error {
+	a.closeOnce.Do(func() {
+		for i := len(a.closers) - 1; i >= 0; i-- {
+			a.closers[i]()
+		}
+	})
+	return nil
+}
+
+// Server returns the underlying go-sdk server, for callers that need to
+// connect it to transports not covered by Run (e.g. in-memory tests)
+func (a *GoSDKAdapter) Server() *mcp.Server {
+	return a.server
+}
+
+// Metrics returns the adapter's tool call metrics, or nil unless it was
+// created with WithMetrics
+func (a *GoSDKAdapter) Metrics() *MetricsMiddleware {
+	return a.metrics
+}
+
+// Logger returns the adapter's logger, e.g. so a CLI can apply --verbose or
+// --quiet to it
+func (a *GoSDKAdapter) Logger() *logging.Logger {
+	return a.logger
+}
+
 // GetName returns the server name
 func (a *GoSDKAdapter) GetName() string {
 	return a.name
 }
 
 // CallTool executes a tool directly (for CLI mode)
-// Optimized for CLI usage with direct map lookup (O(1))
+// Optimized for CLI usage with direct map lookup (O(1)). Calls pass through
+// the tool middleware like calls from MCP clients; a call rejected by
+// middleware returns the rejection as an error.
 func (a *GoSDKAdapter) CallTool(ctx context.Context, name string, args json.RawMessage) ([]types.TextContent, error) {
 	// Fast path: direct map lookup (O(1))
+	a.mu.RLock()
 	handler, exists := a.toolHandlers[name]
+	a.mu.RUnlock()
 	if !exists {
-		return nil, fmt.Errorf("tool %q not found", name)
+		return nil, &framework.ErrToolNotFound{ToolName: name}
 	}
-	return handler(ctx, args)
+	if handler == nil {
+		return nil, fmt.Errorf("tool %q has no handler", name)
+	}
+	args, dryRun, err := framework.ExtractDryRun(args)
+	if err != nil {
+		return nil, err
+	}
+	return handler(framework.WithDryRun(ctx, dryRun), args)
 }
 
-// ListTools returns all registered tools
+// ListTools returns all registered tools, sorted by name
 // Optimized with pre-allocated slice capacity
 func (a *GoSDKAdapter) ListTools() []types.ToolInfo {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
 	if len(a.toolInfo) == 0 {
 		return nil // Return nil slice for empty (better than empty slice)
 	}
@@ -350,5 +941,59 @@ func (a *GoSDKAdapter) ListTools() []types.ToolInfo {
 	for _, info := range a.toolInfo {
 		tools = append(tools, info)
 	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
 	return tools
 }
+
+// ListToolsSorted returns all registered tools sorted by name.
+// Equivalent to ListTools, for callers that want to make the ordering
+// requirement explicit.
+func (a *GoSDKAdapter) ListToolsSorted() []types.ToolInfo {
+	return a.ListTools()
+}
+
+// ListPrompts returns all registered prompts, sorted by name
+func (a *GoSDKAdapter) ListPrompts() []types.PromptInfo {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if len(a.promptInfo) == 0 {
+		return nil
+	}
+	prompts := make([]types.PromptInfo, 0, len(a.promptInfo))
+	for _, info := range a.promptInfo {
+		prompts = append(prompts, info)
+	}
+	sort.Slice(prompts, func(i, j int) bool { return prompts[i].Name < prompts[j].Name })
+	return prompts
+}
+
+// ListResources returns all registered resources, sorted by URI
+func (a *GoSDKAdapter) ListResources() []types.ResourceInfo {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if len(a.resourceInfo) == 0 {
+		return nil
+	}
+	resources := make([]types.ResourceInfo, 0, len(a.resourceInfo))
+	for _, info := range a.resourceInfo {
+		resources = append(resources, info)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].URI < resources[j].URI })
+	return resources
+}
+
+// ListResourceTemplates returns all registered resource templates, sorted by
+// URI template (which is reported in the URI field)
+func (a *GoSDKAdapter) ListResourceTemplates() []types.ResourceInfo {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if len(a.resourceTemplateInfo) == 0 {
+		return nil
+	}
+	templates := make([]types.ResourceInfo, 0, len(a.resourceTemplateInfo))
+	for _, info := range a.resourceTemplateInfo {
+		templates = append(templates, info)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].URI < templates[j].URI })
+	return templates
+}
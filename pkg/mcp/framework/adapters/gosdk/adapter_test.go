@@ -0,0 +1,794 @@
+package gosdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// connectInMemory connects an SDK client to the adapter's server over
+// in-memory transports and returns the client session
+func connectInMemory(t *testing.T, adapter *GoSDKAdapter) *mcp.ClientSession {
+	t.Helper()
+	return connectInMemoryWithOptions(t, adapter, nil)
+}
+
+// connectInMemoryWithOptions is connectInMemory with client options, e.g.
+// notification handlers
+func connectInMemoryWithOptions(t *testing.T, adapter *GoSDKAdapter, opts *mcp.ClientOptions) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := adapter.server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server.Connect() error = %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, opts)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() error = %v", err)
+	}
+	t.Cleanup(func() { _ = clientSession.Close() })
+
+	return clientSession
+}
+
+// echoHandler returns its raw arguments as text
+func echoHandler(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+	return []types.TextContent{{Type: "text", Text: string(args)}}, nil
+}
+
+func TestGoSDKAdapter_RegisterTool_AppliesMiddleware(t *testing.T) {
+	var seen []string
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithMiddleware(func(next ToolHandlerFunc) ToolHandlerFunc {
+		return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			seen = append(seen, req.Params.Name)
+			return next(ctx, req)
+		}
+	}))
+
+	if err := adapter.RegisterTool("echo", "Echo arguments", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+
+	session := connectInMemory(t, adapter)
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "echo",
+		Arguments: map[string]interface{}{"message": "hi"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("CallTool() returned tool error: %+v", result.Content)
+	}
+	if len(seen) != 1 || seen[0] != "echo" {
+		t.Errorf("middleware saw %v, want [echo]", seen)
+	}
+}
+
+func TestGoSDKAdapter_MaxPromptBytes(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithMaxPromptBytes(10))
+
+	if err := adapter.RegisterPrompt("small", "Small prompt", func(ctx context.Context, args map[string]interface{}) (string, error) {
+		return "tiny", nil
+	}); err != nil {
+		t.Fatalf("RegisterPrompt() error = %v", err)
+	}
+	if err := adapter.RegisterPrompt("huge", "Huge prompt", func(ctx context.Context, args map[string]interface{}) (string, error) {
+		return strings.Repeat("x", 11), nil
+	}); err != nil {
+		t.Fatalf("RegisterPrompt() error = %v", err)
+	}
+
+	session := connectInMemory(t, adapter)
+	ctx := context.Background()
+
+	if _, err := session.GetPrompt(ctx, &mcp.GetPromptParams{Name: "small"}); err != nil {
+		t.Errorf("GetPrompt(small) error = %v, want nil", err)
+	}
+	_, err := session.GetPrompt(ctx, &mcp.GetPromptParams{Name: "huge"})
+	if err == nil {
+		t.Fatal("GetPrompt(huge) should fail when result exceeds MaxPromptBytes")
+	}
+	if !strings.Contains(err.Error(), "exceeds limit") {
+		t.Errorf("GetPrompt(huge) error = %v, want size limit error", err)
+	}
+}
+
+func TestGoSDKAdapter_RegisterPromptWithArgs(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+
+	args := []types.PromptArgument{
+		{Name: "topic", Description: "What to write about", Required: true},
+		{Name: "tone", Description: "Writing style"},
+	}
+	called := 0
+	err := adapter.RegisterPromptWithArgs("write", "Write about a topic", args, func(ctx context.Context, args map[string]interface{}) (string, error) {
+		called++
+		return "Write about " + args["topic"].(string), nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterPromptWithArgs() error = %v", err)
+	}
+
+	prompts := adapter.ListPrompts()
+	if len(prompts) != 1 || len(prompts[0].Arguments) != 2 || !prompts[0].Arguments[0].Required {
+		t.Fatalf("ListPrompts() = %+v, want write with its 2 arguments", prompts)
+	}
+
+	session := connectInMemory(t, adapter)
+	ctx := context.Background()
+
+	listed, err := session.ListPrompts(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListPrompts() error = %v", err)
+	}
+	if len(listed.Prompts) != 1 || len(listed.Prompts[0].Arguments) != 2 {
+		t.Fatalf("client ListPrompts() = %+v, want 1 prompt with 2 arguments", listed.Prompts)
+	}
+	if arg := listed.Prompts[0].Arguments[0]; arg.Name != "topic" || !arg.Required || arg.Description != "What to write about" {
+		t.Errorf("Arguments[0] = %+v, want required topic", arg)
+	}
+
+	_, err = session.GetPrompt(ctx, &mcp.GetPromptParams{Name: "write", Arguments: map[string]string{"tone": "formal"}})
+	if err == nil {
+		t.Fatal("GetPrompt() without a required argument should fail")
+	}
+	if !strings.Contains(err.Error(), `missing required argument "topic"`) {
+		t.Errorf("GetPrompt() error = %v, want missing argument error", err)
+	}
+	if called != 0 {
+		t.Errorf("handler called %d times for an invalid request, want 0", called)
+	}
+
+	result, err := session.GetPrompt(ctx, &mcp.GetPromptParams{Name: "write", Arguments: map[string]string{"topic": "Go"}})
+	if err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+	if text := result.Messages[0].Content.(*mcp.TextContent).Text; text != "Write about Go" {
+		t.Errorf("GetPrompt() text = %q, want %q", text, "Write about Go")
+	}
+
+	if err := adapter.RegisterPromptWithArgs("dup", "Duplicate args", []types.PromptArgument{{Name: "a"}, {Name: "a"}}, func(ctx context.Context, args map[string]interface{}) (string, error) {
+		return "", nil
+	}); err == nil {
+		t.Error("RegisterPromptWithArgs() with duplicate arguments should fail")
+	}
+}
+
+func TestGoSDKAdapter_RegisterResourceTemplate(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+
+	var gotURI string
+	var gotVars map[string]string
+	err := adapter.RegisterResourceTemplate("file:///logs/{date}.log", "logs", "Daily logs", "text/plain",
+		func(ctx context.Context, uri string, vars map[string]string) ([]byte, string, error) {
+			gotURI, gotVars = uri, vars
+			return []byte("log for " + vars["date"]), "text/plain", nil
+		})
+	if err != nil {
+		t.Fatalf("RegisterResourceTemplate() error = %v", err)
+	}
+	if templates := adapter.ListResourceTemplates(); len(templates) != 1 || templates[0].URI != "file:///logs/{date}.log" {
+		t.Errorf("ListResourceTemplates() = %+v, want the logs template", templates)
+	}
+
+	session := connectInMemory(t, adapter)
+	ctx := context.Background()
+
+	listed, err := session.ListResourceTemplates(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResourceTemplates() error = %v", err)
+	}
+	if len(listed.ResourceTemplates) != 1 || listed.ResourceTemplates[0].URITemplate != "file:///logs/{date}.log" {
+		t.Errorf("client ListResourceTemplates() = %+v, want the logs template", listed.ResourceTemplates)
+	}
+
+	result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "file:///logs/2024-01-02.log"})
+	if err != nil {
+		t.Fatalf("ReadResource() error = %v", err)
+	}
+	if gotURI != "file:///logs/2024-01-02.log" || gotVars["date"] != "2024-01-02" || len(gotVars) != 1 {
+		t.Errorf("handler got uri %q, vars %v; want date=2024-01-02", gotURI, gotVars)
+	}
+	if text := result.Contents[0].Text; text != "log for 2024-01-02" {
+		t.Errorf("ReadResource() text = %q, want %q", text, "log for 2024-01-02")
+	}
+
+	if err := adapter.RegisterResourceTemplate("file:///{path", "bad", "Bad template", "text/plain",
+		func(ctx context.Context, uri string, vars map[string]string) ([]byte, string, error) {
+			return nil, "", nil
+		}); err == nil {
+		t.Error("RegisterResourceTemplate() with an invalid template should fail")
+	}
+}
+
+func TestGoSDKAdapter_ResourceSubscriptions(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+	for _, uri := range []string{"test://live", "test://other"} {
+		if err := adapter.RegisterResource(uri, "resource", "A resource", "text/plain", func(ctx context.Context, uri string) ([]byte, string, error) {
+			return []byte("data"), "text/plain", nil
+		}); err != nil {
+			t.Fatalf("RegisterResource(%s) error = %v", uri, err)
+		}
+	}
+
+	updated := make(chan string, 10)
+	session := connectInMemoryWithOptions(t, adapter, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(ctx context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	})
+	ctx := context.Background()
+
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: "test://live"}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if !adapter.IsResourceSubscribed("test://live") {
+		t.Fatal("subscription to test://live was not recorded")
+	}
+	if adapter.IsResourceSubscribed("test://other") {
+		t.Fatal("test://other should have no subscribers")
+	}
+
+	// Unsubscribed URI: no-op
+	if err := adapter.NotifyResourceUpdated("test://other"); err != nil {
+		t.Fatalf("NotifyResourceUpdated(other) error = %v", err)
+	}
+	if err := adapter.NotifyResourceUpdated("test://live"); err != nil {
+		t.Fatalf("NotifyResourceUpdated(live) error = %v", err)
+	}
+	select {
+	case uri := <-updated:
+		if uri != "test://live" {
+			t.Errorf("notified of %q, want test://live only", uri)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("client was not notified of the update")
+	}
+
+	if err := session.Unsubscribe(ctx, &mcp.UnsubscribeParams{URI: "test://live"}); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	if adapter.IsResourceSubscribed("test://live") {
+		t.Error("subscription to test://live should be removed")
+	}
+	if err := adapter.NotifyResourceUpdated("test://live"); err != nil {
+		t.Fatalf("NotifyResourceUpdated(live) after unsubscribe error = %v", err)
+	}
+	select {
+	case uri := <-updated:
+		t.Errorf("notified of %q after unsubscribing", uri)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestGoSDKAdapter_SubscribeResource_Counts(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+
+	adapter.SubscribeResource("test://live")
+	adapter.SubscribeResource("test://live")
+	adapter.UnsubscribeResource("test://live")
+	if !adapter.IsResourceSubscribed("test://live") {
+		t.Error("one subscriber should remain")
+	}
+	adapter.UnsubscribeResource("test://live")
+	adapter.UnsubscribeResource("test://live") // extra unsubscribe is ignored
+	if adapter.IsResourceSubscribed("test://live") {
+		t.Error("no subscribers should remain")
+	}
+	adapter.SubscribeResource("test://live")
+	if !adapter.IsResourceSubscribed("test://live") {
+		t.Error("subscribing again after extra unsubscribes should count")
+	}
+}
+
+func TestGoSDKAdapter_RegisterTool_OutputSchema(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+
+	outputSchema := types.ToolSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"count": map[string]interface{}{"type": "integer"},
+		},
+		Required: []string{"count"},
+	}
+	// The handler returns its arguments, so each call picks its result
+	if err := adapter.RegisterTool("count", "Count things", types.ToolSchema{Type: "object"}, echoHandler, framework.ToolOutputSchema(outputSchema)); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	if info := adapter.ListTools(); len(info) != 1 || info[0].OutputSchema == nil {
+		t.Fatalf("ListTools() = %+v, want the tool with its output schema", info)
+	}
+
+	session := connectInMemory(t, adapter)
+	ctx := context.Background()
+
+	listed, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	if listed.Tools[0].OutputSchema == nil {
+		t.Error("output schema not advertised to clients")
+	}
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "count", Arguments: map[string]any{"count": 3}})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("valid output reported as error: %+v", result.Content)
+	}
+	if structured, ok := result.StructuredContent.(map[string]any); !ok || structured["count"] != float64(3) {
+		t.Errorf("StructuredContent = %#v, want {count: 3}", result.StructuredContent)
+	}
+
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "count", Arguments: map[string]any{"count": "three"}})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("output with a field of the wrong type should be a tool error")
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "$.count: expected integer, got string") {
+		t.Errorf("error = %q, want the schema mismatch", text)
+	}
+
+	// CLI mode validates too
+	if _, err := adapter.CallTool(ctx, "count", json.RawMessage(`{"count":"three"}`)); err == nil {
+		t.Error("CallTool() with mismatching output should fail")
+	}
+
+	if err := adapter.RegisterTool("bad", "Bad output schema", types.ToolSchema{Type: "object"}, echoHandler, framework.ToolOutputSchema(types.ToolSchema{Type: "array"})); err == nil {
+		t.Error("RegisterTool() with a non-object output schema should fail")
+	}
+}
+
+func TestGoSDKAdapter_MaxResourceBytes(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithMaxResourceBytes(10))
+
+	if err := adapter.RegisterResource("test://small", "small", "Small resource", "text/plain", func(ctx context.Context, uri string) ([]byte, string, error) {
+		return []byte("tiny"), "text/plain", nil
+	}); err != nil {
+		t.Fatalf("RegisterResource() error = %v", err)
+	}
+	if err := adapter.RegisterResource("test://huge", "huge", "Huge resource", "text/plain", func(ctx context.Context, uri string) ([]byte, string, error) {
+		return make([]byte, 11), "text/plain", nil
+	}); err != nil {
+		t.Fatalf("RegisterResource() error = %v", err)
+	}
+
+	session := connectInMemory(t, adapter)
+	ctx := context.Background()
+
+	if _, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "test://small"}); err != nil {
+		t.Errorf("ReadResource(small) error = %v, want nil", err)
+	}
+	_, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "test://huge"})
+	if err == nil {
+		t.Fatal("ReadResource(huge) should fail when result exceeds MaxResourceBytes")
+	}
+	if !strings.Contains(err.Error(), "exceeds limit") {
+		t.Errorf("ReadResource(huge) error = %v, want size limit error", err)
+	}
+}
+
+func TestGoSDKAdapter_UTF8Policy(t *testing.T) {
+	invalidHandler := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		return []types.TextContent{{Type: "text", Text: "bad \xff bytes"}}, nil
+	}
+
+	tests := []struct {
+		name        string
+		policy      UTF8Policy
+		wantIsError bool
+		wantText    string
+	}{
+		{name: "replace", policy: UTF8Replace, wantText: "bad � bytes"},
+		{name: "reject", policy: UTF8Reject, wantIsError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := NewGoSDKAdapter("test", "1.0.0", WithUTF8Policy(tt.policy))
+			if err := adapter.RegisterTool("bad_text", "Returns invalid UTF-8", types.ToolSchema{Type: "object"}, invalidHandler); err != nil {
+				t.Fatalf("RegisterTool() error = %v", err)
+			}
+
+			session := connectInMemory(t, adapter)
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "bad_text"})
+			if err != nil {
+				t.Fatalf("CallTool() error = %v", err)
+			}
+			if result.IsError != tt.wantIsError {
+				t.Errorf("IsError = %v, want %v", result.IsError, tt.wantIsError)
+			}
+			if tt.wantText != "" {
+				text, ok := result.Content[0].(*mcp.TextContent)
+				if !ok || text.Text != tt.wantText {
+					t.Errorf("Content[0] = %#v, want text %q", result.Content[0], tt.wantText)
+				}
+			}
+		})
+	}
+}
+
+func TestGoSDKAdapter_RegisterTool_Annotations(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+	openWorld := true
+	annotations := types.ToolAnnotations{DestructiveHint: true, IdempotentHint: true, OpenWorldHint: &openWorld}
+
+	if err := adapter.RegisterTool("delete", "Delete things", types.ToolSchema{Type: "object"}, echoHandler, framework.ToolAnnotations(annotations)); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	if err := adapter.RegisterTool("plain", "No annotations", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+
+	// ToolInfo
+	infos := make(map[string]types.ToolInfo)
+	for _, info := range adapter.ListTools() {
+		infos[info.Name] = info
+	}
+	if got := infos["delete"].Annotations; got == nil || *got != annotations {
+		t.Errorf("ListTools() delete annotations = %+v, want %+v", got, annotations)
+	}
+	if got := infos["plain"].Annotations; got != nil {
+		t.Errorf("ListTools() plain annotations = %+v, want nil", got)
+	}
+
+	// tools/list
+	session := connectInMemory(t, adapter)
+	listed, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	for _, tool := range listed.Tools {
+		switch tool.Name {
+		case "delete":
+			a := tool.Annotations
+			if a == nil {
+				t.Fatal("tools/list delete annotations = nil")
+			}
+			if a.ReadOnlyHint || a.DestructiveHint == nil || !*a.DestructiveHint || !a.IdempotentHint ||
+				a.OpenWorldHint == nil || !*a.OpenWorldHint {
+				t.Errorf("tools/list delete annotations = %+v, want destructive, idempotent and open world", a)
+			}
+		case "plain":
+			if tool.Annotations != nil {
+				t.Errorf("tools/list plain annotations = %+v, want nil", tool.Annotations)
+			}
+		}
+	}
+}
+
+func TestGoSDKAdapter_DryRun(t *testing.T) {
+	var writes int
+	writeHandler := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		if strings.Contains(string(args), framework.DryRunArg) {
+			t.Errorf("handler args = %s, want dry-run flag stripped", args)
+		}
+		if framework.IsDryRun(ctx) {
+			return []types.TextContent{{Type: "text", Text: "would write"}}, nil
+		}
+		writes++
+		return []types.TextContent{{Type: "text", Text: "wrote"}}, nil
+	}
+
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+	if err := adapter.RegisterTool("write", "Write data", types.ToolSchema{Type: "object"}, writeHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	session := connectInMemory(t, adapter)
+	ctx := context.Background()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "write",
+		Arguments: map[string]interface{}{"data": "x", framework.DryRunArg: true},
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; text != "would write" {
+		t.Errorf("dry-run result = %q, want %q", text, "would write")
+	}
+
+	// CLI path honors the flag too
+	if _, err := adapter.CallTool(ctx, "write", json.RawMessage(`{"data":"x","_dry_run":true}`)); err != nil {
+		t.Fatalf("adapter.CallTool() error = %v", err)
+	}
+	if writes != 0 {
+		t.Fatalf("writes = %d after dry runs, want 0", writes)
+	}
+
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "write", Arguments: map[string]interface{}{"data": "x"}}); err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if writes != 1 {
+		t.Errorf("writes = %d after real call, want 1", writes)
+	}
+}
+
+func TestGoSDKAdapter_ListSorted(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+	names := []string{"zeta", "alpha", "mu", "beta"}
+	for _, name := range names {
+		if err := adapter.RegisterTool(name, "Tool "+name, types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+			t.Fatalf("RegisterTool(%s) error = %v", name, err)
+		}
+		promptHandler := func(ctx context.Context, args map[string]interface{}) (string, error) { return "", nil }
+		if err := adapter.RegisterPrompt(name, "Prompt "+name, promptHandler); err != nil {
+			t.Fatalf("RegisterPrompt(%s) error = %v", name, err)
+		}
+		resourceHandler := func(ctx context.Context, uri string) ([]byte, string, error) { return nil, "", nil }
+		if err := adapter.RegisterResource("test://"+name, name, "Resource "+name, "text/plain", resourceHandler); err != nil {
+			t.Fatalf("RegisterResource(%s) error = %v", name, err)
+		}
+	}
+
+	want := []string{"alpha", "beta", "mu", "zeta"}
+	if len(adapter.ListTools()) != 4 || len(adapter.ListPrompts()) != 4 || len(adapter.ListResources()) != 4 {
+		t.Fatalf("got %d tools, %d prompts, %d resources; want 4 of each",
+			len(adapter.ListTools()), len(adapter.ListPrompts()), len(adapter.ListResources()))
+	}
+	// Repeat to catch map-order nondeterminism
+	for run := 0; run < 5; run++ {
+		for _, list := range [][]types.ToolInfo{adapter.ListTools(), adapter.ListToolsSorted()} {
+			for i, tool := range list {
+				if tool.Name != want[i] {
+					t.Fatalf("tools[%d] = %q, want %q", i, tool.Name, want[i])
+				}
+			}
+		}
+		for i, prompt := range adapter.ListPrompts() {
+			if prompt.Name != want[i] {
+				t.Fatalf("ListPrompts()[%d] = %q, want %q", i, prompt.Name, want[i])
+			}
+		}
+		for i, resource := range adapter.ListResources() {
+			if resource.URI != "test://"+want[i] {
+				t.Fatalf("ListResources()[%d] = %q, want %q", i, resource.URI, "test://"+want[i])
+			}
+		}
+	}
+}
+
+func TestGoSDKAdapter_NilHandler(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+
+	var handler framework.ToolHandler
+	if err := adapter.RegisterTool("nil_tool", "Nil handler", types.ToolSchema{Type: "object"}, handler); err == nil {
+		t.Fatal("RegisterTool() with typed nil handler error = nil, want error")
+	}
+	if len(adapter.ListTools()) != 0 {
+		t.Errorf("ListTools() = %v, want no tools", adapter.ListTools())
+	}
+
+	// A nil entry left behind in the handler map must not panic
+	adapter.mu.Lock()
+	adapter.toolHandlers["broken"] = nil
+	adapter.mu.Unlock()
+	_, err := adapter.CallTool(context.Background(), "broken", json.RawMessage(`{}`))
+	if err == nil || !strings.Contains(err.Error(), "no handler") {
+		t.Errorf("CallTool() error = %v, want no handler error", err)
+	}
+}
+
+func TestGoSDKAdapter_UnregisterTool(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+	for _, name := range []string{"echo", "keep"} {
+		if err := adapter.RegisterTool(name, "Echo arguments", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+			t.Fatalf("RegisterTool(%s) error = %v", name, err)
+		}
+	}
+
+	// Connect a client that is told when the tool list changes
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := adapter.server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server.Connect() error = %v", err)
+	}
+	defer serverSession.Close()
+	changed := make(chan struct{}, 1)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ToolListChangedHandler: func(context.Context, *mcp.ToolListChangedRequest) {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		},
+	})
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() error = %v", err)
+	}
+	defer session.Close()
+
+	if err := adapter.UnregisterTool("echo"); err != nil {
+		t.Fatalf("UnregisterTool() error = %v", err)
+	}
+	select {
+	case <-changed:
+	case <-time.After(2 * time.Second):
+		t.Error("no tools/list_changed notification after UnregisterTool")
+	}
+
+	if got := toolNames(adapter); got != "keep" {
+		t.Errorf("ListTools() = %s, want keep", got)
+	}
+	listed, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	if len(listed.Tools) != 1 || listed.Tools[0].Name != "keep" {
+		t.Errorf("tools/list = %+v, want only keep", listed.Tools)
+	}
+
+	_, err = adapter.CallTool(ctx, "echo", json.RawMessage(`{}`))
+	if !framework.IsToolNotFound(err) || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("CallTool(echo) error = %v, want tool not found", err)
+	}
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "echo"}); err == nil {
+		t.Error("client CallTool(echo) after UnregisterTool error = nil")
+	}
+
+	if err := adapter.UnregisterTool("echo"); !framework.IsToolNotFound(err) {
+		t.Errorf("UnregisterTool() of a missing tool error = %v, want ErrToolNotFound", err)
+	}
+
+	// The name can be registered again
+	if err := adapter.RegisterTool("echo", "Echo arguments", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+		t.Fatalf("RegisterTool() after UnregisterTool error = %v", err)
+	}
+	if result, err := adapter.CallTool(ctx, "echo", json.RawMessage(`{"a":1}`)); err != nil || result[0].Text != `{"a":1}` {
+		t.Errorf("CallTool(echo) = %v, %v after re-registering", result, err)
+	}
+}
+
+func TestGoSDKAdapter_RegisterTool_Replaces(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+	constant := func(text string) framework.ToolHandler {
+		return func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+			return []types.TextContent{{Type: "text", Text: text}}, nil
+		}
+	}
+	if err := adapter.RegisterTool("tool", "First", types.ToolSchema{Type: "object"}, constant("first")); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	if err := adapter.RegisterTool("tool", "Second", types.ToolSchema{Type: "object"}, constant("second")); err != nil {
+		t.Fatalf("RegisterTool() replacement error = %v", err)
+	}
+
+	tools := adapter.ListTools()
+	if len(tools) != 1 || tools[0].Description != "Second" {
+		t.Errorf("ListTools() = %+v, want only the replacement", tools)
+	}
+
+	session := connectInMemory(t, adapter)
+	listed, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	if len(listed.Tools) != 1 {
+		t.Errorf("tools/list returned %d tools, want 1", len(listed.Tools))
+	}
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "tool"})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; text != "second" {
+		t.Errorf("CallTool() = %q, want the replacement handler's result", text)
+	}
+}
+
+func TestGoSDKAdapter_RegisterRichTool(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+	png := []byte{0x89, 'P', 'N', 'G'}
+	handler := func(ctx context.Context, args json.RawMessage) ([]types.Content, error) {
+		if string(args) == `{"fail":true}` {
+			return nil, context.Canceled
+		}
+		return []types.Content{types.Text("chart"), types.Image(png, "image/png")}, nil
+	}
+	if err := adapter.RegisterRichTool("chart", "Render a chart", types.ToolSchema{Type: "object"}, handler); err != nil {
+		t.Fatalf("RegisterRichTool() error = %v", err)
+	}
+	if err := adapter.RegisterRichTool("nil", "Nil handler", types.ToolSchema{Type: "object"}, nil); err == nil {
+		t.Error("RegisterRichTool() with nil handler error = nil")
+	}
+
+	session := connectInMemory(t, adapter)
+	ctx := context.Background()
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "chart"})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError || len(result.Content) != 2 {
+		t.Fatalf("CallTool() = %+v, want text and image", result)
+	}
+	image, ok := result.Content[1].(*mcp.ImageContent)
+	if !ok || image.MIMEType != "image/png" || string(image.Data) != string(png) {
+		t.Errorf("content[1] = %#v, want the PNG image", result.Content[1])
+	}
+
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "chart", Arguments: map[string]interface{}{"fail": true}})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if !result.IsError {
+		t.Error("CallTool() of a failing rich tool should return a tool error")
+	}
+
+	// CLI mode describes the image as text
+	texts, err := adapter.CallTool(ctx, "chart", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("adapter.CallTool() error = %v", err)
+	}
+	if len(texts) != 2 || texts[0].Text != "chart" || texts[1].Text != "[image image/png, 4 bytes]" {
+		t.Errorf("adapter.CallTool() = %+v", texts)
+	}
+}
+
+// nopWriteCloser discards writes
+type nopWriteCloser struct{}
+
+func (nopWriteCloser) Write(p []byte) (int, error) { return len(p), nil }
+func (nopWriteCloser) Close() error                { return nil }
+
+func TestGoSDKAdapter_ServeWarnsWhenNothingServed(t *testing.T) {
+	var logs bytes.Buffer
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithLogger(logging.NewLoggerWithWriter(&logs)))
+	ctx := context.Background()
+
+	// EOF on the connection before any client initialized
+	empty := &mcp.IOTransport{Reader: io.NopCloser(strings.NewReader("")), Writer: nopWriteCloser{}}
+	if err := adapter.serve(ctx, ctx, &framework.StdioTransport{}, empty); err != nil {
+		t.Fatalf("serve() error = %v", err)
+	}
+	if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), "nothing was served") {
+		t.Errorf("no warning for an immediate EOF:\n%s", logs.String())
+	}
+
+	// A client that initialized and then disconnected is a clean shutdown
+	logs.Reset()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	done := make(chan error, 1)
+	go func() { done <- adapter.serve(ctx, ctx, &framework.StdioTransport{}, serverTransport) }()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if err := session.Ping(ctx, nil); err != nil { // the server has handled initialized by now
+		t.Fatalf("Ping() error = %v", err)
+	}
+	session.Close()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("serve() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("serve() did not return after the client disconnected")
+	}
+	if strings.Contains(logs.String(), "nothing was served") {
+		t.Errorf("warning logged after serving a client:\n%s", logs.String())
+	}
+	if !strings.Contains(logs.String(), "Client disconnected") {
+		t.Errorf("clean shutdown not logged:\n%s", logs.String())
+	}
+}
@@ -0,0 +1,72 @@
+package gosdk
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ErrBudgetExceeded is returned (wrapped) when a request takes longer than
+// the time budget of a BudgetMiddleware
+var ErrBudgetExceeded = errors.New("request exceeded time budget")
+
+// BudgetMiddleware bounds the total time of a request: the middleware
+// registered after it plus the handler. Unlike a per-tool timeout (see
+// WithToolTimeout) the budget also covers slow middleware, e.g. a remote
+// policy check, so requests stay within an SLO.
+//
+// The request's context is canceled when the budget runs out and the
+// request fails at once with ErrBudgetExceeded, without waiting for the
+// rest of the chain to return. Tool calls get a tool error result; prompt
+// and resource requests an error.
+//
+// Register it first so the budget covers all other middleware.
+type BudgetMiddleware struct {
+	budget time.Duration
+}
+
+// NewBudgetMiddleware creates middleware giving each request budget to
+// complete. A budget <= 0 disables the limit.
+//
+// Example:
+//
+//	adapter := NewGoSDKAdapter("server", "1.0.0",
+//		WithMiddleware(NewBudgetMiddleware(2*time.Second)),
+//		WithMiddleware(PolicyMiddleware(policy)),
+//	)
+func NewBudgetMiddleware(budget time.Duration) *BudgetMiddleware {
+	return &BudgetMiddleware{budget: budget}
+}
+
+// ToolMiddleware wraps a tool handler with the time budget
+func (bm *BudgetMiddleware) ToolMiddleware(next ToolHandlerFunc) ToolHandlerFunc {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := callWithTimeout(ctx, bm.budget, ErrBudgetExceeded, func(ctx context.Context) (*mcp.CallToolResult, error) {
+			return next(ctx, req)
+		})
+		if errors.Is(err, ErrBudgetExceeded) {
+			return toolErrorResult("Tool call aborted: %v", err), nil
+		}
+		return result, err
+	}
+}
+
+// PromptMiddleware wraps a prompt handler with the time budget
+func (bm *BudgetMiddleware) PromptMiddleware(next PromptHandlerFunc) PromptHandlerFunc {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return callWithTimeout(ctx, bm.budget, ErrBudgetExceeded, func(ctx context.Context) (*mcp.GetPromptResult, error) {
+			return next(ctx, req)
+		})
+	}
+}
+
+// ResourceMiddleware wraps a resource handler with the time budget
+func (bm *BudgetMiddleware) ResourceMiddleware(next ResourceHandlerFunc) ResourceHandlerFunc {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		return callWithTimeout(ctx, bm.budget, ErrBudgetExceeded, func(ctx context.Context) (*mcp.ReadResourceResult, error) {
+			return next(ctx, req)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package gosdk
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// slowMiddleware delays each tool call by delay before passing it on
+func slowMiddleware(delay time.Duration) func(ToolHandlerFunc) ToolHandlerFunc {
+	return func(next ToolHandlerFunc) ToolHandlerFunc {
+		return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			time.Sleep(delay) // ignores ctx, like a blocking remote check
+			return next(ctx, req)
+		}
+	}
+}
+
+func TestBudgetMiddleware_Tools(t *testing.T) {
+	tests := []struct {
+		name    string
+		delay   time.Duration
+		wantErr bool
+	}{
+		{name: "within budget", delay: 0, wantErr: false},
+		{name: "slow middleware", delay: 500 * time.Millisecond, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The handler alone is fast; only the middleware is slow
+			adapter := NewGoSDKAdapter("test", "1.0.0",
+				WithMiddleware(NewBudgetMiddleware(100*time.Millisecond)),
+				WithMiddleware(slowMiddleware(tt.delay)),
+			)
+			if err := adapter.RegisterTool("echo", "Echo arguments", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+				t.Fatalf("RegisterTool() error = %v", err)
+			}
+			session := connectInMemory(t, adapter)
+
+			start := time.Now()
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "echo", Arguments: map[string]any{}})
+			elapsed := time.Since(start)
+			if err != nil {
+				t.Fatalf("CallTool() error = %v", err)
+			}
+			if result.IsError != tt.wantErr {
+				t.Fatalf("IsError = %v, want %v: %+v", result.IsError, tt.wantErr, result.Content)
+			}
+			if tt.wantErr {
+				if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "request exceeded time budget") {
+					t.Errorf("error = %q, want a budget error", text)
+				}
+				if elapsed >= tt.delay {
+					t.Errorf("CallTool() took %v, want it aborted before the %v middleware finished", elapsed, tt.delay)
+				}
+			}
+		})
+	}
+}
+
+func TestBudgetMiddleware_PromptsAndResources(t *testing.T) {
+	bm := NewBudgetMiddleware(20 * time.Millisecond)
+	ctx := context.Background()
+
+	prompt := bm.PromptMiddleware(func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if _, err := prompt(ctx, nil); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("prompt error = %v, want ErrBudgetExceeded", err)
+	}
+
+	resource := bm.ResourceMiddleware(func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		return &mcp.ReadResourceResult{}, nil
+	})
+	if _, err := resource(ctx, nil); err != nil {
+		t.Errorf("fast resource error = %v", err)
+	}
+
+	// A budget <= 0 disables the limit
+	unlimited := NewBudgetMiddleware(0).PromptMiddleware(func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		time.Sleep(30 * time.Millisecond)
+		return &mcp.GetPromptResult{}, nil
+	})
+	if _, err := unlimited(ctx, nil); err != nil {
+		t.Errorf("unlimited prompt error = %v", err)
+	}
+}
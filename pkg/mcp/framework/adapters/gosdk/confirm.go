@@ -0,0 +1,216 @@
+package gosdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ConfirmationTokenArg is the argument name clients use to pass the
+// confirmation token issued for a destructive tool call
+const ConfirmationTokenArg = "_confirmation_token"
+
+// DefaultConfirmationTTL is how long a confirmation token stays valid
+const DefaultConfirmationTTL = 5 * time.Minute
+
+// pendingConfirmation records what a confirmation token authorizes
+type pendingConfirmation struct {
+	tool     string
+	argsHash string
+	expires  time.Time
+}
+
+// ConfirmationMiddleware requires an explicit second call before tools
+// annotated as destructive are executed.
+//
+// The first call to a destructive tool does not run it. Instead it returns a
+// "confirmation required" error result carrying a single-use token. Repeating
+// the call with the same arguments plus ConfirmationTokenArg set to that token
+// runs the tool; the token argument is removed before the handler sees it.
+// Tokens are bound to the tool name and arguments and expire after ttl.
+//
+// A dry run (see framework.IsDryRun) of a destructive tool does not run it
+// either: it returns a preview of the call that would be made together with a
+// token for the real call, so safety never depends on the handler honoring
+// the dry-run flag.
+//
+// Tools without annotations, or not marked destructive, pass straight through.
+type ConfirmationMiddleware struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	pending map[string]pendingConfirmation
+}
+
+// NewConfirmationMiddleware creates confirmation middleware
+// ttl <= 0 uses DefaultConfirmationTTL.
+//
+// Example:
+//
+//	confirm := NewConfirmationMiddleware(0)
+//	adapter := NewGoSDKAdapter("server", "1.0.0",
+//		WithMiddleware(confirm.ToolMiddleware),
+//	)
+func NewConfirmationMiddleware(ttl time.Duration) *ConfirmationMiddleware {
+	if ttl <= 0 {
+		ttl = DefaultConfirmationTTL
+	}
+	return &ConfirmationMiddleware{
+		ttl:     ttl,
+		pending: make(map[string]pendingConfirmation),
+	}
+}
+
+// ToolMiddleware wraps a tool handler with the confirmation check
+func (cm *ConfirmationMiddleware) ToolMiddleware(next ToolHandlerFunc) ToolHandlerFunc {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		info, ok := ToolInfoFromContext(ctx)
+		if !ok || info.Annotations == nil || !info.Annotations.DestructiveHint || req == nil || req.Params == nil {
+			return next(ctx, req)
+		}
+
+		args, token, err := splitConfirmationToken(req.Params.Arguments)
+		if err != nil {
+			return toolErrorResult("Invalid arguments: %v", err), nil
+		}
+		argsHash := hashArguments(args)
+
+		if framework.IsDryRun(ctx) {
+			token, err := cm.issue(info.Name, argsHash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to issue confirmation token: %w", err)
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: fmt.Sprintf("Dry run: %q is a destructive tool and was not executed. Arguments: %s",
+						info.Name, previewArguments(args))},
+					&mcp.TextContent{Text: fmt.Sprintf("To execute for real, call %q again without %q and with %q: %q (valid for %v).",
+						info.Name, framework.DryRunArg, ConfirmationTokenArg, token, cm.ttl)},
+				},
+			}, nil
+		}
+
+		if token == "" {
+			token, err = cm.issue(info.Name, argsHash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to issue confirmation token: %w", err)
+			}
+			return toolErrorResult("Confirmation required: %q is a destructive tool and was not executed. "+
+				"To proceed, call it again with the same arguments plus %q: %q (valid for %v).",
+				info.Name, ConfirmationTokenArg, token, cm.ttl), nil
+		}
+
+		if !cm.redeem(token, info.Name, argsHash) {
+			return toolErrorResult("Confirmation token for %q is invalid, expired, or does not match the arguments", info.Name), nil
+		}
+
+		// Pass the request on without the token argument
+		params := *req.Params
+		params.Arguments = args
+		confirmed := *req
+		confirmed.Params = &params
+		return next(ctx, &confirmed)
+	}
+}
+
+// issue creates and stores a token for the given tool call
+func (cm *ConfirmationMiddleware) issue(tool, argsHash string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	now := time.Now()
+	for t, p := range cm.pending {
+		if now.After(p.expires) {
+			delete(cm.pending, t)
+		}
+	}
+	cm.pending[token] = pendingConfirmation{
+		tool:     tool,
+		argsHash: argsHash,
+		expires:  now.Add(cm.ttl),
+	}
+	return token, nil
+}
+
+// redeem consumes a token, reporting whether it authorizes the given call
+func (cm *ConfirmationMiddleware) redeem(token, tool, argsHash string) bool {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	p, ok := cm.pending[token]
+	if !ok {
+		return false
+	}
+	delete(cm.pending, token) // single use, even on mismatch
+	return p.tool == tool && p.argsHash == argsHash && time.Now().Before(p.expires)
+}
+
+// splitConfirmationToken removes ConfirmationTokenArg from raw tool
+// arguments, returning the remaining arguments and the token (if any)
+func splitConfirmationToken(raw json.RawMessage) (json.RawMessage, string, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return raw, "", nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return nil, "", fmt.Errorf("arguments must be a JSON object: %w", err)
+	}
+	tokenRaw, ok := fields[ConfirmationTokenArg]
+	if !ok {
+		return raw, "", nil
+	}
+
+	var token string
+	if err := json.Unmarshal(tokenRaw, &token); err != nil {
+		return nil, "", fmt.Errorf("%s must be a string", ConfirmationTokenArg)
+	}
+	delete(fields, ConfirmationTokenArg)
+
+	args, err := json.Marshal(fields)
+	if err != nil {
+		return nil, "", err
+	}
+	return args, token, nil
+}
+
+// previewArguments renders tool arguments for a dry-run preview
+func previewArguments(raw json.RawMessage) string {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return "{}"
+	}
+	return string(trimmed)
+}
+
+// hashArguments returns a digest of the arguments that is stable across
+// key order and whitespace differences
+func hashArguments(raw json.RawMessage) string {
+	canonical := []byte(bytes.TrimSpace(raw))
+	var v interface{}
+	if len(canonical) > 0 && json.Unmarshal(canonical, &v) == nil {
+		if m, ok := v.(map[string]interface{}); ok && len(m) == 0 {
+			v = nil // treat {} and absent arguments alike
+		}
+		canonical, _ = json.Marshal(v)
+	} else {
+		canonical = []byte("null")
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
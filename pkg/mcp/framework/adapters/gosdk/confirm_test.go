@@ -0,0 +1,165 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var tokenPattern = regexp.MustCompile(`"_confirmation_token": "([0-9a-f]+)"`)
+
+func TestConfirmationMiddleware(t *testing.T) {
+	var deleted []string
+	deleteHandler := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		var params map[string]interface{}
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, err
+		}
+		if _, ok := params[ConfirmationTokenArg]; ok {
+			t.Error("handler received the confirmation token argument")
+		}
+		deleted = append(deleted, params["path"].(string))
+		return []types.TextContent{{Type: "text", Text: "deleted"}}, nil
+	}
+
+	confirm := NewConfirmationMiddleware(0)
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithMiddleware(confirm.ToolMiddleware))
+	if err := adapter.RegisterTool("delete", "Delete a file", types.ToolSchema{Type: "object"}, deleteHandler,
+		framework.ToolAnnotations(types.ToolAnnotations{DestructiveHint: true})); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	if err := adapter.RegisterTool("echo", "Echo arguments", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+
+	session := connectInMemory(t, adapter)
+	ctx := context.Background()
+	call := func(name string, args map[string]interface{}) *mcp.CallToolResult {
+		t.Helper()
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: args})
+		if err != nil {
+			t.Fatalf("CallTool(%s) error = %v", name, err)
+		}
+		return result
+	}
+
+	// Non-destructive tools run immediately
+	if result := call("echo", map[string]interface{}{"x": 1}); result.IsError {
+		t.Errorf("echo IsError = true, want false")
+	}
+
+	// First call returns a confirmation prompt and does not execute
+	first := call("delete", map[string]interface{}{"path": "a.txt"})
+	if !first.IsError {
+		t.Fatal("first delete call IsError = false, want confirmation required")
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("handler ran without confirmation: %v", deleted)
+	}
+	match := tokenPattern.FindStringSubmatch(first.Content[0].(*mcp.TextContent).Text)
+	if match == nil {
+		t.Fatalf("confirmation result has no token: %q", first.Content[0].(*mcp.TextContent).Text)
+	}
+	token := match[1]
+
+	// Token does not authorize different arguments (and is consumed)
+	if result := call("delete", map[string]interface{}{"path": "b.txt", ConfirmationTokenArg: token}); !result.IsError {
+		t.Error("delete with mismatched arguments IsError = false, want true")
+	}
+
+	// Confirmed call executes
+	second := call("delete", map[string]interface{}{"path": "a.txt"})
+	token = tokenPattern.FindStringSubmatch(second.Content[0].(*mcp.TextContent).Text)[1]
+	confirmed := call("delete", map[string]interface{}{"path": "a.txt", ConfirmationTokenArg: token})
+	if confirmed.IsError {
+		t.Fatalf("confirmed delete IsError = true: %v", confirmed.Content[0].(*mcp.TextContent).Text)
+	}
+	if len(deleted) != 1 || deleted[0] != "a.txt" {
+		t.Errorf("deleted = %v, want [a.txt]", deleted)
+	}
+
+	// Tokens are single use
+	if result := call("delete", map[string]interface{}{"path": "a.txt", ConfirmationTokenArg: token}); !result.IsError {
+		t.Error("reused token IsError = false, want true")
+	}
+	if len(deleted) != 1 {
+		t.Errorf("deleted = %v, want a single deletion", deleted)
+	}
+}
+
+func TestConfirmationMiddleware_DryRunIssuesToken(t *testing.T) {
+	// The handler ignores the dry-run flag; the middleware must not call it
+	var deleted int
+	deleteHandler := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		deleted++
+		return []types.TextContent{{Type: "text", Text: "deleted"}}, nil
+	}
+
+	confirm := NewConfirmationMiddleware(0)
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithMiddleware(confirm.ToolMiddleware))
+	if err := adapter.RegisterTool("delete", "Delete a file", types.ToolSchema{Type: "object"}, deleteHandler,
+		framework.ToolAnnotations(types.ToolAnnotations{DestructiveHint: true})); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	session := connectInMemory(t, adapter)
+	ctx := context.Background()
+
+	dryRun, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "delete",
+		Arguments: map[string]interface{}{"path": "a.txt", framework.DryRunArg: true},
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if dryRun.IsError || len(dryRun.Content) != 2 {
+		t.Fatalf("dry-run result = %+v, want preview plus confirmation token", dryRun.Content)
+	}
+	if deleted != 0 {
+		t.Fatalf("dry run invoked the handler %d times, want 0", deleted)
+	}
+	if preview := dryRun.Content[0].(*mcp.TextContent).Text; !strings.Contains(preview, "a.txt") {
+		t.Errorf("dry-run preview %q does not show the arguments", preview)
+	}
+	match := tokenPattern.FindStringSubmatch(dryRun.Content[1].(*mcp.TextContent).Text)
+	if match == nil {
+		t.Fatalf("dry-run result has no token: %q", dryRun.Content[1].(*mcp.TextContent).Text)
+	}
+
+	confirmed, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "delete",
+		Arguments: map[string]interface{}{"path": "a.txt", ConfirmationTokenArg: match[1]},
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if confirmed.IsError || deleted != 1 {
+		t.Errorf("confirmed call IsError = %v, deleted = %d; want success and one deletion", confirmed.IsError, deleted)
+	}
+}
+
+func TestHashArguments(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		same bool
+	}{
+		{name: "key order", a: `{"a":1,"b":2}`, b: `{"b":2, "a":1}`, same: true},
+		{name: "empty and absent", a: `{}`, b: ``, same: true},
+		{name: "different values", a: `{"a":1}`, b: `{"a":2}`, same: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hashArguments(json.RawMessage(tt.a)) == hashArguments(json.RawMessage(tt.b))
+			if got != tt.same {
+				t.Errorf("hashArguments(%s) == hashArguments(%s) = %v, want %v", tt.a, tt.b, got, tt.same)
+			}
+		})
+	}
+}
@@ -3,6 +3,12 @@ package gosdk
 import (
 	"context"
 	"fmt"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // ValidateContext checks if context is valid and not cancelled
@@ -15,3 +21,45 @@ func ValidateContext(ctx context.Context) error {
 	}
 	return nil
 }
+
+// toolInfoKey is the context key for the ToolInfo of the tool being called
+type toolInfoKey struct{}
+
+// withToolInfo returns a context carrying the tool's registration info
+func withToolInfo(ctx context.Context, info types.ToolInfo) context.Context {
+	return context.WithValue(ctx, toolInfoKey{}, info)
+}
+
+// ToolInfoFromContext returns the registration info of the tool being called.
+// It is available to tool middleware and handlers invoked through the server.
+func ToolInfoFromContext(ctx context.Context) (types.ToolInfo, bool) {
+	info, ok := ctx.Value(toolInfoKey{}).(types.ToolInfo)
+	return info, ok
+}
+
+// extractDryRun strips framework.DryRunArg from a tool call request,
+// returning a copy of the request without it and the requested flag
+func extractDryRun(req *mcp.CallToolRequest) (*mcp.CallToolRequest, bool, error) {
+	if req == nil || req.Params == nil {
+		return req, false, nil
+	}
+	args, dryRun, err := framework.ExtractDryRun(req.Params.Arguments)
+	if err != nil {
+		return req, false, err
+	}
+	params := *req.Params
+	params.Arguments = args
+	stripped := *req
+	stripped.Params = &params
+	return &stripped, dryRun, nil
+}
+
+// withRequestID returns ctx carrying the correlation ID the client sent in
+// the request's _meta (see protocol.RequestIDMetaKey), for logging with
+// the Logger's *Ctx methods. ctx is returned unchanged if there is none.
+func withRequestID(ctx context.Context, meta mcp.Meta) context.Context {
+	if id, ok := meta[protocol.RequestIDMetaKey].(string); ok && id != "" {
+		return logging.WithRequestID(ctx, id)
+	}
+	return ctx
+}
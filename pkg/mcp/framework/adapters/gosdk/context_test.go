@@ -93,3 +93,28 @@ func TestValidateContext(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateContext_WrapsContextError(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"cancelled", cancelled, context.Canceled},
+		{"deadline exceeded", expired, context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateContext(tt.ctx)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ValidateContext() error = %v, want wrapped %v", err, tt.want)
+			}
+		})
+	}
+}
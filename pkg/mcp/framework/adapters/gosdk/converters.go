@@ -1,6 +1,9 @@
 package gosdk
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -16,14 +19,155 @@ func TextContentToMCP(contents []types.TextContent) []mcp.Content {
 	return mcpContents
 }
 
+// ContentToMCP converts rich framework content to MCP Content.
+// Image data and resource blobs are raw bytes, base64-encoded when the
+// result is marshaled. Content types other than types.TextContent,
+// types.ImageContent and types.ResourceContent (or pointers to them) are
+// rejected.
+func ContentToMCP(contents []types.Content) ([]mcp.Content, error) {
+	mcpContents := make([]mcp.Content, len(contents))
+	for i, content := range contents {
+		switch c := content.(type) {
+		case types.TextContent:
+			mcpContents[i] = &mcp.TextContent{Text: c.Text}
+		case *types.TextContent:
+			mcpContents[i] = &mcp.TextContent{Text: c.Text}
+		case types.ImageContent:
+			mcpContents[i] = &mcp.ImageContent{Data: c.Data, MIMEType: c.MIMEType}
+		case *types.ImageContent:
+			mcpContents[i] = &mcp.ImageContent{Data: c.Data, MIMEType: c.MIMEType}
+		case types.ResourceContent:
+			mcpContents[i] = resourceContentToMCP(c)
+		case *types.ResourceContent:
+			mcpContents[i] = resourceContentToMCP(*c)
+		default:
+			return nil, fmt.Errorf("content[%d]: unsupported content type %T", i, content)
+		}
+	}
+	return mcpContents, nil
+}
+
+// resourceContentToMCP converts an embedded resource to MCP Content
+func resourceContentToMCP(r types.ResourceContent) *mcp.EmbeddedResource {
+	return &mcp.EmbeddedResource{
+		Resource: &mcp.ResourceContents{
+			URI:      r.URI,
+			MIMEType: r.MIMEType,
+			Text:     r.Text,
+			Blob:     r.Blob,
+		},
+	}
+}
+
 // ToolSchemaToMCP converts framework ToolSchema to MCP input schema
+// Raw property maps are passed through unchanged (including keywords such as
+// "default" and "examples"); types.PropertySchema values are converted to maps.
 func ToolSchemaToMCP(schema types.ToolSchema) map[string]interface{} {
 	inputSchema := map[string]interface{}{
 		"type":       schema.Type,
-		"properties": schema.Properties,
+		"properties": propertiesToMCP(schema.Properties),
 	}
 	if len(schema.Required) > 0 {
 		inputSchema["required"] = schema.Required
 	}
+	if len(schema.Defs) > 0 {
+		inputSchema["$defs"] = schema.Defs
+	}
 	return inputSchema
 }
+
+// propertiesToMCP converts typed property schemas to JSON Schema maps
+func propertiesToMCP(properties map[string]interface{}) map[string]interface{} {
+	if properties == nil {
+		return nil
+	}
+	converted := make(map[string]interface{}, len(properties))
+	for name, prop := range properties {
+		switch p := prop.(type) {
+		case types.PropertySchema:
+			converted[name] = p.Map()
+		case *types.PropertySchema:
+			if p != nil {
+				converted[name] = p.Map()
+			}
+		default:
+			converted[name] = prop
+		}
+	}
+	return converted
+}
+
+// ToolAnnotationsToMCP converts framework ToolAnnotations to MCP tool annotations
+// Returns nil if annotations is nil. DestructiveHint is always set
+// explicitly because MCP treats an absent one as true; OpenWorldHint is only
+// set when specified.
+func ToolAnnotationsToMCP(annotations *types.ToolAnnotations) *mcp.ToolAnnotations {
+	if annotations == nil {
+		return nil
+	}
+	destructive := annotations.DestructiveHint
+	converted := &mcp.ToolAnnotations{
+		ReadOnlyHint:    annotations.ReadOnlyHint,
+		DestructiveHint: &destructive,
+		IdempotentHint:  annotations.IdempotentHint,
+	}
+	if annotations.OpenWorldHint != nil {
+		openWorld := *annotations.OpenWorldHint
+		converted.OpenWorldHint = &openWorld
+	}
+	return converted
+}
+
+// PromptArgumentsToMCP converts framework PromptArguments to MCP prompt arguments
+// Returns nil if there are no arguments.
+func PromptArgumentsToMCP(args []types.PromptArgument) []*mcp.PromptArgument {
+	if len(args) == 0 {
+		return nil
+	}
+	converted := make([]*mcp.PromptArgument, 0, len(args))
+	for _, arg := range args {
+		converted = append(converted, &mcp.PromptArgument{
+			Name:        arg.Name,
+			Description: arg.Description,
+			Required:    arg.Required,
+		})
+	}
+	return converted
+}
+
+// toolErrorResult builds a CallToolResult reporting a tool error
+// Tool errors are returned as results (not protocol errors) per the MCP spec.
+func toolErrorResult(format string, args ...interface{}) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: fmt.Sprintf(format, args...),
+			},
+		},
+	}
+}
+
+// textContentFromMCP returns the text content of a tool result
+func textContentFromMCP(result *mcp.CallToolResult) []types.TextContent {
+	if result == nil {
+		return nil
+	}
+	var contents []types.TextContent
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			contents = append(contents, types.TextContent{Type: "text", Text: text.Text})
+		}
+	}
+	return contents
+}
+
+// resultText joins the text content of a tool result, e.g. to report a
+// tool error result as an error
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range textContentFromMCP(result) {
+		texts = append(texts, content.Text)
+	}
+	return strings.Join(texts, "\n")
+}
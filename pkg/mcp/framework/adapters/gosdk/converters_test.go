@@ -1,6 +1,11 @@
 package gosdk
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
@@ -164,3 +169,138 @@ func TestToolSchemaToMCP(t *testing.T) {
 		})
 	}
 }
+
+func TestToolAnnotationsToMCP(t *testing.T) {
+	if got := ToolAnnotationsToMCP(nil); got != nil {
+		t.Errorf("ToolAnnotationsToMCP(nil) = %+v, want nil", got)
+	}
+
+	got := ToolAnnotationsToMCP(&types.ToolAnnotations{ReadOnlyHint: true})
+	if !got.ReadOnlyHint {
+		t.Error("ReadOnlyHint = false, want true")
+	}
+	// An unset destructive hint must be sent as false, since MCP defaults it to true
+	if got.DestructiveHint == nil || *got.DestructiveHint {
+		t.Errorf("DestructiveHint = %v, want explicit false", got.DestructiveHint)
+	}
+	// An unset open world hint is left unspecified
+	if got.OpenWorldHint != nil {
+		t.Errorf("OpenWorldHint = %v, want nil", *got.OpenWorldHint)
+	}
+
+	openWorld := true
+	got = ToolAnnotationsToMCP(&types.ToolAnnotations{OpenWorldHint: &openWorld, IdempotentHint: true})
+	if got.OpenWorldHint == nil || !*got.OpenWorldHint || !got.IdempotentHint {
+		t.Errorf("ToolAnnotationsToMCP() = %+v, want open world and idempotent", got)
+	}
+
+	closedWorld := false
+	got = ToolAnnotationsToMCP(&types.ToolAnnotations{OpenWorldHint: &closedWorld})
+	if got.OpenWorldHint == nil || *got.OpenWorldHint {
+		t.Errorf("OpenWorldHint = %v, want explicit false", got.OpenWorldHint)
+	}
+}
+
+func TestContentToMCP(t *testing.T) {
+	png := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}
+	contents, err := ContentToMCP([]types.Content{
+		types.Text("chart"),
+		types.Image(png, "image/png"),
+		&types.ResourceContent{URI: "file:///out.bin", MIMEType: "application/octet-stream", Blob: []byte("raw")},
+	})
+	if err != nil {
+		t.Fatalf("ContentToMCP() error = %v", err)
+	}
+	if len(contents) != 3 {
+		t.Fatalf("ContentToMCP() returned %d items, want 3", len(contents))
+	}
+
+	if text, ok := contents[0].(*mcp.TextContent); !ok || text.Text != "chart" {
+		t.Errorf("contents[0] = %#v, want text chart", contents[0])
+	}
+
+	image, ok := contents[1].(*mcp.ImageContent)
+	if !ok || image.MIMEType != "image/png" || string(image.Data) != string(png) {
+		t.Fatalf("contents[1] = %#v, want the PNG image", contents[1])
+	}
+	data, err := json.Marshal(image)
+	if err != nil {
+		t.Fatalf("json.Marshal(image) error = %v", err)
+	}
+	var wire map[string]interface{}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		t.Fatal(err)
+	}
+	if wire["type"] != "image" || wire["mimeType"] != "image/png" || wire["data"] != base64.StdEncoding.EncodeToString(png) {
+		t.Errorf("image JSON = %s, want base64 data %q", data, base64.StdEncoding.EncodeToString(png))
+	}
+
+	resource, ok := contents[2].(*mcp.EmbeddedResource)
+	if !ok || resource.Resource.URI != "file:///out.bin" || string(resource.Resource.Blob) != "raw" {
+		t.Errorf("contents[2] = %#v, want the embedded blob", contents[2])
+	}
+}
+
+// unknownContent is a types.Content that ContentToMCP can't convert
+type unknownContent struct{}
+
+func (unknownContent) ContentType() string { return "video" }
+
+func TestContentToMCP_Unsupported(t *testing.T) {
+	_, err := ContentToMCP([]types.Content{types.Text("ok"), unknownContent{}})
+	if err == nil || !strings.Contains(err.Error(), "content[1]") {
+		t.Errorf("ContentToMCP() error = %v, want unsupported content[1]", err)
+	}
+}
+
+func TestToolSchemaToMCP_DefaultsAndExamples(t *testing.T) {
+	schema := types.ToolSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"raw": map[string]interface{}{
+				"type":     "string",
+				"default":  "x",
+				"examples": []interface{}{"x", "y"},
+			},
+			"typed":   types.IntegerProperty("Limit").WithDefault(10).WithExamples(1, 100),
+			"pointer": func() *types.PropertySchema { p := types.BooleanProperty("Flag").WithDefault(false); return &p }(),
+		},
+	}
+
+	props := ToolSchemaToMCP(schema)["properties"].(map[string]interface{})
+	tests := []struct {
+		name         string
+		wantDefault  interface{}
+		wantExamples interface{}
+	}{
+		{name: "raw", wantDefault: "x", wantExamples: []interface{}{"x", "y"}},
+		{name: "typed", wantDefault: 10, wantExamples: []interface{}{1, 100}},
+		{name: "pointer", wantDefault: false},
+	}
+	for _, tt := range tests {
+		prop, ok := props[tt.name].(map[string]interface{})
+		if !ok {
+			t.Fatalf("properties[%s] = %T, want map", tt.name, props[tt.name])
+		}
+		if !reflect.DeepEqual(prop["default"], tt.wantDefault) {
+			t.Errorf("properties[%s].default = %v, want %v", tt.name, prop["default"], tt.wantDefault)
+		}
+		if !reflect.DeepEqual(prop["examples"], tt.wantExamples) {
+			t.Errorf("properties[%s].examples = %v, want %v", tt.name, prop["examples"], tt.wantExamples)
+		}
+	}
+
+	// And they reach clients through tools/list
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+	if err := adapter.RegisterTool("search", "Search", schema, echoHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	listed, err := connectInMemory(t, adapter).ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	raw, _ := json.Marshal(listed.Tools[0].InputSchema)
+	if !strings.Contains(string(raw), `"typed":{"default":10,"description":"Limit","examples":[1,100],"type":"integer"}`) {
+		t.Errorf("advertised schema = %s, want typed property with default and examples", raw)
+	}
+}
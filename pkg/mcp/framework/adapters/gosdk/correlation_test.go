@@ -0,0 +1,104 @@
+//go:build !mcp_golang
+// +build !mcp_golang
+
+package gosdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/client"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/protocol"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent logging and reading
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestRequestIDCorrelation(t *testing.T) {
+	debugLogger := func(w io.Writer) *logging.Logger {
+		logger := logging.NewLoggerWithWriter(w)
+		logger.SetLevel(logging.LevelDebug)
+		return logger
+	}
+
+	var serverLogs, clientLogs syncBuffer
+	var handlerID string
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithLogger(debugLogger(&serverLogs)))
+	if err := adapter.RegisterTool("echo", "Echo arguments", types.ToolSchema{Type: "object"},
+		func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+			handlerID = logging.RequestIDFromContext(ctx)
+			return echoHandler(ctx, args)
+		}); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+
+	clientToServerR, clientToServerW := io.Pipe()
+	serverToClientR, serverToClientW := io.Pipe()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go func() {
+		_ = adapter.Server().Run(ctx, &mcp.IOTransport{Reader: clientToServerR, Writer: serverToClientW})
+	}()
+
+	c, err := client.NewClientWithIO(serverToClientR, clientToServerW, protocol.ClientInfo{Name: "test-client", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("NewClientWithIO() error = %v", err)
+	}
+	defer c.Close()
+	c.SetLogger(debugLogger(&clientLogs))
+	if _, err := c.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	// A generated ID is logged on both sides and seen by the handler
+	if _, err := c.CallTool(ctx, "echo", map[string]interface{}{"x": 1}); err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	match := regexp.MustCompile(`req:([0-9a-f]{32})`).FindStringSubmatch(clientLogs.String())
+	if match == nil {
+		t.Fatalf("client logs have no request ID:\n%s", clientLogs.String())
+	}
+	id := match[1]
+	if !regexp.MustCompile(`Tool call: echo.*req:` + id).MatchString(serverLogs.String()) {
+		t.Errorf("server logs lack request ID %s for the call:\n%s", id, serverLogs.String())
+	}
+	if handlerID != id {
+		t.Errorf("handler request ID = %q, want %q", handlerID, id)
+	}
+
+	// An ID set by the caller is used as is
+	if _, err := c.CallTool(logging.WithRequestID(ctx, "trace-42"), "echo", nil); err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if handlerID != "trace-42" {
+		t.Errorf("handler request ID = %q, want trace-42", handlerID)
+	}
+	if !strings.Contains(clientLogs.String(), "req:trace-42") {
+		t.Errorf("client logs lack the caller's request ID:\n%s", clientLogs.String())
+	}
+}
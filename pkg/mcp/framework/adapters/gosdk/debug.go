@@ -0,0 +1,69 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DebugEchoTool is the name of the diagnostic tool registered in debug mode.
+// It returns the raw arguments and request metadata the server received.
+const DebugEchoTool = "_debug_echo"
+
+// debugEcho is the result of a DebugEchoTool call
+type debugEcho struct {
+	Tool      string          `json:"tool"`
+	Arguments json.RawMessage `json:"arguments"`
+	ClientID  string          `json:"client_id"`
+	RequestID string          `json:"request_id,omitempty"`
+	Meta      mcp.Meta        `json:"meta,omitempty"`
+}
+
+// debugModeEnabled reports whether MCP_DEBUG=1 is set
+func debugModeEnabled() bool {
+	return os.Getenv("MCP_DEBUG") == "1"
+}
+
+// registerDebugEcho registers DebugEchoTool directly with the SDK server.
+// It bypasses registerTool so the handler sees the request exactly as it
+// arrived (including reserved arguments like framework.DryRunArg), and it is
+// not listed by ListTools or callable through CallTool.
+func (a *GoSDKAdapter) registerDebugEcho() {
+	tool := &mcp.Tool{
+		Name:        DebugEchoTool,
+		Description: "Debug: echo the raw arguments and request metadata received by the server",
+		InputSchema: map[string]interface{}{"type": "object"},
+	}
+	handler := func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if err := ValidateCallToolRequest(req); err != nil {
+			return nil, err
+		}
+		args := req.Params.Arguments
+		if len(args) == 0 {
+			args = json.RawMessage("null")
+		}
+		clientID := sessionID(req)
+		if clientID == "" {
+			clientID = defaultClientID
+		}
+		data, err := json.MarshalIndent(debugEcho{
+			Tool:      req.Params.Name,
+			Arguments: args,
+			ClientID:  clientID,
+			RequestID: logging.RequestIDFromContext(ctx),
+			Meta:      req.Params.Meta,
+		}, "", "  ")
+		if err != nil {
+			return toolErrorResult("Failed to encode request: %v", err), nil
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+		}, nil
+	}
+
+	a.server.AddTool(tool, mcp.ToolHandler(a.middleware.WrapToolHandler(handler)))
+	a.logger.Info("", "Debug mode: registered %s tool", DebugEchoTool)
+}
@@ -0,0 +1,80 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func hasTool(t *testing.T, session *mcp.ClientSession, name string) bool {
+	t.Helper()
+	listed, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	for _, tool := range listed.Tools {
+		if tool.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDebugEcho_OnlyInDebugMode(t *testing.T) {
+	t.Setenv("MCP_DEBUG", "")
+	if hasTool(t, connectInMemory(t, NewGoSDKAdapter("test", "1.0.0")), DebugEchoTool) {
+		t.Errorf("%s registered without MCP_DEBUG", DebugEchoTool)
+	}
+
+	t.Setenv("MCP_DEBUG", "1")
+	if !hasTool(t, connectInMemory(t, NewGoSDKAdapter("test", "1.0.0")), DebugEchoTool) {
+		t.Errorf("%s not registered with MCP_DEBUG=1", DebugEchoTool)
+	}
+
+	// The option overrides the environment
+	if hasTool(t, connectInMemory(t, NewGoSDKAdapter("test", "1.0.0", WithDebugEcho(false))), DebugEchoTool) {
+		t.Errorf("%s registered with WithDebugEcho(false)", DebugEchoTool)
+	}
+}
+
+func TestDebugEcho_EchoesArguments(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithDebugEcho(true))
+	if len(adapter.ListTools()) != 0 {
+		t.Errorf("ListTools() = %v, want debug tool hidden from CLI listing", adapter.ListTools())
+	}
+	session := connectInMemory(t, adapter)
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      DebugEchoTool,
+		Arguments: map[string]interface{}{"path": "a.txt", "count": 2, framework.DryRunArg: true},
+		Meta:      mcp.Meta{"progressToken": "p1"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("CallTool() IsError = true: %v", result.Content)
+	}
+
+	var echo struct {
+		Tool      string                 `json:"tool"`
+		Arguments map[string]interface{} `json:"arguments"`
+		ClientID  string                 `json:"client_id"`
+		Meta      map[string]interface{} `json:"meta"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &echo); err != nil {
+		t.Fatalf("result is not JSON: %v", err)
+	}
+	if echo.Tool != DebugEchoTool || echo.ClientID == "" {
+		t.Errorf("echo = %+v, want tool name and client ID", echo)
+	}
+	if echo.Arguments["path"] != "a.txt" || echo.Arguments["count"] != float64(2) || echo.Arguments[framework.DryRunArg] != true {
+		t.Errorf("echoed arguments = %v, want the raw arguments", echo.Arguments)
+	}
+	if echo.Meta["progressToken"] != "p1" {
+		t.Errorf("echoed meta = %v, want progressToken", echo.Meta)
+	}
+}
@@ -0,0 +1,132 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// DefaultCanaryTTL is how long a canary tool result is reused by
+// HealthCheck before the tool is called again
+const DefaultCanaryTTL = 30 * time.Second
+
+// canaryProbe calls a designated tool to check the server works, caching
+// the outcome
+type canaryProbe struct {
+	tool string
+	args json.RawMessage
+	ttl  time.Duration
+
+	mu        sync.Mutex
+	lastErr   error
+	checkedAt time.Time
+	inflight  *canaryCall // the call in progress, shared by concurrent probes
+}
+
+// canaryCall is one call of the canary tool; err is set before done is closed
+type canaryCall struct {
+	done chan struct{}
+	err  error
+}
+
+// WithCanaryTool designates tool as the canary for HealthCheck and
+// ReadyzHandler: a cheap tool, called with args, whose success shows the
+// server is functionally healthy rather than just alive. Results are
+// reused for ttl (DefaultCanaryTTL if ttl <= 0), so frequent probes don't
+// load the server. The tool must be registered by the time it is probed.
+//
+// Example:
+//
+//	adapter := NewGoSDKAdapter("server", "1.0.0",
+//		WithCanaryTool("db_ping", json.RawMessage(`{}`), time.Minute),
+//	)
+//	mux.Handle("/readyz", adapter.ReadyzHandler())
+func WithCanaryTool(tool string, args json.RawMessage, ttl time.Duration) AdapterOption {
+	return func(a *GoSDKAdapter) {
+		if ttl <= 0 {
+			ttl = DefaultCanaryTTL
+		}
+		if len(args) == 0 {
+			args = json.RawMessage(`{}`)
+		}
+		a.canary = &canaryProbe{tool: tool, args: args, ttl: ttl}
+	}
+}
+
+// HealthCheck reports whether the server is healthy: nil if no canary tool
+// is set (see WithCanaryTool) or the canary's last call within its TTL
+// succeeded, otherwise the error from calling it. A stale result is
+// refreshed by calling the canary (once for all concurrent checks); if ctx
+// is done first, HealthCheck returns its error without waiting.
+func (a *GoSDKAdapter) HealthCheck(ctx context.Context) error {
+	if a.canary == nil {
+		return nil
+	}
+	return a.canary.check(ctx, a.CallTool)
+}
+
+// ReadyzHandler returns an HTTP handler for readiness probes, e.g. at
+// /readyz. It responds 200 "ok" when HealthCheck passes and 503 with the
+// error otherwise.
+func (a *GoSDKAdapter) ReadyzHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		if err := a.HealthCheck(r.Context()); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, "unhealthy: %v\n", err)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+}
+
+// check returns the cached canary outcome, calling the tool with call if it
+// is missing or older than the TTL. Concurrent probes share one call and
+// each stops waiting for it when its own ctx is done.
+func (p *canaryProbe) check(ctx context.Context, call func(context.Context, string, json.RawMessage) ([]types.TextContent, error)) error {
+	p.mu.Lock()
+	if !p.checkedAt.IsZero() && time.Since(p.checkedAt) < p.ttl {
+		err := p.lastErr
+		p.mu.Unlock()
+		return err
+	}
+	c := p.inflight
+	if c == nil {
+		c = &canaryCall{done: make(chan struct{})}
+		p.inflight = c
+		// The call outlives a probe that gives up, so its result can
+		// still be shared and cached
+		go p.run(context.WithoutCancel(ctx), c, call)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.err
+	case <-ctx.Done():
+		return fmt.Errorf("canary tool %q: %w", p.tool, ctx.Err())
+	}
+}
+
+// run calls the canary tool and caches the outcome
+func (p *canaryProbe) run(ctx context.Context, c *canaryCall, call func(context.Context, string, json.RawMessage) ([]types.TextContent, error)) {
+	_, err := call(ctx, p.tool, p.args)
+	if err != nil {
+		err = fmt.Errorf("canary tool %q failed: %w", p.tool, err)
+	}
+
+	p.mu.Lock()
+	p.lastErr = err
+	p.checkedAt = time.Now()
+	p.inflight = nil
+	p.mu.Unlock()
+
+	c.err = err
+	close(c.done)
+}
@@ -0,0 +1,125 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+func TestGoSDKAdapter_HealthCheck(t *testing.T) {
+	var calls atomic.Int32
+	var failing atomic.Bool
+	canary := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		calls.Add(1)
+		if failing.Load() {
+			return nil, errors.New("database unreachable")
+		}
+		return []types.TextContent{{Type: "text", Text: "pong"}}, nil
+	}
+
+	ttl := 50 * time.Millisecond
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithCanaryTool("db_ping", nil, ttl))
+	if err := adapter.RegisterTool("db_ping", "Ping the database", types.ToolSchema{Type: "object"}, canary); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	ctx := context.Background()
+
+	// A passing canary is healthy, and the result is cached for the TTL
+	if err := adapter.HealthCheck(ctx); err != nil {
+		t.Fatalf("HealthCheck() error = %v, want healthy", err)
+	}
+	failing.Store(true)
+	if err := adapter.HealthCheck(ctx); err != nil {
+		t.Errorf("HealthCheck() within the TTL error = %v, want the cached healthy result", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("canary called %d times within the TTL, want 1", calls.Load())
+	}
+
+	// Once the TTL expires the failing canary makes the server unhealthy
+	time.Sleep(ttl + 10*time.Millisecond)
+	err := adapter.HealthCheck(ctx)
+	if err == nil || !strings.Contains(err.Error(), "database unreachable") {
+		t.Fatalf("HealthCheck() error = %v, want the canary's failure", err)
+	}
+
+	rec := httptest.NewRecorder()
+	adapter.ReadyzHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), "database unreachable") {
+		t.Errorf("/readyz = %d %q, want 503 with the failure", rec.Code, rec.Body.String())
+	}
+
+	// Recovery is seen after the next TTL
+	failing.Store(false)
+	time.Sleep(ttl + 10*time.Millisecond)
+	rec = httptest.NewRecorder()
+	adapter.ReadyzHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != "ok" {
+		t.Errorf("/readyz = %d %q, want 200 ok", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGoSDKAdapter_HealthCheck_NoCanary(t *testing.T) {
+	if err := NewGoSDKAdapter("test", "1.0.0").HealthCheck(context.Background()); err != nil {
+		t.Errorf("HealthCheck() without a canary error = %v, want healthy", err)
+	}
+
+	// A canary that was never registered is a failure
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithCanaryTool("missing", nil, 0))
+	var notFound *framework.ErrToolNotFound
+	if err := adapter.HealthCheck(context.Background()); !errors.As(err, &notFound) {
+		t.Errorf("HealthCheck() error = %v, want ErrToolNotFound", err)
+	}
+}
+
+func TestCanaryProbe_SharesCallAndHonorsContext(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	call := func(ctx context.Context, name string, args json.RawMessage) ([]types.TextContent, error) {
+		calls.Add(1)
+		<-release
+		return nil, nil
+	}
+	probe := &canaryProbe{tool: "slow", args: json.RawMessage(`{}`), ttl: time.Minute}
+
+	// A probe whose context ends stops waiting while the call is in flight
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := probe.check(ctx, call); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("check() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("check() waited %v for the in-flight call, want it to give up", elapsed)
+	}
+
+	// Concurrent probes wait for the same call
+	results := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func() { results <- probe.check(context.Background(), call) }()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	for i := 0; i < 3; i++ {
+		if err := <-results; err != nil {
+			t.Errorf("check() error = %v, want healthy", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("canary called %d times, want 1 shared call", n)
+	}
+
+	// The shared result is cached
+	if err := probe.check(context.Background(), call); err != nil || calls.Load() != 1 {
+		t.Errorf("check() = %v after %d calls, want the cached result", err, calls.Load())
+	}
+}
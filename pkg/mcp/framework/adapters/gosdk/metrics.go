@@ -0,0 +1,131 @@
+package gosdk
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxLatencySamples bounds the durations kept per tool for percentiles.
+// Older samples are overwritten once the limit is reached, so percentiles
+// reflect recent calls while counts cover all of them.
+const maxLatencySamples = 1024
+
+// ToolMetrics summarizes the calls to one tool
+type ToolMetrics struct {
+	Calls  int64         // Total calls
+	Errors int64         // Calls that failed or returned a tool error
+	P50    time.Duration // Median latency of recent calls
+	P95    time.Duration // 95th percentile latency of recent calls
+	Max    time.Duration // Longest call
+}
+
+// toolSamples accumulates calls to one tool
+type toolSamples struct {
+	metrics ToolMetrics
+	samples []time.Duration // ring buffer of recent durations
+	next    int
+}
+
+// MetricsMiddleware records per-tool call counts, error counts and latency.
+// It is safe for concurrent use; read the numbers with Snapshot.
+//
+// Example:
+//
+//	adapter := NewGoSDKAdapter("server", "1.0.0", WithMetrics())
+//	...
+//	for name, m := range adapter.Metrics().Snapshot() {
+//		fmt.Printf("%s: %d calls, %d errors, p95 %v\n", name, m.Calls, m.Errors, m.P95)
+//	}
+type MetricsMiddleware struct {
+	mu    sync.Mutex
+	tools map[string]*toolSamples
+}
+
+// NewMetricsMiddleware creates metrics middleware with no calls recorded
+func NewMetricsMiddleware() *MetricsMiddleware {
+	return &MetricsMiddleware{tools: make(map[string]*toolSamples)}
+}
+
+// ToolMiddleware wraps a tool handler, recording each call
+func (mm *MetricsMiddleware) ToolMiddleware(next ToolHandlerFunc) ToolHandlerFunc {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := next(ctx, req)
+		name := ""
+		if req != nil && req.Params != nil {
+			name = req.Params.Name
+		}
+		mm.record(name, time.Since(start), err != nil || (result != nil && result.IsError))
+		return result, err
+	}
+}
+
+// record adds a call to the named tool
+func (mm *MetricsMiddleware) record(name string, duration time.Duration, failed bool) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	tool, ok := mm.tools[name]
+	if !ok {
+		tool = &toolSamples{}
+		mm.tools[name] = tool
+	}
+	tool.metrics.Calls++
+	if failed {
+		tool.metrics.Errors++
+	}
+	if duration > tool.metrics.Max {
+		tool.metrics.Max = duration
+	}
+
+	if len(tool.samples) < maxLatencySamples {
+		tool.samples = append(tool.samples, duration)
+	} else {
+		tool.samples[tool.next] = duration
+		tool.next = (tool.next + 1) % maxLatencySamples
+	}
+}
+
+// Snapshot returns the metrics of every tool called so far, by tool name
+func (mm *MetricsMiddleware) Snapshot() map[string]ToolMetrics {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	snapshot := make(map[string]ToolMetrics, len(mm.tools))
+	for name, tool := range mm.tools {
+		sorted := make([]time.Duration, len(tool.samples))
+		copy(sorted, tool.samples)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		metrics := tool.metrics
+		metrics.P50 = latencyPercentile(sorted, 50)
+		metrics.P95 = latencyPercentile(sorted, 95)
+		snapshot[name] = metrics
+	}
+	return snapshot
+}
+
+// Reset discards all recorded calls
+func (mm *MetricsMiddleware) Reset() {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	mm.tools = make(map[string]*toolSamples)
+}
+
+// latencyPercentile returns the nearest-rank percentile p (0-100) of sorted
+// durations
+func latencyPercentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,86 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestGoSDKAdapter_WithMetrics(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithMetrics())
+	work := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		var params struct {
+			Fail bool `json:"fail"`
+		}
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, err
+		}
+		time.Sleep(time.Millisecond)
+		if params.Fail {
+			return nil, errors.New("failed")
+		}
+		return []types.TextContent{{Type: "text", Text: "ok"}}, nil
+	}
+	if err := adapter.RegisterTool("work", "Sleeps briefly", types.ToolSchema{Type: "object"}, work); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	if err := adapter.RegisterTool("echo", "Echo arguments", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+
+	session := connectInMemory(t, adapter)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		args := map[string]any{"fail": i%2 == 0}
+		if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "work", Arguments: args}); err != nil {
+			t.Fatalf("CallTool() error = %v", err)
+		}
+	}
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "echo", Arguments: map[string]any{}}); err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+
+	snapshot := adapter.Metrics().Snapshot()
+	got := snapshot["work"]
+	if got.Calls != 5 || got.Errors != 3 {
+		t.Errorf("work Calls, Errors = %d, %d; want 5, 3", got.Calls, got.Errors)
+	}
+	if got.P50 < time.Millisecond || got.P95 < got.P50 || got.Max < got.P95 {
+		t.Errorf("work latencies P50=%v P95=%v Max=%v, want 1ms <= P50 <= P95 <= Max", got.P50, got.P95, got.Max)
+	}
+	if echo := snapshot["echo"]; echo.Calls != 1 || echo.Errors != 0 {
+		t.Errorf("echo metrics = %+v, want 1 call and no errors", echo)
+	}
+
+	adapter.Metrics().Reset()
+	if snapshot := adapter.Metrics().Snapshot(); len(snapshot) != 0 {
+		t.Errorf("Snapshot() after Reset() = %v, want empty", snapshot)
+	}
+
+	if NewGoSDKAdapter("test", "1.0.0").Metrics() != nil {
+		t.Error("Metrics() without WithMetrics should be nil")
+	}
+}
+
+func TestMetricsMiddleware_SampleLimit(t *testing.T) {
+	mm := NewMetricsMiddleware()
+	for i := 0; i < maxLatencySamples; i++ {
+		mm.record("tool", time.Second, false)
+	}
+	// Newer samples replace the oldest ones; counts and Max cover all calls
+	for i := 0; i < maxLatencySamples; i++ {
+		mm.record("tool", time.Millisecond, false)
+	}
+	got := mm.Snapshot()["tool"]
+	if got.Calls != 2*maxLatencySamples {
+		t.Errorf("Calls = %d, want %d", got.Calls, 2*maxLatencySamples)
+	}
+	if got.P95 != time.Millisecond || got.Max != time.Second {
+		t.Errorf("P95, Max = %v, %v; want 1ms, 1s", got.P95, got.Max)
+	}
+}
@@ -127,3 +127,11 @@ func (mc *MiddlewareChain) ApplyMiddleware(mw Middleware) {
 	mc.AddPromptMiddleware(mw.PromptMiddleware)
 	mc.AddResourceMiddleware(mw.ResourceMiddleware)
 }
+
+// prependMiddleware applies mw ahead of all middleware added so far, so it
+// wraps them
+func (mc *MiddlewareChain) prependMiddleware(mw Middleware) {
+	mc.toolMiddlewares = append([]func(ToolHandlerFunc) ToolHandlerFunc{mw.ToolMiddleware}, mc.toolMiddlewares...)
+	mc.promptMiddlewares = append([]func(PromptHandlerFunc) PromptHandlerFunc{mw.PromptMiddleware}, mc.promptMiddlewares...)
+	mc.resourceMiddlewares = append([]func(ResourceHandlerFunc) ResourceHandlerFunc{mw.ResourceMiddleware}, mc.resourceMiddlewares...)
+}
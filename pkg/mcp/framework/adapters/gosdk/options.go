@@ -1,6 +1,10 @@
 package gosdk
 
-import "github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+import (
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+)
 
 // AdapterOption configures a GoSDKAdapter
 type AdapterOption func(*GoSDKAdapter)
@@ -16,6 +20,107 @@ func WithLogger(logger *logging.Logger) AdapterOption {
 	}
 }
 
+// WithMaxPromptBytes caps the size of prompt handler results.
+// Results larger than maxBytes are rejected with an error instead of being
+// sent to the client. Zero or negative means unlimited (the default).
+func WithMaxPromptBytes(maxBytes int) AdapterOption {
+	return func(a *GoSDKAdapter) {
+		if maxBytes > 0 {
+			a.maxPromptBytes = maxBytes
+		}
+	}
+}
+
+// WithMaxResourceBytes caps the size of resource handler results.
+// Results larger than maxBytes are rejected with an error instead of being
+// sent to the client. Zero or negative means unlimited (the default).
+func WithMaxResourceBytes(maxBytes int) AdapterOption {
+	return func(a *GoSDKAdapter) {
+		if maxBytes > 0 {
+			a.maxResourceBytes = maxBytes
+		}
+	}
+}
+
+// WithUTF8Policy sets how invalid UTF-8 in tool and prompt text results is
+// handled. The default, UTF8Replace, substitutes U+FFFD for invalid bytes;
+// UTF8Reject turns such results into errors.
+func WithUTF8Policy(policy UTF8Policy) AdapterOption {
+	return func(a *GoSDKAdapter) {
+		a.utf8Policy = policy
+	}
+}
+
+// WithSchemaRefResolution inlines local "$ref"s (against "$defs") in tool
+// input schemas before they are advertised, for clients that can't resolve
+// references. Registration fails if a schema has unknown or circular refs.
+func WithSchemaRefResolution() AdapterOption {
+	return func(a *GoSDKAdapter) {
+		a.resolveSchemaRefs = true
+	}
+}
+
+// WithPluginRegistry sets the registry LoadPlugins resolves plugin names
+// against. Defaults to DefaultPluginRegistry.
+func WithPluginRegistry(registry *PluginRegistry) AdapterOption {
+	return func(a *GoSDKAdapter) {
+		if registry != nil {
+			a.pluginRegistry = registry
+		}
+	}
+}
+
+// WithDebugEcho enables or disables the DebugEchoTool, overriding the
+// MCP_DEBUG environment variable
+func WithDebugEcho(enabled bool) AdapterOption {
+	return func(a *GoSDKAdapter) {
+		a.debugEcho = enabled
+	}
+}
+
+// WithShutdownSignals makes Run return cleanly (nil) when the process
+// receives a shutdown signal, as listed by platform.ShutdownSignals
+func WithShutdownSignals() AdapterOption {
+	return func(a *GoSDKAdapter) {
+		a.shutdownSignals = true
+	}
+}
+
+// WithToolTimeout cancels each tool call's context after d and reports the
+// call to the client as a tool error ("tool exceeded timeout") without
+// waiting for the handler to return. Tools registered with a
+// framework.ToolTimeout option use their own timeout instead. Zero or negative
+// means no timeout (the default).
+func WithToolTimeout(d time.Duration) AdapterOption {
+	return func(a *GoSDKAdapter) {
+		if d > 0 {
+			a.toolTimeout = d
+		}
+	}
+}
+
+// WithRecovery installs RecoverMiddleware, logging to the adapter's logger,
+// as the outermost middleware, so a panicking handler or middleware returns
+// an error instead of crashing the server.
+func WithRecovery() AdapterOption {
+	return func(a *GoSDKAdapter) {
+		a.recovery = true
+	}
+}
+
+// WithMetrics records per-tool call counts, error counts and latency with a
+// MetricsMiddleware, available from the adapter's Metrics method. Like other
+// middleware it only sees calls that reach it, so add it before middleware
+// that rejects calls if those should be counted.
+func WithMetrics() AdapterOption {
+	return func(a *GoSDKAdapter) {
+		if a.metrics == nil {
+			a.metrics = NewMetricsMiddleware()
+			a.middleware.AddToolMiddleware(a.metrics.ToolMiddleware)
+		}
+	}
+}
+
 // WithMiddleware adds middleware to the adapter
 // Middleware can be provided as:
 //   - A Middleware interface (applies to all handler types)
@@ -78,3 +183,14 @@ func WithMiddleware(middleware interface{}) AdapterOption {
 		}
 	}
 }
+
+// WithCloser registers fn to release a resource owned by the adapter, such
+// as the cleanup goroutine of a rate limiter used by its middleware. fn is
+// called once by Close, which Run calls when it returns.
+func WithCloser(fn func()) AdapterOption {
+	return func(a *GoSDKAdapter) {
+		if fn != nil {
+			a.closers = append(a.closers, fn)
+		}
+	}
+}
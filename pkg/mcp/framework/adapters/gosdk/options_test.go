@@ -110,3 +110,46 @@ func (tm *testMiddlewareForOptions) PromptMiddleware(next PromptHandlerFunc) Pro
 func (tm *testMiddlewareForOptions) ResourceMiddleware(next ResourceHandlerFunc) ResourceHandlerFunc {
 	return next
 }
+
+func TestAdapterOption_WithMaxResultBytes(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithMaxPromptBytes(100), WithMaxResourceBytes(200))
+	if adapter.maxPromptBytes != 100 {
+		t.Errorf("maxPromptBytes = %d, want 100", adapter.maxPromptBytes)
+	}
+	if adapter.maxResourceBytes != 200 {
+		t.Errorf("maxResourceBytes = %d, want 200", adapter.maxResourceBytes)
+	}
+
+	// Non-positive values leave limits disabled
+	adapter = NewGoSDKAdapter("test", "1.0.0", WithMaxPromptBytes(0), WithMaxResourceBytes(-1))
+	if adapter.maxPromptBytes != 0 || adapter.maxResourceBytes != 0 {
+		t.Errorf("limits = %d/%d, want 0/0", adapter.maxPromptBytes, adapter.maxResourceBytes)
+	}
+}
+
+func TestAdapterOption_WithCloser(t *testing.T) {
+	var order []int
+	adapter := NewGoSDKAdapter("test", "1.0.0",
+		WithCloser(func() { order = append(order, 1) }),
+		WithCloser(nil),
+		WithCloser(func() { order = append(order, 2) }),
+	)
+
+	// Run closes the adapter when it returns, even on error
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := adapter.Run(ctx, nil); err == nil {
+		t.Fatal("Run() with a canceled context error = nil, want error")
+	}
+	if len(order) != 2 || order[0] != 2 || order[1] != 1 {
+		t.Fatalf("closers ran in order %v, want [2 1]", order)
+	}
+
+	// Later closes do nothing
+	if err := adapter.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if len(order) != 2 {
+		t.Errorf("closers ran again on Close(): %v", order)
+	}
+}
@@ -0,0 +1,210 @@
+package gosdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// PluginManifestSuffix is the file suffix LoadPlugins looks for
+const PluginManifestSuffix = ".plugin.json"
+
+// PluginTool is a tool provided by a plugin
+type PluginTool struct {
+	Name        string
+	Description string
+	Schema      types.ToolSchema
+	Annotations *types.ToolAnnotations // optional
+	Handler     framework.ToolHandler
+}
+
+// PluginFactory builds a plugin's tools from the "config" section of its
+// manifest (nil if the manifest has none)
+type PluginFactory func(config json.RawMessage) ([]PluginTool, error)
+
+// PluginManifest is the content of a "<name>.plugin.json" file.
+// It selects a plugin compiled into the server by name and configures it.
+//
+// Example:
+//
+//	{"plugin": "github", "config": {"org": "acme"}}
+type PluginManifest struct {
+	Plugin   string          `json:"plugin"`
+	Config   json.RawMessage `json:"config,omitempty"`
+	Disabled bool            `json:"disabled,omitempty"`
+}
+
+// PluginRegistry maps plugin names to factories.
+//
+// Plugins are ordinary Go packages compiled into the server that register a
+// factory, typically from init(). A plugin directory then controls which
+// plugins are enabled and how they are configured, without rebuilding. This
+// avoids the platform and toolchain restrictions of Go's plugin package.
+type PluginRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]PluginFactory
+}
+
+// NewPluginRegistry creates an empty plugin registry
+func NewPluginRegistry() *PluginRegistry {
+	return &PluginRegistry{factories: make(map[string]PluginFactory)}
+}
+
+// Register adds a plugin factory under name
+// Returns an error if name is empty, factory is nil, or name is taken.
+func (r *PluginRegistry) Register(name string, factory PluginFactory) error {
+	if name == "" {
+		return fmt.Errorf("plugin name cannot be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("plugin %q: factory cannot be nil", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.factories[name]; exists {
+		return fmt.Errorf("plugin %q already registered", name)
+	}
+	r.factories[name] = factory
+	return nil
+}
+
+// Lookup returns the factory registered under name
+func (r *PluginRegistry) Lookup(name string) (PluginFactory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	factory, ok := r.factories[name]
+	return factory, ok
+}
+
+// DefaultPluginRegistry is the registry used by LoadPlugins unless
+// WithPluginRegistry is given
+var DefaultPluginRegistry = NewPluginRegistry()
+
+// RegisterPlugin registers a plugin factory in DefaultPluginRegistry.
+// It panics on error, since it is meant to be called from init().
+//
+// Example:
+//
+//	func init() {
+//		gosdk.RegisterPlugin("github", newGitHubTools)
+//	}
+func RegisterPlugin(name string, factory PluginFactory) {
+	if err := DefaultPluginRegistry.Register(name, factory); err != nil {
+		panic(err)
+	}
+}
+
+// LoadPlugins scans dir for "*.plugin.json" manifests and registers the tools
+// of each enabled plugin.
+//
+// It can be called again to reload: tools from plugins that were removed or
+// disabled are unregistered, and the rest are re-registered with their new
+// configuration. Connected clients receive a tools/list_changed notification
+// for the changes.
+//
+// A manifest that fails (bad JSON, unknown plugin, factory error) is skipped
+// and reported in the returned error; other plugins are still loaded. Plugin
+// tools may not replace tools registered directly with RegisterTool.
+func (a *GoSDKAdapter) LoadPlugins(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), PluginManifestSuffix) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var errs []error
+	loaded := make(map[string]bool)
+	for _, file := range names {
+		tools, err := a.loadPluginManifest(filepath.Join(dir, file))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file, err))
+			continue
+		}
+		for _, tool := range tools {
+			if err := a.registerPluginTool(tool, loaded); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", file, err))
+			}
+		}
+	}
+
+	// Drop tools from plugins that are gone or no longer provide them
+	var stale []string
+	a.mu.Lock()
+	for name := range a.pluginTools {
+		if !loaded[name] {
+			stale = append(stale, name)
+			a.forgetTool(name)
+		}
+	}
+	a.mu.Unlock()
+	if len(stale) > 0 {
+		a.server.RemoveTools(stale...)
+		a.logger.Info("", "Unloaded plugin tools: %s", strings.Join(stale, ", "))
+	}
+
+	return errors.Join(errs...)
+}
+
+// loadPluginManifest reads a manifest and builds its plugin's tools
+// Returns no tools for a disabled plugin.
+func (a *GoSDKAdapter) loadPluginManifest(path string) ([]PluginTool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest PluginManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if manifest.Disabled {
+		return nil, nil
+	}
+	factory, ok := a.pluginRegistry.Lookup(manifest.Plugin)
+	if !ok {
+		return nil, fmt.Errorf("unknown plugin %q", manifest.Plugin)
+	}
+	tools, err := factory(manifest.Config)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %q: %w", manifest.Plugin, err)
+	}
+	return tools, nil
+}
+
+// registerPluginTool registers one plugin tool, recording it in loaded
+func (a *GoSDKAdapter) registerPluginTool(tool PluginTool, loaded map[string]bool) error {
+	if loaded[tool.Name] {
+		return fmt.Errorf("tool %q provided by more than one plugin", tool.Name)
+	}
+	a.mu.RLock()
+	_, exists := a.toolInfo[tool.Name]
+	isPlugin := a.pluginTools[tool.Name]
+	a.mu.RUnlock()
+	if exists && !isPlugin {
+		return fmt.Errorf("tool %q conflicts with a registered tool", tool.Name)
+	}
+
+	if err := a.registerTool(tool.Name, tool.Description, tool.Schema, framework.ToolConfig{Annotations: tool.Annotations}, tool.Handler); err != nil {
+		return err
+	}
+	loaded[tool.Name] = true
+	a.mu.Lock()
+	a.pluginTools[tool.Name] = true
+	a.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,209 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// fakePluginRegistry returns a registry with a "greeter" plugin whose tool
+// name comes from its config, and a "math" plugin with two fixed tools
+func fakePluginRegistry(t *testing.T) *PluginRegistry {
+	t.Helper()
+	registry := NewPluginRegistry()
+	greeter := func(config json.RawMessage) ([]PluginTool, error) {
+		var cfg struct {
+			Tool     string `json:"tool"`
+			Greeting string `json:"greeting"`
+		}
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, err
+		}
+		return []PluginTool{{
+			Name:        cfg.Tool,
+			Description: "Greet",
+			Schema:      types.ToolSchema{Type: "object"},
+			Handler: func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+				return types.TextList(cfg.Greeting), nil
+			},
+		}}, nil
+	}
+	math := func(config json.RawMessage) ([]PluginTool, error) {
+		return []PluginTool{
+			{Name: "add", Description: "Add", Schema: types.ToolSchema{Type: "object"}, Handler: echoHandler},
+			{Name: "sub", Description: "Subtract", Schema: types.ToolSchema{Type: "object"}, Handler: echoHandler},
+		}, nil
+	}
+	if err := registry.Register("greeter", greeter); err != nil {
+		t.Fatalf("Register(greeter) error = %v", err)
+	}
+	if err := registry.Register("math", math); err != nil {
+		t.Fatalf("Register(math) error = %v", err)
+	}
+	return registry
+}
+
+func writeManifest(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name+PluginManifestSuffix), []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func toolNames(adapter *GoSDKAdapter) string {
+	var names []string
+	for _, tool := range adapter.ListTools() {
+		names = append(names, tool.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestPluginRegistry_Register(t *testing.T) {
+	registry := fakePluginRegistry(t)
+	if err := registry.Register("math", func(json.RawMessage) ([]PluginTool, error) { return nil, nil }); err == nil {
+		t.Error("Register() duplicate name error = nil, want error")
+	}
+	if err := registry.Register("", func(json.RawMessage) ([]PluginTool, error) { return nil, nil }); err == nil {
+		t.Error("Register() empty name error = nil, want error")
+	}
+	if err := registry.Register("nil", nil); err == nil {
+		t.Error("Register() nil factory error = nil, want error")
+	}
+}
+
+func TestGoSDKAdapter_LoadPlugins(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, dir, "greeter", `{"plugin": "greeter", "config": {"tool": "hello", "greeting": "hi"}}`)
+	writeManifest(t, dir, "math", `{"plugin": "math"}`)
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithPluginRegistry(fakePluginRegistry(t)))
+	if err := adapter.RegisterTool("echo", "Echo", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	if err := adapter.LoadPlugins(dir); err != nil {
+		t.Fatalf("LoadPlugins() error = %v", err)
+	}
+	if got := toolNames(adapter); got != "add,echo,hello,sub" {
+		t.Errorf("ListTools() = %s, want add,echo,hello,sub", got)
+	}
+
+	session := connectInMemory(t, adapter)
+	ctx := context.Background()
+	listed, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	if len(listed.Tools) != 4 {
+		t.Errorf("tools/list returned %d tools, want 4", len(listed.Tools))
+	}
+
+	// Reload: math removed, greeter reconfigured, greeter tool renamed
+	if err := os.Remove(filepath.Join(dir, "math"+PluginManifestSuffix)); err != nil {
+		t.Fatal(err)
+	}
+	writeManifest(t, dir, "greeter", `{"plugin": "greeter", "config": {"tool": "greet", "greeting": "hello"}}`)
+	if err := adapter.LoadPlugins(dir); err != nil {
+		t.Fatalf("LoadPlugins() reload error = %v", err)
+	}
+	if got := toolNames(adapter); got != "echo,greet" {
+		t.Errorf("ListTools() after reload = %s, want echo,greet", got)
+	}
+
+	listed, err = session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	if len(listed.Tools) != 2 {
+		t.Errorf("tools/list after reload returned %d tools, want 2", len(listed.Tools))
+	}
+
+	result, err := adapter.CallTool(ctx, "greet", json.RawMessage(`{}`))
+	if err != nil || len(result) != 1 || result[0].Text != "hello" {
+		t.Errorf("CallTool(greet) = %v, %v; want hello", result, err)
+	}
+	if _, err := adapter.CallTool(ctx, "add", json.RawMessage(`{}`)); err == nil {
+		t.Error("CallTool(add) after unload error = nil, want error")
+	}
+
+	// Disabled plugins are unloaded too
+	writeManifest(t, dir, "greeter", `{"plugin": "greeter", "disabled": true}`)
+	if err := adapter.LoadPlugins(dir); err != nil {
+		t.Fatalf("LoadPlugins() error = %v", err)
+	}
+	if got := toolNames(adapter); got != "echo" {
+		t.Errorf("ListTools() after disable = %s, want echo", got)
+	}
+}
+
+func TestGoSDKAdapter_LoadPlugins_AfterToolChanges(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, dir, "greeter", `{"plugin": "greeter", "config": {"tool": "hello", "greeting": "hi"}}`)
+	writeManifest(t, dir, "math", `{"plugin": "math"}`)
+
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithPluginRegistry(fakePluginRegistry(t)))
+	if err := adapter.LoadPlugins(dir); err != nil {
+		t.Fatalf("LoadPlugins() error = %v", err)
+	}
+
+	// A plugin tool can be unregistered, and one replaced by a direct
+	// registration is no longer managed by the plugin
+	if err := adapter.UnregisterTool("add"); err != nil {
+		t.Fatalf("UnregisterTool(add) error = %v", err)
+	}
+	if err := adapter.RegisterTool("hello", "Direct", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+		t.Fatalf("RegisterTool(hello) error = %v", err)
+	}
+	if err := os.Remove(filepath.Join(dir, "greeter"+PluginManifestSuffix)); err != nil {
+		t.Fatal(err)
+	}
+	if err := adapter.LoadPlugins(dir); err != nil {
+		t.Fatalf("LoadPlugins() reload error = %v", err)
+	}
+	if got := toolNames(adapter); got != "add,hello,sub" {
+		t.Errorf("ListTools() after reload = %s, want add,hello,sub", got)
+	}
+}
+
+func TestGoSDKAdapter_LoadPlugins_Errors(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, dir, "bad", `{not json`)
+	writeManifest(t, dir, "math", `{"plugin": "math"}`)
+	writeManifest(t, dir, "unknown", `{"plugin": "missing"}`)
+	writeManifest(t, dir, "zgreeter", `{"plugin": "greeter", "config": {"tool": "echo", "greeting": "hi"}}`)
+
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithPluginRegistry(fakePluginRegistry(t)))
+	if err := adapter.RegisterTool("echo", "Echo", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+
+	err := adapter.LoadPlugins(dir)
+	if err == nil {
+		t.Fatal("LoadPlugins() error = nil, want error")
+	}
+	for _, want := range []string{"bad.plugin.json", `unknown plugin "missing"`, `tool "echo" conflicts`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("LoadPlugins() error = %v, want it to mention %s", err, want)
+		}
+	}
+
+	// Valid plugins still load and the directly registered tool is untouched
+	if got := toolNames(adapter); got != "add,echo,sub" {
+		t.Errorf("ListTools() = %s, want add,echo,sub", got)
+	}
+	result, err := adapter.CallTool(context.Background(), "echo", json.RawMessage(`{"x":1}`))
+	if err != nil || len(result) != 1 || result[0].Text != `{"x":1}` {
+		t.Errorf("CallTool(echo) = %v, %v; want original echo handler", result, err)
+	}
+
+	if err := adapter.LoadPlugins(filepath.Join(dir, "missing")); err == nil {
+		t.Error("LoadPlugins() missing directory error = nil, want error")
+	}
+}
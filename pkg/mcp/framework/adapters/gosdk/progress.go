@@ -0,0 +1,76 @@
+package gosdk
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ProgressReporter sends progress notifications for a tool call. Clients
+// ask for them by putting a progressToken in the call's _meta; without one
+// (or outside a server session) reporting does nothing, so handlers can
+// always report without checking.
+type ProgressReporter struct {
+	ctx     context.Context
+	session *mcp.ServerSession
+	token   any
+}
+
+// Report sends a notifications/progress message with the progress so far.
+// current should increase with every report; a total of 0 means the total
+// is unknown.
+func (r *ProgressReporter) Report(current, total float64, message string) error {
+	if !r.Enabled() {
+		return nil
+	}
+	return r.session.NotifyProgress(r.ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: r.token,
+		Progress:      current,
+		Total:         total,
+		Message:       message,
+	})
+}
+
+// Enabled reports whether the client asked for progress, i.e. whether Report
+// sends anything
+func (r *ProgressReporter) Enabled() bool {
+	return r != nil && r.session != nil && r.token != nil
+}
+
+// newProgressReporter returns a reporter for a tool call request
+func newProgressReporter(ctx context.Context, req *mcp.CallToolRequest) *ProgressReporter {
+	if req == nil || req.Params == nil {
+		return &ProgressReporter{}
+	}
+	return &ProgressReporter{
+		ctx:     ctx,
+		session: req.Session,
+		token:   req.Params.GetProgressToken(),
+	}
+}
+
+// progressKey is the context key for the ProgressReporter of a tool call
+type progressKey struct{}
+
+// withProgress returns a context carrying a tool call's progress reporter
+func withProgress(ctx context.Context, reporter *ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressKey{}, reporter)
+}
+
+// ProgressFromContext returns the progress reporter of the tool call being
+// handled. It never returns nil: outside a tool call the reporter does
+// nothing.
+//
+// Example:
+//
+//	progress := gosdk.ProgressFromContext(ctx)
+//	for i, item := range items {
+//		process(item)
+//		progress.Report(float64(i+1), float64(len(items)), "processing")
+//	}
+func ProgressFromContext(ctx context.Context) *ProgressReporter {
+	if reporter, ok := ctx.Value(progressKey{}).(*ProgressReporter); ok {
+		return reporter
+	}
+	return &ProgressReporter{}
+}
@@ -0,0 +1,98 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestGoSDKAdapter_ProgressReporting(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+	if err := adapter.RegisterTool("work", "Report progress twice", types.ToolSchema{Type: "object"},
+		func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+			progress := ProgressFromContext(ctx)
+			if err := progress.Report(1, 2, "halfway"); err != nil {
+				return nil, err
+			}
+			if err := progress.Report(2, 2, "done"); err != nil {
+				return nil, err
+			}
+			return []types.TextContent{{Type: "text", Text: "ok"}}, nil
+		}); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+
+	notifications := make(chan *mcp.ProgressNotificationParams, 10)
+	session := connectInMemoryWithOptions(t, adapter, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(ctx context.Context, req *mcp.ProgressNotificationClientRequest) {
+			notifications <- req.Params
+		},
+	})
+	ctx := context.Background()
+
+	// Set _meta directly: SetProgressToken does nothing while Meta is nil
+	params := &mcp.CallToolParams{Meta: mcp.Meta{"progressToken": "token-1"}, Name: "work", Arguments: map[string]any{}}
+	result, err := session.CallTool(ctx, params)
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("CallTool() returned tool error: %+v", result.Content)
+	}
+
+	var got []*mcp.ProgressNotificationParams
+	for len(got) < 2 {
+		select {
+		case n := <-notifications:
+			got = append(got, n)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("got %d progress notifications, want 2", len(got))
+		}
+	}
+	for i, n := range got {
+		if n.ProgressToken != "token-1" {
+			t.Errorf("notification %d token = %v, want token-1", i, n.ProgressToken)
+		}
+		if n.Total != 2 {
+			t.Errorf("notification %d total = %v, want 2", i, n.Total)
+		}
+	}
+	if got[0].Progress != 1 || got[1].Progress != 2 {
+		t.Errorf("progress = %v, %v; want 1, 2", got[0].Progress, got[1].Progress)
+	}
+	if got[0].Message != "halfway" || got[1].Message != "done" {
+		t.Errorf("messages = %q, %q; want halfway, done", got[0].Message, got[1].Message)
+	}
+
+	// Without a progress token reporting is a no-op
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "work", Arguments: map[string]any{}}); err != nil {
+		t.Fatalf("CallTool() without a progress token error = %v", err)
+	}
+	select {
+	case n := <-notifications:
+		t.Errorf("got progress notification %+v for a call without a progress token", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestProgressFromContext_NoReporter(t *testing.T) {
+	progress := ProgressFromContext(context.Background())
+	if progress == nil {
+		t.Fatal("ProgressFromContext() = nil, want a no-op reporter")
+	}
+	if progress.Enabled() {
+		t.Error("reporter outside a tool call should not be enabled")
+	}
+	if err := progress.Report(1, 1, "ignored"); err != nil {
+		t.Errorf("Report() error = %v, want nil", err)
+	}
+
+	var nilReporter *ProgressReporter
+	if err := nilReporter.Report(1, 1, "ignored"); err != nil {
+		t.Errorf("nil Report() error = %v, want nil", err)
+	}
+}
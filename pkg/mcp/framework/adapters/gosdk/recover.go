@@ -0,0 +1,108 @@
+package gosdk
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PanicError reports a panic recovered from a handler by RecoverMiddleware
+type PanicError struct {
+	Value interface{} // the value passed to panic
+	Stack []byte      // stack trace of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("handler panicked: %v", e.Value)
+}
+
+// RecoverMiddleware turns handler panics into errors, so one crashing tool,
+// prompt or resource does not take down the server. Each panic is logged at
+// error level with its stack trace. Tool panics are reported to the client
+// as tool error results; prompt and resource panics as *PanicError.
+//
+// Register it before other middleware so it also covers them, or use
+// WithRecovery, which always installs it first.
+type RecoverMiddleware struct {
+	logger *logging.Logger
+}
+
+// NewRecoverMiddleware creates panic recovery middleware logging to logger.
+// If logger is nil, a default logger is created.
+//
+// Example:
+//
+//	adapter := NewGoSDKAdapter("server", "1.0.0",
+//		WithMiddleware(NewRecoverMiddleware(logger)),
+//	)
+func NewRecoverMiddleware(logger *logging.Logger) *RecoverMiddleware {
+	if logger == nil {
+		logger = logging.NewLogger()
+	}
+	return &RecoverMiddleware{logger: logger}
+}
+
+// recovered converts a value returned by recover() into a *PanicError and
+// logs it; what names the handler for the log. A *PanicError value (as
+// re-panicked by callWithTimeout) keeps the stack of the goroutine that
+// originally panicked.
+func (rm *RecoverMiddleware) recovered(value interface{}, what string) *PanicError {
+	err, ok := value.(*PanicError)
+	if !ok {
+		err = &PanicError{Value: value, Stack: debug.Stack()}
+	}
+	rm.logger.ErrorKV("", "recovered from panic", "handler", what, "panic", fmt.Sprint(err.Value), "stack", string(err.Stack))
+	return err
+}
+
+// ToolMiddleware wraps a tool handler with panic recovery
+func (rm *RecoverMiddleware) ToolMiddleware(next ToolHandlerFunc) ToolHandlerFunc {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+		defer func() {
+			if value := recover(); value != nil {
+				name := ""
+				if req != nil && req.Params != nil {
+					name = req.Params.Name
+				}
+				panicErr := rm.recovered(value, "tool "+name)
+				result, err = toolErrorResult("Tool execution error: %v", panicErr), nil
+			}
+		}()
+		return next(ctx, req)
+	}
+}
+
+// PromptMiddleware wraps a prompt handler with panic recovery
+func (rm *RecoverMiddleware) PromptMiddleware(next PromptHandlerFunc) PromptHandlerFunc {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (result *mcp.GetPromptResult, err error) {
+		defer func() {
+			if value := recover(); value != nil {
+				name := ""
+				if req != nil && req.Params != nil {
+					name = req.Params.Name
+				}
+				result, err = nil, rm.recovered(value, "prompt "+name)
+			}
+		}()
+		return next(ctx, req)
+	}
+}
+
+// ResourceMiddleware wraps a resource handler with panic recovery
+func (rm *RecoverMiddleware) ResourceMiddleware(next ResourceHandlerFunc) ResourceHandlerFunc {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (result *mcp.ReadResourceResult, err error) {
+		defer func() {
+			if value := recover(); value != nil {
+				uri := ""
+				if req != nil && req.Params != nil {
+					uri = req.Params.URI
+				}
+				result, err = nil, rm.recovered(value, "resource "+uri)
+			}
+		}()
+		return next(ctx, req)
+	}
+}
@@ -0,0 +1,147 @@
+package gosdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestRecoverMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	rm := NewRecoverMiddleware(logging.NewLoggerWithWriter(&logs))
+	ctx := context.Background()
+
+	tool := rm.ToolMiddleware(func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		panic("tool boom")
+	})
+	result, err := tool(ctx, &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "crash"}})
+	if err != nil {
+		t.Fatalf("tool error = %v, want a tool error result", err)
+	}
+	if result == nil || !result.IsError {
+		t.Fatalf("tool result = %+v, want IsError", result)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "tool boom") {
+		t.Errorf("tool error = %q, want the panic value", text)
+	}
+	if !strings.Contains(logs.String(), "recovered from panic") || !strings.Contains(logs.String(), "tool crash") {
+		t.Errorf("panic not logged: %s", logs.String())
+	}
+
+	prompt := rm.PromptMiddleware(func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		panic(errors.New("prompt boom"))
+	})
+	var panicErr *PanicError
+	if _, err := prompt(ctx, &mcp.GetPromptRequest{Params: &mcp.GetPromptParams{Name: "p"}}); !errors.As(err, &panicErr) {
+		t.Errorf("prompt error = %v, want *PanicError", err)
+	} else if len(panicErr.Stack) == 0 {
+		t.Error("PanicError has no stack trace")
+	}
+
+	resource := rm.ResourceMiddleware(func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		var m map[string]int
+		m["x"] = 1 // nil map write
+		return nil, nil
+	})
+	if _, err := resource(ctx, &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: "test://r"}}); !errors.As(err, &panicErr) {
+		t.Errorf("resource error = %v, want *PanicError", err)
+	}
+
+	// Handlers that don't panic are unaffected
+	ok := rm.PromptMiddleware(func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return &mcp.GetPromptResult{Description: "fine"}, nil
+	})
+	if got, err := ok(ctx, nil); err != nil || got.Description != "fine" {
+		t.Errorf("non-panicking prompt = %+v, %v", got, err)
+	}
+}
+
+func TestWithRecovery(t *testing.T) {
+	crash := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		panic("boom")
+	}
+
+	for _, opts := range map[string][]AdapterOption{
+		"no timeout":   {WithRecovery()},
+		"with timeout": {WithRecovery(), WithToolTimeout(time.Second)},
+	} {
+		adapter := NewGoSDKAdapter("test", "1.0.0", append(opts, WithLogger(logging.NewLoggerWithWriter(&bytes.Buffer{})))...)
+		if err := adapter.RegisterTool("crash", "Always panics", types.ToolSchema{Type: "object"}, crash); err != nil {
+			t.Fatalf("RegisterTool() error = %v", err)
+		}
+		if err := adapter.RegisterTool("echo", "Echo arguments", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+			t.Fatalf("RegisterTool() error = %v", err)
+		}
+		session := connectInMemory(t, adapter)
+		ctx := context.Background()
+
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "crash", Arguments: map[string]any{}})
+		if err != nil {
+			t.Fatalf("CallTool(crash) error = %v", err)
+		}
+		if !result.IsError {
+			t.Errorf("CallTool(crash) IsError = false, want the panic as a tool error")
+		}
+
+		// The server keeps serving other tools
+		result, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "echo", Arguments: map[string]any{"x": 1}})
+		if err != nil || result.IsError {
+			t.Errorf("CallTool(echo) after a panic = %+v, %v", result, err)
+		}
+	}
+}
+
+// panickingToolHandler is a named handler so its frame can be found in
+// logged stack traces
+func panickingToolHandler(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+	panic("boom")
+}
+
+func TestWithRecovery_TimeoutKeepsHandlerStack(t *testing.T) {
+	var logs bytes.Buffer
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithRecovery(), WithToolTimeout(time.Second),
+		WithLogger(logging.NewLoggerWithWriter(&logs)))
+	if err := adapter.RegisterTool("crash", "Always panics", types.ToolSchema{Type: "object"}, panickingToolHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	session := connectInMemory(t, adapter)
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "crash", Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("CallTool(crash) error = %v", err)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !result.IsError || !strings.Contains(text, "boom") {
+		t.Errorf("CallTool(crash) = %q (IsError %v), want the panic as a tool error", text, result.IsError)
+	}
+	// The stack is the handler goroutine's, not the one that re-panicked
+	if !strings.Contains(logs.String(), "panickingToolHandler") {
+		t.Errorf("logged stack does not include the handler: %s", logs.String())
+	}
+}
+
+func TestCallWithTimeout_PanicCarriesStack(t *testing.T) {
+	defer func() {
+		panicErr, ok := recover().(*PanicError)
+		if !ok {
+			t.Fatal("panic value is not a *PanicError")
+		}
+		if panicErr.Value != "boom" {
+			t.Errorf("Value = %v, want boom", panicErr.Value)
+		}
+		if !strings.Contains(string(panicErr.Stack), "panickingToolHandler") {
+			t.Errorf("Stack does not include the handler:\n%s", panicErr.Stack)
+		}
+	}()
+	_, _ = callWithTimeout(context.Background(), time.Second, ErrToolTimeout, func(ctx context.Context) ([]types.TextContent, error) {
+		return panickingToolHandler(ctx, nil)
+	})
+	t.Error("callWithTimeout did not re-panic")
+}
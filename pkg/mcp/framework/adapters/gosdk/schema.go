@@ -0,0 +1,251 @@
+package gosdk
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// Local reference prefixes understood by ResolveSchemaRefs
+var schemaRefPrefixes = []string{"#/$defs/", "#/definitions/"}
+
+// ResolveSchemaRefs returns a copy of a JSON schema with local "$ref"s
+// ("#/$defs/Name" or "#/definitions/Name") replaced by the referenced
+// definitions, for clients that cannot follow references themselves.
+//
+// Keywords next to a "$ref" (e.g. "description") override those of the
+// inlined definition. The root "$defs"/"definitions" are dropped from the
+// result once everything is inlined. Non-local references (URLs) are left
+// as-is. Returns an error for unknown or circular references.
+func ResolveSchemaRefs(schema map[string]interface{}) (map[string]interface{}, error) {
+	defs := make(map[string]interface{})
+	for _, key := range []string{"$defs", "definitions"} {
+		if d, ok := schema[key].(map[string]interface{}); ok {
+			for name, def := range d {
+				defs[key+"/"+name] = def
+			}
+		}
+	}
+
+	r := &refResolver{defs: defs, resolving: make(map[string]bool)}
+	resolved, err := r.resolve(schema)
+	if err != nil {
+		return nil, err
+	}
+	out := resolved.(map[string]interface{})
+	delete(out, "$defs")
+	delete(out, "definitions")
+	return out, nil
+}
+
+// refResolver inlines local $refs, tracking in-progress refs to detect cycles
+type refResolver struct {
+	defs      map[string]interface{} // "$defs/Name" or "definitions/Name" -> schema
+	resolving map[string]bool
+}
+
+// resolve returns a deep copy of v with local $refs inlined
+func (r *refResolver) resolve(v interface{}) (interface{}, error) {
+	switch node := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := node["$ref"].(string); ok {
+			if key, local := localRefKey(ref); local {
+				return r.inline(ref, key, node)
+			}
+		}
+		out := make(map[string]interface{}, len(node))
+		for k, child := range node {
+			resolved, err := r.resolve(child)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = resolved
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(node))
+		for i, child := range node {
+			resolved, err := r.resolve(child)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// inline replaces a $ref node with its resolved definition, overlaid with
+// the node's sibling keywords
+func (r *refResolver) inline(ref, key string, node map[string]interface{}) (interface{}, error) {
+	def, ok := r.defs[key]
+	if !ok {
+		return nil, fmt.Errorf("unresolved schema reference %q", ref)
+	}
+	if r.resolving[key] {
+		return nil, fmt.Errorf("circular schema reference %q", ref)
+	}
+
+	r.resolving[key] = true
+	resolved, err := r.resolve(def)
+	delete(r.resolving, key)
+	if err != nil {
+		return nil, err
+	}
+
+	defMap, ok := resolved.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema reference %q does not point to an object", ref)
+	}
+	for k, sibling := range node {
+		if k == "$ref" {
+			continue
+		}
+		resolvedSibling, err := r.resolve(sibling)
+		if err != nil {
+			return nil, err
+		}
+		defMap[k] = resolvedSibling
+	}
+	return defMap, nil
+}
+
+// localRefKey maps a local reference to its key in refResolver.defs
+func localRefKey(ref string) (string, bool) {
+	for _, prefix := range schemaRefPrefixes {
+		if name, ok := strings.CutPrefix(ref, prefix); ok {
+			return strings.TrimPrefix(prefix, "#/") + name, true
+		}
+	}
+	return "", false
+}
+
+// ValidateAgainstSchema checks a decoded JSON value (as produced by
+// json.Unmarshal into an interface{}) against a JSON schema. It is a
+// lightweight check of the "type", "required", "properties" and "items"
+// keywords; other keywords, including "$ref", are ignored. The error names
+// the path of the first mismatch, e.g. "$.items[2].id: expected integer, got
+// string".
+func ValidateAgainstSchema(value interface{}, schema map[string]interface{}) error {
+	return validateSchemaNode(value, schema, "$")
+}
+
+// validateSchemaNode validates value at path against one schema node
+func validateSchemaNode(value interface{}, schema map[string]interface{}, path string) error {
+	if want, ok := schema["type"]; ok && !matchesSchemaType(value, want) {
+		return fmt.Errorf("%s: expected %v, got %s", path, want, jsonTypeName(value))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range schemaStrings(schema["required"]) {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		props, _ := schema["properties"].(map[string]interface{})
+		names := make([]string, 0, len(props))
+		for name := range props {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			propValue, ok := v[name]
+			if !ok {
+				continue
+			}
+			if sub := schemaNode(props[name]); sub != nil {
+				if err := validateSchemaNode(propValue, sub, path+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		if items := schemaNode(schema["items"]); items != nil {
+			for i, item := range v {
+				if err := validateSchemaNode(item, items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// schemaNode returns a subschema as a map, converting types.PropertySchema
+func schemaNode(v interface{}) map[string]interface{} {
+	switch s := v.(type) {
+	case map[string]interface{}:
+		return s
+	case types.PropertySchema:
+		return s.Map()
+	case *types.PropertySchema:
+		if s != nil {
+			return s.Map()
+		}
+	}
+	return nil
+}
+
+// schemaStrings returns a keyword value that is a list of strings, such as
+// "required" or a list of types
+func schemaStrings(v interface{}) []string {
+	switch list := v.(type) {
+	case []string:
+		return list
+	case []interface{}:
+		strs := make([]string, 0, len(list))
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+		return strs
+	}
+	return nil
+}
+
+// matchesSchemaType reports whether value has the JSON type want, which is
+// a type name or a list of them
+func matchesSchemaType(value interface{}, want interface{}) bool {
+	names := schemaStrings(want)
+	if name, ok := want.(string); ok {
+		names = []string{name}
+	}
+	for _, name := range names {
+		got := jsonTypeName(value)
+		if got == name || (name == "number" && got == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonTypeName returns the JSON schema type of a decoded JSON value; numbers
+// without a fractional part are "integer"
+func jsonTypeName(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
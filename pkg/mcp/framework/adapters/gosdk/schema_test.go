@@ -0,0 +1,216 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+func TestResolveSchemaRefs(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"home": map[string]interface{}{"$ref": "#/$defs/Address"},
+			"work": map[string]interface{}{
+				"$ref":        "#/$defs/Address",
+				"description": "Office address",
+			},
+			"tags": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"$ref": "#/definitions/Tag"},
+			},
+		},
+		"$defs": map[string]interface{}{
+			"Address": map[string]interface{}{
+				"type":        "object",
+				"description": "A postal address",
+				"properties": map[string]interface{}{
+					"city": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+		"definitions": map[string]interface{}{
+			"Tag": map[string]interface{}{"type": "string"},
+		},
+	}
+
+	got, err := ResolveSchemaRefs(schema)
+	if err != nil {
+		t.Fatalf("ResolveSchemaRefs() error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"home": map[string]interface{}{
+				"type":        "object",
+				"description": "A postal address",
+				"properties": map[string]interface{}{
+					"city": map[string]interface{}{"type": "string"},
+				},
+			},
+			"work": map[string]interface{}{
+				"type":        "object",
+				"description": "Office address",
+				"properties": map[string]interface{}{
+					"city": map[string]interface{}{"type": "string"},
+				},
+			},
+			"tags": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		t.Errorf("ResolveSchemaRefs() =\n%s", gotJSON)
+	}
+
+	// The input must not be modified
+	if _, ok := schema["$defs"]; !ok {
+		t.Error("ResolveSchemaRefs() modified its input")
+	}
+}
+
+func TestResolveSchemaRefs_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "unknown ref",
+			schema: map[string]interface{}{
+				"properties": map[string]interface{}{"a": map[string]interface{}{"$ref": "#/$defs/Missing"}},
+			},
+			wantErr: "unresolved",
+		},
+		{
+			name: "circular ref",
+			schema: map[string]interface{}{
+				"properties": map[string]interface{}{"a": map[string]interface{}{"$ref": "#/$defs/Node"}},
+				"$defs": map[string]interface{}{
+					"Node": map[string]interface{}{
+						"properties": map[string]interface{}{"next": map[string]interface{}{"$ref": "#/$defs/Node"}},
+					},
+				},
+			},
+			wantErr: "circular",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ResolveSchemaRefs(tt.schema)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ResolveSchemaRefs() error = %v, want %q error", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGoSDKAdapter_WithSchemaRefResolution(t *testing.T) {
+	schema := types.ToolSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"point": map[string]interface{}{"$ref": "#/$defs/Point"},
+		},
+		Defs: map[string]interface{}{
+			"Point": map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{"x": map[string]interface{}{"type": "number"}},
+			},
+		},
+	}
+
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithSchemaRefResolution())
+	if err := adapter.RegisterTool("plot", "Plot a point", schema, echoHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+
+	session := connectInMemory(t, adapter)
+	listed, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	raw, err := json.Marshal(listed.Tools[0].InputSchema)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(raw), "$ref") || strings.Contains(string(raw), "$defs") {
+		t.Errorf("advertised schema still has references: %s", raw)
+	}
+	if !strings.Contains(string(raw), `"x":{"type":"number"}`) {
+		t.Errorf("advertised schema missing inlined definition: %s", raw)
+	}
+
+	// Bad references fail registration
+	bad := types.ToolSchema{
+		Type:       "object",
+		Properties: map[string]interface{}{"p": map[string]interface{}{"$ref": "#/$defs/Nope"}},
+	}
+	if err := adapter.RegisterTool("bad", "Bad schema", bad, echoHandler); err == nil {
+		t.Error("RegisterTool() with unresolved $ref error = nil, want error")
+	}
+}
+
+func TestValidateAgainstSchema(t *testing.T) {
+	schema := ToolSchemaToMCP(types.ToolSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"name":  types.StringProperty("Name"),
+			"count": map[string]interface{}{"type": "integer"},
+			"score": map[string]interface{}{"type": "number"},
+			"note":  map[string]interface{}{"type": []interface{}{"string", "null"}},
+			"tags": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string"},
+			},
+			"owner": map[string]interface{}{
+				"type":       "object",
+				"required":   []interface{}{"id"},
+				"properties": map[string]interface{}{"id": map[string]interface{}{"type": "integer"}},
+			},
+		},
+		Required: []string{"name", "count"},
+	})
+
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{name: "valid", json: `{"name":"a","count":1,"score":2,"note":null,"tags":["x"],"owner":{"id":7}}`},
+		{name: "extra properties allowed", json: `{"name":"a","count":1,"extra":true}`},
+		{name: "not an object", json: `[1]`, wantErr: "$: expected object, got array"},
+		{name: "missing required", json: `{"name":"a"}`, wantErr: `$: missing required property "count"`},
+		{name: "wrong type", json: `{"name":"a","count":"1"}`, wantErr: "$.count: expected integer, got string"},
+		{name: "fraction for integer", json: `{"name":"a","count":1.5}`, wantErr: "$.count: expected integer, got number"},
+		{name: "type list", json: `{"name":"a","count":1,"note":3}`, wantErr: "$.note: expected [string null], got integer"},
+		{name: "array item", json: `{"name":"a","count":1,"tags":["x",2]}`, wantErr: "$.tags[1]: expected string, got integer"},
+		{name: "nested required", json: `{"name":"a","count":1,"owner":{}}`, wantErr: `$.owner: missing required property "id"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(tt.json), &value); err != nil {
+				t.Fatal(err)
+			}
+			err := ValidateAgainstSchema(value, schema)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateAgainstSchema() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ValidateAgainstSchema() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
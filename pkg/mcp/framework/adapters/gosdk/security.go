@@ -0,0 +1,144 @@
+package gosdk
+
+import (
+	"context"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/security"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// sessionID returns the ID of the session a request arrived on, or "" if the
+// request was not delivered through a server session (e.g. in tests)
+func sessionID(req *mcp.CallToolRequest) string {
+	if req == nil || req.Session == nil {
+		return ""
+	}
+	return req.Session.ID()
+}
+
+// PIIScrubbingMiddleware returns tool middleware that logs every tool call
+// with PII masked out of its arguments. Use it instead of logging raw
+// arguments so emails, card numbers, etc. never reach the logs.
+// If scrubber is nil, security.NewPIIScrubber() defaults are used.
+//
+// Example:
+//
+//	adapter := NewGoSDKAdapter("server", "1.0.0",
+//		WithMiddleware(PIIScrubbingMiddleware(logger, nil)),
+//	)
+func PIIScrubbingMiddleware(logger *logging.Logger, scrubber *security.PIIScrubber) func(ToolHandlerFunc) ToolHandlerFunc {
+	if scrubber == nil {
+		scrubber = security.NewPIIScrubber()
+	}
+	return func(next ToolHandlerFunc) ToolHandlerFunc {
+		return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if req != nil && req.Params != nil {
+				logger.LogToolCall(sessionID(req), req.Params.Name, scrubber.ScrubJSON(req.Params.Arguments))
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// defaultClientID is the rate limit bucket for requests without a session ID
+// (e.g. stdio, where there is only one client)
+const defaultClientID = "default"
+
+// RateLimitMiddleware returns tool middleware that limits calls per session.
+// Calls over the limit are rejected with a tool error result reporting when
+// to retry; the handler is not invoked.
+func RateLimitMiddleware(rl *security.RateLimiter) func(ToolHandlerFunc) ToolHandlerFunc {
+	return func(next ToolHandlerFunc) ToolHandlerFunc {
+		return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			clientID := sessionID(req)
+			if clientID == "" {
+				clientID = defaultClientID
+			}
+			if err := security.CheckRateLimits(rl, nil, clientID); err != nil {
+				return toolErrorResult("Tool call rejected: %v", err), nil
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// ToolRateLimitMiddleware returns tool middleware that limits calls per
+// session and per tool using g, so each tool can have its own limit.
+// Rejected calls get a tool error result; the handler is not invoked.
+//
+// Example:
+//
+//	limits := security.NewRateLimiterGroup(time.Minute, 100)
+//	limits.RegisterLimit("run_command", time.Minute, 5)
+//	adapter := NewGoSDKAdapter("server", "1.0.0",
+//		WithMiddleware(ToolRateLimitMiddleware(limits)),
+//	)
+func ToolRateLimitMiddleware(g *security.RateLimiterGroup) func(ToolHandlerFunc) ToolHandlerFunc {
+	return func(next ToolHandlerFunc) ToolHandlerFunc {
+		return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if req == nil || req.Params == nil {
+				return next(ctx, req)
+			}
+			clientID := sessionID(req)
+			if clientID == "" {
+				clientID = defaultClientID
+			}
+			if err := g.CheckFor(clientID, req.Params.Name); err != nil {
+				return toolErrorResult("Tool call %q rejected: %v", req.Params.Name, err), nil
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// AccessControlMiddleware returns tool middleware that rejects calls to tools
+// denied by ac with a tool error result
+func AccessControlMiddleware(ac *security.AccessControl) func(ToolHandlerFunc) ToolHandlerFunc {
+	return func(next ToolHandlerFunc) ToolHandlerFunc {
+		return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if req != nil && req.Params != nil {
+				if err := ac.CheckToolForClient(sessionID(req), req.Params.Name); err != nil {
+					return toolErrorResult("Tool call rejected: %v", err), nil
+				}
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// RequestSizeMiddleware returns tool middleware that rejects calls whose raw
+// arguments exceed maxBytes. A maxBytes of 0 or less disables the check.
+func RequestSizeMiddleware(maxBytes int) func(ToolHandlerFunc) ToolHandlerFunc {
+	return func(next ToolHandlerFunc) ToolHandlerFunc {
+		return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if maxBytes > 0 && req != nil && req.Params != nil && len(req.Params.Arguments) > maxBytes {
+				return toolErrorResult("Tool call rejected: arguments size %d bytes exceeds limit of %d bytes",
+					len(req.Params.Arguments), maxBytes), nil
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// PolicyMiddleware returns tool middleware enforcing p: calls that are too
+// large, denied, or over the rate limit are rejected with a tool error result
+// and the handler is not invoked. It replaces stacking RequestSizeMiddleware,
+// AccessControlMiddleware and RateLimitMiddleware.
+func PolicyMiddleware(p *security.Policy) func(ToolHandlerFunc) ToolHandlerFunc {
+	return func(next ToolHandlerFunc) ToolHandlerFunc {
+		return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if req == nil || req.Params == nil {
+				return next(ctx, req)
+			}
+			clientID := sessionID(req)
+			if clientID == "" {
+				clientID = defaultClientID
+			}
+			if err := p.CheckRequest(clientID, req.Params.Name, len(req.Params.Arguments)); err != nil {
+				return toolErrorResult("Tool call rejected: %v", err), nil
+			}
+			return next(ctx, req)
+		}
+	}
+}
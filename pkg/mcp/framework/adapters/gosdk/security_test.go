@@ -0,0 +1,225 @@
+package gosdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/security"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// captureStderr runs fn with os.Stderr redirected and returns what was written
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	original := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = original }()
+
+	fn()
+
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read captured stderr: %v", err)
+	}
+	return string(out)
+}
+
+func TestPIIScrubbingMiddleware(t *testing.T) {
+	called := false
+	var gotArgs json.RawMessage
+
+	output := captureStderr(t, func() {
+		logger := logging.NewLogger()
+		logger.SetLevel(logging.LevelDebug)
+
+		handler := PIIScrubbingMiddleware(logger, nil)(func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			called = true
+			gotArgs = req.Params.Arguments
+			return &mcp.CallToolResult{}, nil
+		})
+
+		_, _ = handler(context.Background(), &mcp.CallToolRequest{
+			Params: &mcp.CallToolParamsRaw{
+				Name:      "lookup",
+				Arguments: json.RawMessage(`{"email":"alice@example.com","card":"4111 1111 1111 1111","note":"hello"}`),
+			},
+		})
+	})
+
+	if !called {
+		t.Fatal("next handler was not called")
+	}
+	if !strings.Contains(string(gotArgs), "alice@example.com") {
+		t.Error("handler should receive original, unscrubbed arguments")
+	}
+	if strings.Contains(output, "alice@example.com") || strings.Contains(output, "4111 1111 1111 1111") {
+		t.Errorf("PII leaked into logs: %s", output)
+	}
+	if !strings.Contains(output, "lookup") || !strings.Contains(output, "hello") {
+		t.Errorf("log output missing tool name or ordinary text: %s", output)
+	}
+}
+
+func TestPIIScrubbingMiddleware_RedactKeys(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "")
+	var buf bytes.Buffer
+	logger := logging.NewLoggerWithWriter(&buf)
+	logger.SetLevel(logging.LevelDebug)
+	logger.SetRedactKeys([]string{"password"})
+
+	handler := PIIScrubbingMiddleware(logger, nil)(func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{}, nil
+	})
+	_, _ = handler(context.Background(), &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      "login",
+			Arguments: json.RawMessage(`{"user":"alice","auth":{"Password":"hunter2"}}`),
+		},
+	})
+
+	output := strings.ReplaceAll(buf.String(), `\"`, `"`) // unquote the text format
+	if strings.Contains(output, "hunter2") {
+		t.Errorf("redacted argument leaked into logs: %s", output)
+	}
+	if !strings.Contains(output, `"Password":"`+logging.RedactedValue+`"`) || !strings.Contains(output, `"user":"alice"`) {
+		t.Errorf("log output = %s, want the password masked and other arguments kept", output)
+	}
+}
+
+func TestToolRateLimitMiddleware(t *testing.T) {
+	limits := security.NewRateLimiterGroup(time.Minute, 5)
+	defer limits.Stop()
+	limits.RegisterLimit("shell", time.Minute, 1)
+
+	calls := 0
+	handler := ToolRateLimitMiddleware(limits)(func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return &mcp.CallToolResult{}, nil
+	})
+	call := func(name string) *mcp.CallToolResult {
+		result, err := handler(context.Background(), &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: name}})
+		if err != nil {
+			t.Fatalf("handler(%s) error = %v", name, err)
+		}
+		return result
+	}
+
+	if result := call("shell"); result.IsError {
+		t.Fatal("first shell call should be allowed")
+	}
+	result := call("shell")
+	if !result.IsError {
+		t.Fatal("second shell call should be rejected")
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, `"shell"`) {
+		t.Errorf("rejection should name the tool: %s", text)
+	}
+
+	// The default limit is independent of the shell limit
+	for i := 0; i < 5; i++ {
+		if result := call("echo"); result.IsError {
+			t.Fatalf("echo call %d should be allowed", i+1)
+		}
+	}
+	if result := call("echo"); !result.IsError {
+		t.Error("6th echo call should be rejected")
+	}
+	if calls != 6 {
+		t.Errorf("handler called %d times, want 6", calls)
+	}
+}
+
+func TestPolicyMiddleware(t *testing.T) {
+	ac := security.NewAccessControl(security.PermissionAllow)
+	ac.DenyTool("shell")
+	policy := &security.Policy{
+		Access:      ac,
+		RateLimit:   security.NewRateLimiter(time.Minute, 1),
+		MaxArgBytes: 10,
+	}
+	defer policy.Stop()
+
+	calls := 0
+	handler := PolicyMiddleware(policy)(func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return &mcp.CallToolResult{}, nil
+	})
+	call := func(name, args string) *mcp.CallToolResult {
+		result, err := handler(context.Background(), &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: name, Arguments: json.RawMessage(args)}})
+		if err != nil {
+			t.Fatalf("handler(%s) error = %v", name, err)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name, tool, args, want string
+	}{
+		{"too large", "echo", `{"text":"far too long"}`, "exceeds limit"},
+		{"denied", "shell", `{}`, "access denied"},
+		{"allowed", "echo", `{}`, ""},
+		{"rate limited", "echo", `{}`, "rate limit exceeded"},
+	}
+	for _, tt := range tests {
+		result := call(tt.tool, tt.args)
+		if tt.want == "" {
+			if result.IsError {
+				t.Errorf("%s: call rejected: %+v", tt.name, result.Content)
+			}
+			continue
+		}
+		if !result.IsError {
+			t.Errorf("%s: call should be rejected", tt.name)
+			continue
+		}
+		if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, tt.want) {
+			t.Errorf("%s: rejection %q does not contain %q", tt.name, text, tt.want)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+}
+
+func TestPolicyMiddleware_CallTool(t *testing.T) {
+	ac := security.NewAccessControl(security.PermissionAllow)
+	ac.DenyTool("shell")
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithMiddleware(PolicyMiddleware(&security.Policy{Access: ac})))
+
+	calls := 0
+	handler := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		calls++
+		return []types.TextContent{{Type: "text", Text: "ok"}}, nil
+	}
+	for _, name := range []string{"echo", "shell"} {
+		if err := adapter.RegisterTool(name, "Test tool", types.ToolSchema{Type: "object"}, handler); err != nil {
+			t.Fatalf("RegisterTool(%s) error = %v", name, err)
+		}
+	}
+
+	ctx := context.Background()
+	if _, err := adapter.CallTool(ctx, "shell", json.RawMessage(`{}`)); err == nil || !strings.Contains(err.Error(), "access denied") {
+		t.Errorf("CallTool(shell) error = %v, want access denied", err)
+	}
+	if calls != 0 {
+		t.Errorf("handler called %d times for a denied tool, want 0", calls)
+	}
+
+	result, err := adapter.CallTool(ctx, "echo", json.RawMessage(`{}`))
+	if err != nil || len(result) != 1 || result[0].Text != "ok" {
+		t.Errorf("CallTool(echo) = %v, %v; want ok", result, err)
+	}
+}
@@ -0,0 +1,213 @@
+package gosdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// sessionBuffer is how many received messages are queued per client
+// session before the dispatcher waits for the session to read them
+const sessionBuffer = 16
+
+// sessionServer is a framework transport serving many clients, each on its
+// own connection identified by a session ID
+type sessionServer interface {
+	framework.Transport
+
+	// readSessionMessage returns the next message received from any client
+	// and the session ID of its connection
+	readSessionMessage(ctx context.Context) (sessionID string, data []byte, err error)
+
+	// WriteToConnection sends data to the client of the given session only
+	WriteToConnection(sessionID string, data []byte) error
+
+	// ConnectionDone is closed when the session's connection ends
+	ConnectionDone(sessionID string) <-chan struct{}
+}
+
+// wsSessions serves the clients of a framework.WSTransport
+type wsSessions struct {
+	*framework.WSTransport
+}
+
+func (s wsSessions) readSessionMessage(ctx context.Context) (string, []byte, error) {
+	msg, err := s.ReadSessionMessage(ctx)
+	return msg.SessionID, msg.Data, err
+}
+
+// sseSessions serves the clients of a framework.SSETransport
+type sseSessions struct {
+	*framework.SSETransport
+}
+
+func (s sseSessions) readSessionMessage(ctx context.Context) (string, []byte, error) {
+	msg, err := s.ReadSessionMessage(ctx)
+	return msg.SessionID, msg.Data, err
+}
+
+// serveSessions starts transport and serves every client in its own MCP
+// session until runCtx is done or the transport stops, then stops transport.
+//
+// Messages from the transport are dispatched to the session of the
+// connection they arrived on (creating it for a new connection), and each
+// session's responses and notifications are written to its connection only.
+func (a *GoSDKAdapter) serveSessions(ctx, runCtx context.Context, transport sessionServer) error {
+	if err := transport.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start transport: %w", err)
+	}
+
+	var (
+		mu       sync.Mutex
+		sessions = make(map[string]*sessionConnection)
+		wg       sync.WaitGroup
+	)
+	defer func() {
+		mu.Lock()
+		for _, conn := range sessions {
+			_ = conn.Close()
+		}
+		mu.Unlock()
+		wg.Wait()
+	}()
+
+	for {
+		sessionID, data, err := transport.readSessionMessage(runCtx)
+		if err != nil {
+			break
+		}
+
+		mu.Lock()
+		conn, ok := sessions[sessionID]
+		mu.Unlock()
+		if !ok {
+			conn = newSessionConnection(transport, sessionID)
+			session, err := a.server.Connect(runCtx, &sessionTransport{conn: conn}, nil)
+			if err != nil {
+				a.logger.Warn("", "Failed to start session for %s client %s: %v", transport.Type(), sessionID, err)
+				continue
+			}
+			mu.Lock()
+			sessions[sessionID] = conn
+			mu.Unlock()
+
+			// End the session when its client disconnects
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				select {
+				case <-transport.ConnectionDone(conn.sessionID):
+				case <-conn.closed:
+				}
+				_ = session.Close()
+				mu.Lock()
+				delete(sessions, conn.sessionID)
+				mu.Unlock()
+			}()
+		}
+		conn.deliver(runCtx, data)
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		_ = transport.Stop(context.Background())
+		return fmt.Errorf("server run failed: %w", ctx.Err())
+	case runCtx.Err() != nil:
+		a.logger.Info("", "Shutdown signal received, stopping server")
+	default:
+		a.logger.Info("", "%s transport stopped, stopping server", transport.Type())
+	}
+
+	if err := transport.Stop(ctx); err != nil {
+		return fmt.Errorf("failed to stop transport: %w", err)
+	}
+	return nil
+}
+
+// sessionTransport is the go-sdk mcp.Transport for one client session
+type sessionTransport struct {
+	conn *sessionConnection
+}
+
+// Connect returns the session's JSON-RPC connection
+func (t *sessionTransport) Connect(ctx context.Context) (mcp.Connection, error) {
+	return t.conn, nil
+}
+
+// sessionConnection implements mcp.Connection for one client of a
+// sessionServer. It reads the messages dispatched to it by serveSessions
+// and writes to its own client only.
+type sessionConnection struct {
+	transport sessionServer
+	sessionID string
+	incoming  chan []byte
+	closed    chan struct{} // closed by Close to unblock Read
+	closeOnce sync.Once
+}
+
+// newSessionConnection creates the connection for the given session
+func newSessionConnection(transport sessionServer, sessionID string) *sessionConnection {
+	return &sessionConnection{
+		transport: transport,
+		sessionID: sessionID,
+		incoming:  make(chan []byte, sessionBuffer),
+		closed:    make(chan struct{}),
+	}
+}
+
+// deliver queues a message received from the session's client, giving up
+// if the connection is closed or ctx is done
+func (c *sessionConnection) deliver(ctx context.Context, data []byte) {
+	select {
+	case c.incoming <- data:
+	case <-c.closed:
+	case <-ctx.Done():
+	}
+}
+
+// Read decodes the next JSON-RPC message received from the session's client
+func (c *sessionConnection) Read(ctx context.Context) (jsonrpc.Message, error) {
+	select {
+	case data := <-c.incoming:
+		msg, err := jsonrpc.DecodeMessage(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON-RPC message: %w", err)
+		}
+		return msg, nil
+	case <-c.closed:
+		return nil, io.EOF
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Write encodes a JSON-RPC message and sends it to the session's client
+func (c *sessionConnection) Write(ctx context.Context, msg jsonrpc.Message) error {
+	select {
+	case <-c.closed:
+		return fmt.Errorf("connection closed")
+	default:
+	}
+	data, err := jsonrpc.EncodeMessage(msg)
+	if err != nil {
+		return err
+	}
+	return c.transport.WriteToConnection(c.sessionID, data)
+}
+
+// Close ends the session. The client's connection itself is closed when the
+// client disconnects or the transport is stopped by serveSessions.
+func (c *sessionConnection) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+// SessionID returns the session ID assigned by the transport
+func (c *sessionConnection) SessionID() string {
+	return c.sessionID
+}
@@ -0,0 +1,47 @@
+//go:build !windows
+
+package gosdk
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+)
+
+func TestGoSDKAdapter_RunShutdownSignal(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithShutdownSignals())
+
+	transport := framework.NewWSTransport("/ws", 0)
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- adapter.Run(context.Background(), transport) }()
+
+	// The signal handler is installed before the transport starts
+	deadline := time.Now().Add(2 * time.Second)
+	for transport.BoundPort() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("transport never started")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatalf("failed to send SIGTERM: %v", err)
+	}
+
+	select {
+	case err := <-runErr:
+		if err != nil {
+			t.Errorf("Run() error = %v, want nil after shutdown signal", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return after SIGTERM")
+	}
+	if transport.BoundPort() != 0 {
+		t.Error("transport still listening after shutdown")
+	}
+}
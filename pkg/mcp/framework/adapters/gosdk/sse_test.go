@@ -0,0 +1,160 @@
+package gosdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// runSSE runs adapter on an ephemeral-port SSE transport until the test
+// ends and returns the transport once it is listening
+func runSSE(t *testing.T, adapter *GoSDKAdapter) *framework.SSETransport {
+	t.Helper()
+	transport := framework.NewSSETransport("/sse", 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- adapter.Run(ctx, transport) }()
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case <-runErr:
+		case <-time.After(2 * time.Second):
+			t.Error("Run() did not return after cancellation")
+		}
+	})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for transport.BoundPort() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("transport never started")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return transport
+}
+
+// sseClient is a raw JSON-RPC client that posts requests to an SSE
+// transport and reads the responses from its event stream
+type sseClient struct {
+	t         *testing.T
+	url       string
+	sessionID string
+	events    chan []byte // data of each event received
+}
+
+// dialSSE opens an event stream to transport and initializes an MCP session
+func dialSSE(t *testing.T, transport *framework.SSETransport) *sseClient {
+	t.Helper()
+	url := fmt.Sprintf("http://127.0.0.1:%d/sse", transport.BoundPort())
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	c := &sseClient{
+		t:         t,
+		url:       url,
+		sessionID: resp.Header.Get(framework.SSESessionHeader),
+		events:    make(chan []byte, 16),
+	}
+	go func() {
+		defer close(c.events)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+				c.events <- []byte(data)
+			}
+		}
+	}()
+
+	c.call(1, "initialize", map[string]interface{}{
+		"protocolVersion": "2025-06-18",
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]interface{}{"name": "sse-test", "version": "1.0.0"},
+	})
+	c.send(map[string]interface{}{"jsonrpc": "2.0", "method": "notifications/initialized"})
+	return c
+}
+
+// send posts a JSON-RPC message
+func (c *sseClient) send(msg map[string]interface{}) {
+	c.t.Helper()
+	data, _ := json.Marshal(msg)
+	req, err := http.NewRequest(http.MethodPost, c.url, strings.NewReader(string(data)))
+	if err != nil {
+		c.t.Fatalf("NewRequest() error = %v", err)
+	}
+	req.Header.Set(framework.SSESessionHeader, c.sessionID)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatalf("POST error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		c.t.Fatalf("POST status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+}
+
+// call sends a request and returns its response, skipping notifications
+// and the transport's own connection events
+func (c *sseClient) call(id int, method string, params interface{}) map[string]interface{} {
+	c.t.Helper()
+	c.send(map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+	timeout := time.After(2 * time.Second)
+	for {
+		var data []byte
+		select {
+		case d, ok := <-c.events:
+			if !ok {
+				c.t.Fatalf("%s: event stream closed", method)
+			}
+			data = d
+		case <-timeout:
+			c.t.Fatalf("%s: timed out waiting for response", method)
+		}
+		var resp map[string]interface{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			c.t.Fatalf("event is not JSON: %s", data)
+		}
+		if resp["id"] == nil {
+			continue
+		}
+		if resp["id"] != float64(id) {
+			c.t.Fatalf("%s: got response to request %v, want %d", method, resp["id"], id)
+		}
+		if resp["error"] != nil {
+			c.t.Fatalf("%s error = %v", method, resp["error"])
+		}
+		return resp
+	}
+}
+
+func TestGoSDKAdapter_RunSSE(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+	if err := adapter.RegisterTool("echo", "Echo arguments", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	transport := runSSE(t, adapter)
+	a := dialSSE(t, transport)
+	b := dialSSE(t, transport)
+
+	// Each client gets only the responses to its own requests, even with
+	// the same request IDs
+	for name, client := range map[string]*sseClient{"a": a, "b": b} {
+		resp := client.call(2, "tools/call", map[string]interface{}{"name": "echo", "arguments": map[string]interface{}{"client": name}})
+		data, _ := json.Marshal(resp["result"])
+		if !strings.Contains(string(data), fmt.Sprintf(`{\"client\":\"%s\"}`, name)) {
+			t.Errorf("client %s tools/call result = %s, want its own arguments echoed", name, data)
+		}
+	}
+}
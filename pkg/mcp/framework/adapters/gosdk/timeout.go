@@ -0,0 +1,75 @@
+package gosdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// ErrToolTimeout is returned (wrapped) when a tool call runs longer than
+// its timeout; see WithToolTimeout and framework.ToolTimeout
+var ErrToolTimeout = errors.New("tool exceeded timeout")
+
+// toolTimeoutFor returns the timeout for a tool registered with timeout:
+// the adapter default for 0, none (0) for a negative timeout
+func (a *GoSDKAdapter) toolTimeoutFor(timeout time.Duration) time.Duration {
+	switch {
+	case timeout == 0:
+		return a.toolTimeout
+	case timeout < 0:
+		return 0
+	default:
+		return timeout
+	}
+}
+
+// callWithTimeout runs call with a context that is canceled after timeout
+// (0 = no timeout). When the timeout expires callWithTimeout returns
+// exceeded (wrapped with the timeout) at once rather than waiting for call,
+// which keeps running in the background until it notices its context is
+// done.
+func callWithTimeout[T any](ctx context.Context, timeout time.Duration, exceeded error, call func(context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return call(ctx)
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	type outcome struct {
+		result T
+		err    error
+		panic  *PanicError
+	}
+	done := make(chan outcome, 1) // buffered so an abandoned call can finish
+	go func() {
+		// Forward a panic to the caller's goroutine, where middleware such
+		// as RecoverMiddleware can recover it, as a *PanicError carrying
+		// this goroutine's stack (the caller's would not show the handler).
+		// A panic after the call was abandoned has no caller and is dropped.
+		defer func() {
+			if value := recover(); value != nil {
+				done <- outcome{panic: &PanicError{Value: value, Stack: debug.Stack()}}
+			}
+		}()
+		result, err := call(ctx)
+		done <- outcome{result: result, err: err}
+	}()
+
+	select {
+	case out := <-done:
+		if out.panic != nil {
+			panic(out.panic)
+		}
+		return out.result, out.err
+	case <-ctx.Done():
+		var zero T
+		if err := parent.Err(); err != nil {
+			return zero, err // canceled by the caller, not timed out
+		}
+		return zero, fmt.Errorf("%w of %v", exceeded, timeout)
+	}
+}
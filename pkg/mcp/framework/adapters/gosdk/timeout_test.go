@@ -0,0 +1,113 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestGoSDKAdapter_ToolTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	canceled := make(chan struct{}, 10)
+	// slow ignores cancellation until released, so a prompt error proves
+	// the adapter did not wait for it
+	slow := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		<-release
+		return []types.TextContent{{Type: "text", Text: "finally"}}, nil
+	}
+	sleepy := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		select {
+		case <-time.After(300 * time.Millisecond):
+			return []types.TextContent{{Type: "text", Text: "rested"}}, nil
+		case <-ctx.Done():
+			canceled <- struct{}{}
+			return nil, ctx.Err()
+		}
+	}
+
+	adapter := NewGoSDKAdapter("test", "1.0.0", WithToolTimeout(100*time.Millisecond))
+	if err := adapter.RegisterTool("slow", "Never finishes in time", types.ToolSchema{Type: "object"}, slow); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	if err := adapter.RegisterTool("sleepy", "Sleeps past the timeout", types.ToolSchema{Type: "object"}, sleepy); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	if err := adapter.RegisterTool("patient", "Has a longer timeout", types.ToolSchema{Type: "object"}, sleepy, framework.ToolTimeout(time.Second)); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	if err := adapter.RegisterTool("unbounded", "Has no timeout", types.ToolSchema{Type: "object"}, sleepy, framework.ToolTimeout(0)); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	if err := adapter.RegisterTool("strict", "Has a shorter timeout", types.ToolSchema{Type: "object"}, slow, framework.ToolTimeout(10*time.Millisecond)); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+
+	session := connectInMemory(t, adapter)
+	ctx := context.Background()
+
+	tests := []struct {
+		tool     string
+		wantErr  bool
+		maxDelay time.Duration
+	}{
+		{tool: "slow", wantErr: true, maxDelay: 250 * time.Millisecond},
+		{tool: "sleepy", wantErr: true, maxDelay: 250 * time.Millisecond},
+		{tool: "strict", wantErr: true, maxDelay: 80 * time.Millisecond},
+		{tool: "patient", wantErr: false, maxDelay: 2 * time.Second},
+		{tool: "unbounded", wantErr: false, maxDelay: 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			start := time.Now()
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: tt.tool, Arguments: map[string]any{}})
+			elapsed := time.Since(start)
+			if err != nil {
+				t.Fatalf("CallTool() error = %v", err)
+			}
+			if result.IsError != tt.wantErr {
+				t.Fatalf("IsError = %v, want %v: %+v", result.IsError, tt.wantErr, result.Content)
+			}
+			if tt.wantErr {
+				if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "tool exceeded timeout") {
+					t.Errorf("error = %q, want a timeout error", text)
+				}
+			}
+			if elapsed > tt.maxDelay {
+				t.Errorf("CallTool() took %v, want at most %v", elapsed, tt.maxDelay)
+			}
+		})
+	}
+
+	// The handler's context is canceled when the call times out
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Error("sleepy handler's context was not canceled")
+	}
+
+	// CLI mode enforces the timeout too
+	if _, err := adapter.CallTool(ctx, "slow", json.RawMessage(`{}`)); !errors.Is(err, ErrToolTimeout) {
+		t.Errorf("CallTool() error = %v, want ErrToolTimeout", err)
+	}
+}
+
+func TestCallWithTimeout_CallerCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := callWithTimeout(ctx, time.Second, ErrToolTimeout, func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond) // let the select see ctx.Done first
+		return "", ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrToolTimeout) {
+		t.Errorf("callWithTimeout() error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,128 @@
+package gosdk
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// URITemplate is a parsed RFC 6570 level 1 URI template, such as
+// "file:///logs/{date}.log": literal text with simple {var} expressions
+type URITemplate struct {
+	raw      string
+	literals []string // literal text around the variables; len(vars)+1 entries
+	vars     []string
+	re       *regexp.Regexp
+}
+
+// uriTemplateVarName matches a level 1 variable name
+var uriTemplateVarName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// uriTemplateValue matches an expanded level 1 value: unreserved characters
+// and percent-encoded octets
+const uriTemplateValue = `((?:[A-Za-z0-9\-._~]|%[0-9A-Fa-f]{2})*)`
+
+// ParseURITemplate parses a level 1 URI template. It rejects unbalanced
+// braces, operators and modifiers from higher levels (e.g. "{+path}" or
+// "{list*}"), and variables used more than once.
+func ParseURITemplate(template string) (*URITemplate, error) {
+	t := &URITemplate{raw: template}
+	seen := make(map[string]bool)
+	var pattern strings.Builder
+	pattern.WriteString("^")
+
+	rest := template
+	for {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			break
+		}
+		if rest[open] == '}' {
+			return nil, fmt.Errorf("URI template %q: unexpected '}'", template)
+		}
+		end := strings.IndexAny(rest[open+1:], "{}")
+		if end < 0 || rest[open+1+end] == '{' {
+			return nil, fmt.Errorf("URI template %q: unclosed '{'", template)
+		}
+		name := rest[open+1 : open+1+end]
+		if !uriTemplateVarName.MatchString(name) {
+			return nil, fmt.Errorf("URI template %q: unsupported expression {%s}", template, name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("URI template %q: variable %q used more than once", template, name)
+		}
+		seen[name] = true
+
+		t.literals = append(t.literals, rest[:open])
+		t.vars = append(t.vars, name)
+		pattern.WriteString(regexp.QuoteMeta(rest[:open]))
+		pattern.WriteString(uriTemplateValue)
+		rest = rest[open+1+end+1:]
+	}
+	t.literals = append(t.literals, rest)
+	pattern.WriteString(regexp.QuoteMeta(rest))
+	pattern.WriteString("$")
+
+	t.re = regexp.MustCompile(pattern.String())
+	return t, nil
+}
+
+// String returns the template as it was parsed
+func (t *URITemplate) String() string {
+	return t.raw
+}
+
+// Variables returns the template's variable names, in order
+func (t *URITemplate) Variables() []string {
+	return append([]string(nil), t.vars...)
+}
+
+// Expand substitutes vars into the template, percent-encoding every
+// character outside the unreserved set. Missing variables expand to "".
+func (t *URITemplate) Expand(vars map[string]string) string {
+	var b strings.Builder
+	for i, name := range t.vars {
+		b.WriteString(t.literals[i])
+		b.WriteString(escapeUnreserved(vars[name]))
+	}
+	b.WriteString(t.literals[len(t.literals)-1])
+	return b.String()
+}
+
+// Match reports whether uri is an expansion of the template and, if so,
+// returns the decoded value of each variable
+func (t *URITemplate) Match(uri string) (map[string]string, bool) {
+	m := t.re.FindStringSubmatch(uri)
+	if m == nil {
+		return nil, false
+	}
+	vars := make(map[string]string, len(t.vars))
+	for i, name := range t.vars {
+		value, err := url.PathUnescape(m[i+1])
+		if err != nil {
+			return nil, false
+		}
+		vars[name] = value
+	}
+	return vars, true
+}
+
+// escapeUnreserved percent-encodes s as RFC 6570 simple string expansion
+// does: everything except ALPHA, DIGIT, '-', '.', '_' and '~'
+func escapeUnreserved(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0xF])
+	}
+	return b.String()
+}
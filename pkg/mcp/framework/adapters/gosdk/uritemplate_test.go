@@ -0,0 +1,91 @@
+package gosdk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseURITemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		vars     []string
+		wantErr  bool
+	}{
+		{template: "file:///logs/{date}.log", vars: []string{"date"}},
+		{template: "db://{table}/{id}", vars: []string{"table", "id"}},
+		{template: "static://readme", vars: nil},
+		{template: "file:///{path", wantErr: true},
+		{template: "file:///path}", wantErr: true},
+		{template: "file:///{a{b}}", wantErr: true},
+		{template: "file:///{}", wantErr: true},
+		{template: "file:///{+path}", wantErr: true},
+		{template: "file:///{list*}", wantErr: true},
+		{template: "db://{id}/{id}", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			tmpl, err := ParseURITemplate(tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseURITemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := tmpl.Variables(); !reflect.DeepEqual(got, tt.vars) {
+				t.Errorf("Variables() = %v, want %v", got, tt.vars)
+			}
+			if tmpl.String() != tt.template {
+				t.Errorf("String() = %q, want %q", tmpl.String(), tt.template)
+			}
+		})
+	}
+}
+
+func TestURITemplate_Match(t *testing.T) {
+	tmpl, err := ParseURITemplate("db://{table}/{id}.json")
+	if err != nil {
+		t.Fatalf("ParseURITemplate() error = %v", err)
+	}
+
+	tests := []struct {
+		uri  string
+		vars map[string]string
+		ok   bool
+	}{
+		{"db://users/42.json", map[string]string{"table": "users", "id": "42"}, true},
+		{"db://users/v1.2.json", map[string]string{"table": "users", "id": "v1.2"}, true},
+		{"db://my%20table/42.json", map[string]string{"table": "my table", "id": "42"}, true},
+		{"db://users/.json", map[string]string{"table": "users", "id": ""}, true},
+		{"db://users/a/b.json", nil, false}, // '/' must be encoded in a value
+		{"db://users/42.xml", nil, false},
+		{"file://users/42.json", nil, false},
+	}
+	for _, tt := range tests {
+		vars, ok := tmpl.Match(tt.uri)
+		if ok != tt.ok || !reflect.DeepEqual(vars, tt.vars) {
+			t.Errorf("Match(%q) = %v, %v; want %v, %v", tt.uri, vars, ok, tt.vars, tt.ok)
+		}
+	}
+}
+
+func TestURITemplate_Expand(t *testing.T) {
+	tmpl, err := ParseURITemplate("file:///logs/{date}.log?q={query}")
+	if err != nil {
+		t.Fatalf("ParseURITemplate() error = %v", err)
+	}
+
+	got := tmpl.Expand(map[string]string{"date": "2024-01-02", "query": "a b/c"})
+	want := "file:///logs/2024-01-02.log?q=a%20b%2Fc"
+	if got != want {
+		t.Errorf("Expand() = %q, want %q", got, want)
+	}
+	if got := tmpl.Expand(nil); got != "file:///logs/.log?q=" {
+		t.Errorf("Expand(nil) = %q, want missing variables to expand to \"\"", got)
+	}
+
+	// Expansion and matching round-trip
+	vars := map[string]string{"date": "día 1", "query": "x&y=z"}
+	if matched, ok := tmpl.Match(tmpl.Expand(vars)); !ok || !reflect.DeepEqual(matched, vars) {
+		t.Errorf("Match(Expand(%v)) = %v, %v", vars, matched, ok)
+	}
+}
@@ -2,7 +2,11 @@ package gosdk
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -15,12 +19,26 @@ func ValidateRegistration(name, description string, handler interface{}) error {
 	if description == "" {
 		return fmt.Errorf("description cannot be empty")
 	}
-	if handler == nil {
+	if isNilHandler(handler) {
 		return fmt.Errorf("handler cannot be nil")
 	}
 	return nil
 }
 
+// isNilHandler reports whether handler is nil, including a typed nil such as
+// a nil framework.ToolHandler stored in an interface{}
+func isNilHandler(handler interface{}) bool {
+	if handler == nil {
+		return true
+	}
+	v := reflect.ValueOf(handler)
+	switch v.Kind() {
+	case reflect.Func, reflect.Ptr, reflect.Interface, reflect.Map, reflect.Chan, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
 // ValidateResourceRegistration validates resource-specific registration parameters
 func ValidateResourceRegistration(uri, name, description string, handler interface{}) error {
 	if err := ValidateRegistration(name, description, handler); err != nil {
@@ -54,6 +72,22 @@ func ValidateGetPromptRequest(req *mcp.GetPromptRequest) error {
 	return nil
 }
 
+// ValidatePromptArguments validates declared prompt arguments
+// Each argument needs a unique, non-empty name.
+func ValidatePromptArguments(args []types.PromptArgument) error {
+	seen := make(map[string]bool, len(args))
+	for i, arg := range args {
+		if arg.Name == "" {
+			return fmt.Errorf("prompt argument %d: name cannot be empty", i)
+		}
+		if seen[arg.Name] {
+			return fmt.Errorf("duplicate prompt argument %q", arg.Name)
+		}
+		seen[arg.Name] = true
+	}
+	return nil
+}
+
 // ValidateReadResourceRequest validates a ReadResourceRequest
 func ValidateReadResourceRequest(req *mcp.ReadResourceRequest) error {
 	if req == nil {
@@ -67,3 +101,101 @@ func ValidateReadResourceRequest(req *mcp.ReadResourceRequest) error {
 	}
 	return nil
 }
+
+// UTF8Policy controls how invalid UTF-8 in text results is handled
+type UTF8Policy int
+
+const (
+	// UTF8Replace replaces invalid byte sequences with U+FFFD (default)
+	UTF8Replace UTF8Policy = iota
+	// UTF8Reject fails the request when text contains invalid UTF-8
+	UTF8Reject
+)
+
+// SanitizeTextContent applies a UTF-8 policy to text content.
+// With UTF8Replace, invalid sequences are replaced with U+FFFD and the
+// input slice is left untouched. With UTF8Reject, an error identifying
+// the offending content item is returned.
+func SanitizeTextContent(contents []types.TextContent, policy UTF8Policy) ([]types.TextContent, error) {
+	var sanitized []types.TextContent
+	for i, content := range contents {
+		if utf8.ValidString(content.Text) {
+			continue
+		}
+		text, err := sanitizeText(content.Text, policy)
+		if err != nil {
+			return nil, fmt.Errorf("content[%d]: %w", i, err)
+		}
+		// Copy on first change so the caller's slice isn't modified
+		if sanitized == nil {
+			sanitized = make([]types.TextContent, len(contents))
+			copy(sanitized, contents)
+		}
+		sanitized[i].Text = text
+	}
+	if sanitized == nil {
+		return contents, nil
+	}
+	return sanitized, nil
+}
+
+// SanitizeContent is SanitizeTextContent for rich content: the policy is
+// applied to text items and the text of embedded resources. Images and
+// binary resources are unchanged. The input slice is not modified.
+func SanitizeContent(contents []types.Content, policy UTF8Policy) ([]types.Content, error) {
+	var sanitized []types.Content
+	for i, content := range contents {
+		switch c := content.(type) {
+		case *types.TextContent:
+			content = *c
+		case *types.ResourceContent:
+			content = *c
+		}
+
+		var fixed types.Content
+		switch c := content.(type) {
+		case types.TextContent:
+			text, err := sanitizeText(c.Text, policy)
+			if err != nil {
+				return nil, fmt.Errorf("content[%d]: %w", i, err)
+			}
+			if text != c.Text {
+				c.Text = text
+				fixed = c
+			}
+		case types.ResourceContent:
+			text, err := sanitizeText(c.Text, policy)
+			if err != nil {
+				return nil, fmt.Errorf("content[%d]: %w", i, err)
+			}
+			if text != c.Text {
+				c.Text = text
+				fixed = c
+			}
+		}
+		if fixed == nil {
+			continue
+		}
+		// Copy on first change so the caller's slice isn't modified
+		if sanitized == nil {
+			sanitized = make([]types.Content, len(contents))
+			copy(sanitized, contents)
+		}
+		sanitized[i] = fixed
+	}
+	if sanitized == nil {
+		return contents, nil
+	}
+	return sanitized, nil
+}
+
+// sanitizeText applies a UTF-8 policy to a single string
+func sanitizeText(text string, policy UTF8Policy) (string, error) {
+	if utf8.ValidString(text) {
+		return text, nil
+	}
+	if policy == UTF8Reject {
+		return "", fmt.Errorf("text contains invalid UTF-8")
+	}
+	return strings.ToValidUTF8(text, string(utf8.RuneError)), nil
+}
@@ -1,7 +1,12 @@
 package gosdk
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
 )
 
 func TestValidateRegistration(t *testing.T) {
@@ -44,6 +49,14 @@ func TestValidateRegistration(t *testing.T) {
 			wantErr:     true,
 			errContains: "handler cannot be nil",
 		},
+		{
+			name:        "typed nil handler",
+			toolName:    "test_tool",
+			description: "Test description",
+			handler:     framework.ToolHandler(nil),
+			wantErr:     true,
+			errContains: "handler cannot be nil",
+		},
 	}
 
 	for _, tt := range tests {
@@ -160,3 +173,95 @@ func TestValidateResourceRegistration(t *testing.T) {
 		})
 	}
 }
+
+func TestSanitizeTextContent(t *testing.T) {
+	invalid := "bad \xff\xfe bytes"
+
+	t.Run("valid passes through", func(t *testing.T) {
+		input := []types.TextContent{{Type: "text", Text: "héllo"}}
+		got, err := SanitizeTextContent(input, UTF8Reject)
+		if err != nil {
+			t.Fatalf("SanitizeTextContent() error = %v", err)
+		}
+		if got[0].Text != "héllo" {
+			t.Errorf("Text = %q, want %q", got[0].Text, "héllo")
+		}
+	})
+
+	t.Run("replace policy", func(t *testing.T) {
+		input := []types.TextContent{{Type: "text", Text: "ok"}, {Type: "text", Text: invalid}}
+		got, err := SanitizeTextContent(input, UTF8Replace)
+		if err != nil {
+			t.Fatalf("SanitizeTextContent() error = %v", err)
+		}
+		if !utf8.ValidString(got[1].Text) {
+			t.Errorf("Text = %q, want valid UTF-8", got[1].Text)
+		}
+		if got[1].Text != "bad � bytes" {
+			t.Errorf("Text = %q, want %q", got[1].Text, "bad � bytes")
+		}
+		if input[1].Text != invalid {
+			t.Error("SanitizeTextContent() modified the input slice")
+		}
+	})
+
+	t.Run("reject policy", func(t *testing.T) {
+		input := []types.TextContent{{Type: "text", Text: "ok"}, {Type: "text", Text: invalid}}
+		_, err := SanitizeTextContent(input, UTF8Reject)
+		if err == nil {
+			t.Fatal("SanitizeTextContent() with UTF8Reject should return error")
+		}
+		if !strings.Contains(err.Error(), "content[1]") {
+			t.Errorf("error = %v, want it to identify content[1]", err)
+		}
+	})
+}
+
+func TestSanitizeContent(t *testing.T) {
+	invalid := "bad \xff\xfe bytes"
+	image := types.Image([]byte{0xff, 0xfe}, "image/png")
+	input := []types.Content{
+		types.Text("ok"),
+		image,
+		&types.ResourceContent{URI: "file:///a.txt", Text: invalid},
+	}
+
+	got, err := SanitizeContent(input, UTF8Replace)
+	if err != nil {
+		t.Fatalf("SanitizeContent() error = %v", err)
+	}
+	if resource := got[2].(types.ResourceContent); resource.Text != "bad � bytes" || resource.URI != "file:///a.txt" {
+		t.Errorf("resource = %+v, want replaced text", resource)
+	}
+	if img := got[1].(types.ImageContent); string(img.Data) != string(image.Data) {
+		t.Error("SanitizeContent() changed image data")
+	}
+	if input[2].(*types.ResourceContent).Text != invalid {
+		t.Error("SanitizeContent() modified the input")
+	}
+
+	if _, err := SanitizeContent([]types.Content{types.Text(invalid)}, UTF8Reject); err == nil {
+		t.Error("SanitizeContent() with UTF8Reject error = nil, want error")
+	}
+}
+
+func TestValidatePromptArguments(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []types.PromptArgument
+		wantErr bool
+	}{
+		{name: "none", args: nil},
+		{name: "valid", args: []types.PromptArgument{{Name: "a", Required: true}, {Name: "b"}}},
+		{name: "empty name", args: []types.PromptArgument{{Name: ""}}, wantErr: true},
+		{name: "duplicate", args: []types.PromptArgument{{Name: "a"}, {Name: "a"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePromptArguments(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePromptArguments() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
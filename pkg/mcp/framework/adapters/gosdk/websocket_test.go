@@ -0,0 +1,152 @@
+package gosdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+	"github.com/gorilla/websocket"
+)
+
+// runWebSocket runs adapter on an ephemeral-port WebSocket transport until
+// the test ends and returns the transport once it is listening
+func runWebSocket(t *testing.T, adapter *GoSDKAdapter) *framework.WSTransport {
+	t.Helper()
+	transport := framework.NewWSTransport("/ws", 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- adapter.Run(ctx, transport) }()
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case <-runErr:
+		case <-time.After(2 * time.Second):
+			t.Error("Run() did not return after cancellation")
+		}
+	})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for transport.BoundPort() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("transport never started")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return transport
+}
+
+// wsClient is a raw JSON-RPC client over a WebSocket connection
+type wsClient struct {
+	t    *testing.T
+	conn *websocket.Conn
+}
+
+// dialWebSocket connects a client to transport and initializes an MCP
+// session
+func dialWebSocket(t *testing.T, transport *framework.WSTransport) *wsClient {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://127.0.0.1:%d/ws", transport.BoundPort()), nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	c := &wsClient{t: t, conn: conn}
+	c.call(1, "initialize", map[string]interface{}{
+		"protocolVersion": "2025-06-18",
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]interface{}{"name": "ws-test", "version": "1.0.0"},
+	})
+	c.send(map[string]interface{}{"jsonrpc": "2.0", "method": "notifications/initialized"})
+	return c
+}
+
+// send writes a JSON-RPC message
+func (c *wsClient) send(msg map[string]interface{}) {
+	c.t.Helper()
+	data, _ := json.Marshal(msg)
+	if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		c.t.Fatalf("WriteMessage() error = %v", err)
+	}
+}
+
+// call sends a request and returns its response, skipping notifications
+func (c *wsClient) call(id int, method string, params interface{}) map[string]interface{} {
+	c.t.Helper()
+	c.send(map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+	_ = c.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			c.t.Fatalf("ReadMessage() error = %v", err)
+		}
+		var resp map[string]interface{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			c.t.Fatalf("response is not JSON: %s", data)
+		}
+		if resp["id"] == nil {
+			continue
+		}
+		if resp["id"] != float64(id) {
+			c.t.Fatalf("%s: got response to request %v, want %d", method, resp["id"], id)
+		}
+		if resp["error"] != nil {
+			c.t.Fatalf("%s error = %v", method, resp["error"])
+		}
+		return resp
+	}
+}
+
+func TestGoSDKAdapter_RunWebSocket(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+	if err := adapter.RegisterTool("echo", "Echo arguments", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	client := dialWebSocket(t, runWebSocket(t, adapter))
+
+	resp := client.call(2, "tools/call", map[string]interface{}{"name": "echo", "arguments": map[string]interface{}{"x": 1}})
+	data, _ := json.Marshal(resp["result"])
+	if !strings.Contains(string(data), `{\"x\":1}`) {
+		t.Errorf("tools/call result = %s, want echoed arguments", data)
+	}
+}
+
+func TestGoSDKAdapter_RunWebSocket_SessionPerClient(t *testing.T) {
+	adapter := NewGoSDKAdapter("test", "1.0.0")
+	if err := adapter.RegisterTool("echo", "Echo arguments", types.ToolSchema{Type: "object"}, echoHandler); err != nil {
+		t.Fatalf("RegisterTool() error = %v", err)
+	}
+	transport := runWebSocket(t, adapter)
+
+	// Both clients use the same request IDs; each must get only its own
+	// responses
+	clientA := dialWebSocket(t, transport)
+	clientB := dialWebSocket(t, transport)
+	for i, client := range []*wsClient{clientA, clientB} {
+		resp := client.call(2, "tools/call", map[string]interface{}{"name": "echo", "arguments": map[string]interface{}{"client": i}})
+		data, _ := json.Marshal(resp["result"])
+		if want := fmt.Sprintf(`{\"client\":%d}`, i); !strings.Contains(string(data), want) {
+			t.Errorf("client %d tools/call result = %s, want %s", i, data, want)
+		}
+	}
+
+	// No other responses were delivered to either client
+	for i, client := range []*wsClient{clientA, clientB} {
+		_ = client.conn.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+		for {
+			_, data, err := client.conn.ReadMessage()
+			if err != nil {
+				break
+			}
+			if strings.Contains(string(data), `"id"`) {
+				t.Errorf("client %d received unexpected response %s", i, data)
+			}
+		}
+	}
+}
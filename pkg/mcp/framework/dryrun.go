@@ -0,0 +1,58 @@
+package framework
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// DryRunArg is the tool argument clients set to true to request a dry run.
+// Adapters strip it from the arguments and expose it via IsDryRun.
+const DryRunArg = "_dry_run"
+
+// dryRunKey is the context key for the dry-run flag
+type dryRunKey struct{}
+
+// WithDryRun returns a context marked (or unmarked) as a dry run
+func WithDryRun(ctx context.Context, dryRun bool) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, dryRun)
+}
+
+// IsDryRun reports whether the caller requested a dry run.
+// Handlers should skip side effects and describe what they would have done.
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// ExtractDryRun removes DryRunArg from raw tool arguments, returning the
+// remaining arguments and whether a dry run was requested. Arguments without
+// the flag are returned unchanged.
+func ExtractDryRun(args json.RawMessage) (json.RawMessage, bool, error) {
+	trimmed := bytes.TrimSpace(args)
+	if len(trimmed) == 0 || trimmed[0] != '{' || !bytes.Contains(trimmed, []byte(DryRunArg)) {
+		return args, false, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return nil, false, fmt.Errorf("invalid arguments: %w", err)
+	}
+	flag, ok := fields[DryRunArg]
+	if !ok {
+		return args, false, nil
+	}
+
+	var dryRun bool
+	if err := json.Unmarshal(flag, &dryRun); err != nil {
+		return nil, false, fmt.Errorf("%s must be a boolean", DryRunArg)
+	}
+	delete(fields, DryRunArg)
+
+	stripped, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false, err
+	}
+	return stripped, dryRun, nil
+}
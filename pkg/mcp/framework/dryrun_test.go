@@ -0,0 +1,55 @@
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestIsDryRun(t *testing.T) {
+	ctx := context.Background()
+	if IsDryRun(ctx) {
+		t.Error("IsDryRun(background) = true, want false")
+	}
+	if !IsDryRun(WithDryRun(ctx, true)) {
+		t.Error("IsDryRun(WithDryRun(true)) = false, want true")
+	}
+	if IsDryRun(WithDryRun(WithDryRun(ctx, true), false)) {
+		t.Error("IsDryRun(WithDryRun(false)) = true, want false")
+	}
+}
+
+func TestExtractDryRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       string
+		wantArgs   string
+		wantDryRun bool
+		wantErr    bool
+	}{
+		{name: "empty", args: ``, wantArgs: ``},
+		{name: "no flag", args: `{"a":1}`, wantArgs: `{"a":1}`},
+		{name: "dry run", args: `{"a":1,"_dry_run":true}`, wantArgs: `{"a":1}`, wantDryRun: true},
+		{name: "explicit false", args: `{"_dry_run":false}`, wantArgs: `{}`},
+		{name: "non-boolean flag", args: `{"_dry_run":"yes"}`, wantErr: true},
+		{name: "flag name in value only", args: `{"note":"_dry_run"}`, wantArgs: `{"note":"_dry_run"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, dryRun, err := ExtractDryRun(json.RawMessage(tt.args))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExtractDryRun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if string(args) != tt.wantArgs {
+				t.Errorf("ExtractDryRun() args = %s, want %s", args, tt.wantArgs)
+			}
+			if dryRun != tt.wantDryRun {
+				t.Errorf("ExtractDryRun() dryRun = %v, want %v", dryRun, tt.wantDryRun)
+			}
+		})
+	}
+}
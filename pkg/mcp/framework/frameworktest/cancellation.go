@@ -0,0 +1,103 @@
+// Package frameworktest provides test helpers for tool handlers built on
+// the framework package. It imports the testing package, so it is kept out
+// of framework itself to keep testing out of production binaries.
+package frameworktest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// CancellationTimeout is how long AssertRespectsCancellation gives a handler
+// to return once its context is canceled
+const CancellationTimeout = 100 * time.Millisecond
+
+// AssertRespectsCancellation calls handler with an already-canceled context
+// and fails the test unless it returns a context error (context.Canceled or
+// context.DeadlineExceeded, possibly wrapped) within CancellationTimeout.
+// Handlers that do slow work should check ctx.Done() so canceled calls stop
+// promptly.
+//
+// The handler is called with the schema's required properties set to their
+// default, first example, or zero value.
+//
+// Example:
+//
+//	func TestSearchRespectsCancellation(t *testing.T) {
+//		frameworktest.AssertRespectsCancellation(t, searchHandler, searchSchema)
+//	}
+func AssertRespectsCancellation(t testing.TB, handler framework.ToolHandler, schema types.ToolSchema) {
+	t.Helper()
+
+	args, err := sampleArgs(schema)
+	if err != nil {
+		t.Fatalf("failed to build arguments from schema: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := handler(ctx, args)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("handler with a canceled context returned error %v, want a context error", err)
+		}
+	case <-time.After(CancellationTimeout):
+		t.Errorf("handler did not return within %v of its context being canceled", CancellationTimeout)
+	}
+}
+
+// sampleArgs returns arguments setting each required property of schema to
+// its default, its first example, or the zero value of its type
+func sampleArgs(schema types.ToolSchema) (json.RawMessage, error) {
+	args := make(map[string]interface{}, len(schema.Required))
+	for _, name := range schema.Required {
+		args[name] = sampleValue(schema.Properties[name])
+	}
+	return json.Marshal(args)
+}
+
+// sampleValue returns a valid-looking value for a property schema given as
+// a map or a types.PropertySchema
+func sampleValue(property interface{}) interface{} {
+	if p, ok := property.(types.PropertySchema); ok {
+		property = p.Map()
+	}
+	m, ok := property.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if value, ok := m["default"]; ok {
+		return value
+	}
+	if examples, ok := m["examples"].([]interface{}); ok && len(examples) > 0 {
+		return examples[0]
+	}
+	switch m["type"] {
+	case "string":
+		return ""
+	case "number", "integer":
+		return 0
+	case "boolean":
+		return false
+	case "array":
+		return []interface{}{}
+	case "object":
+		return map[string]interface{}{}
+	default:
+		return nil
+	}
+}
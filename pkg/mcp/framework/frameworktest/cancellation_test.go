@@ -0,0 +1,85 @@
+package frameworktest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/framework"
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// recordingT captures test failures so assertions can be tested
+type recordingT struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+}
+
+func TestAssertRespectsCancellation(t *testing.T) {
+	schema := types.ToolSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"query": types.StringProperty("Search query"),
+			"limit": map[string]interface{}{"type": "integer", "default": 10},
+		},
+		Required: []string{"query", "limit"},
+	}
+
+	var gotArgs map[string]interface{}
+	compliant := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		if err := json.Unmarshal(args, &gotArgs); err != nil {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("search stopped: %w", ctx.Err())
+		default:
+			return []types.TextContent{{Type: "text", Text: "results"}}, nil
+		}
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	blocking := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		<-release // ignores ctx
+		return nil, nil
+	}
+	ignoring := func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error) {
+		return []types.TextContent{{Type: "text", Text: "done anyway"}}, nil
+	}
+
+	tests := []struct {
+		name    string
+		handler framework.ToolHandler
+		wantOK  bool
+	}{
+		{name: "compliant", handler: compliant, wantOK: true},
+		{name: "blocks", handler: blocking, wantOK: false},
+		{name: "succeeds despite cancellation", handler: ignoring, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recordingT{}
+			AssertRespectsCancellation(rec, tt.handler, schema)
+			if ok := len(rec.failures) == 0; ok != tt.wantOK {
+				t.Errorf("assertion passed = %v, want %v (failures: %v)", ok, tt.wantOK, rec.failures)
+			}
+		})
+	}
+
+	want := map[string]interface{}{"query": "", "limit": float64(10)}
+	if fmt.Sprint(gotArgs) != fmt.Sprint(want) {
+		t.Errorf("handler got args %v, want %v", gotArgs, want)
+	}
+}
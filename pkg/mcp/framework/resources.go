@@ -0,0 +1,87 @@
+package framework
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"text/template"
+	"unicode/utf8"
+)
+
+// envRefPattern matches ${VAR} references
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ExpandEnvResource wraps a resource handler so ${VAR} references in its
+// content are replaced with environment variable values.
+//
+// Only variables named in allowed are expanded; references to any other
+// variable are left as-is, so secrets in the environment cannot leak through
+// a resource by accident. Unset allowlisted variables expand to "". Content
+// that is not valid UTF-8 (binary resources) is returned unchanged.
+//
+// Example:
+//
+//	server.RegisterResource("config://app", "App config", "Configuration", "application/yaml",
+//		framework.ExpandEnvResource(configHandler, "APP_ENV", "APP_REGION"))
+func ExpandEnvResource(handler ResourceHandler, allowed ...string) ResourceHandler {
+	allowlist := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		allowlist[name] = true
+	}
+
+	return func(ctx context.Context, uri string) ([]byte, string, error) {
+		data, mimeType, err := handler(ctx, uri)
+		if err != nil || !utf8.Valid(data) {
+			return data, mimeType, err
+		}
+		expanded := envRefPattern.ReplaceAllFunc(data, func(ref []byte) []byte {
+			name := string(ref[2 : len(ref)-1])
+			if !allowlist[name] {
+				return ref
+			}
+			return []byte(os.Getenv(name))
+		})
+		return expanded, mimeType, nil
+	}
+}
+
+// TemplateResource returns a resource handler that renders a text/template
+// with data computed per request. The result is served as "text/plain".
+//
+// tmpl is parsed once, up front; if it is invalid, every request fails with
+// the parse error. Referencing a missing map key is an error rather than
+// rendering "<no value>".
+//
+// Example:
+//
+//	handler := framework.TemplateResource(
+//		"Server: {{.Name}}\nTools: {{len .Tools}}\n",
+//		func(ctx context.Context, uri string) (interface{}, error) {
+//			return map[string]interface{}{"Name": server.GetName(), "Tools": server.ListTools()}, nil
+//		})
+//	server.RegisterResource("example://info", "Server Information", "Information about the server", "text/plain", handler)
+func TemplateResource(tmpl string, data func(ctx context.Context, uri string) (interface{}, error)) ResourceHandler {
+	parsed, parseErr := template.New("resource").Option("missingkey=error").Parse(tmpl)
+
+	return func(ctx context.Context, uri string) ([]byte, string, error) {
+		if parseErr != nil {
+			return nil, "", fmt.Errorf("invalid resource template: %w", parseErr)
+		}
+
+		var value interface{}
+		if data != nil {
+			var err error
+			if value, err = data(ctx, uri); err != nil {
+				return nil, "", err
+			}
+		}
+
+		var buf bytes.Buffer
+		if err := parsed.Execute(&buf, value); err != nil {
+			return nil, "", fmt.Errorf("failed to render resource template: %w", err)
+		}
+		return buf.Bytes(), "text/plain", nil
+	}
+}
@@ -0,0 +1,126 @@
+package framework
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func staticResource(content string) ResourceHandler {
+	return func(ctx context.Context, uri string) ([]byte, string, error) {
+		return []byte(content), "text/plain", nil
+	}
+}
+
+func TestExpandEnvResource(t *testing.T) {
+	t.Setenv("APP_ENV", "production")
+	t.Setenv("APP_SECRET", "s3cr3t")
+	t.Setenv("APP_EMPTY", "")
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "allowlisted", content: "env: ${APP_ENV}", want: "env: production"},
+		{name: "not allowlisted", content: "secret: ${APP_SECRET}", want: "secret: ${APP_SECRET}"},
+		{name: "mixed", content: "${APP_ENV}/${APP_SECRET}", want: "production/${APP_SECRET}"},
+		{name: "allowlisted but empty", content: "[${APP_EMPTY}]", want: "[]"},
+		{name: "bare dollar form untouched", content: "$APP_ENV", want: "$APP_ENV"},
+		{name: "no references", content: "plain", want: "plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := ExpandEnvResource(staticResource(tt.content), "APP_ENV", "APP_EMPTY")
+			data, mimeType, err := handler(context.Background(), "config://app")
+			if err != nil {
+				t.Fatalf("handler() error = %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("handler() = %q, want %q", data, tt.want)
+			}
+			if mimeType != "text/plain" {
+				t.Errorf("mimeType = %q, want text/plain", mimeType)
+			}
+		})
+	}
+}
+
+func TestExpandEnvResource_PassesThrough(t *testing.T) {
+	t.Setenv("APP_ENV", "production")
+
+	binary := []byte{0xff, '$', '{', 'A', 'P', 'P', '_', 'E', 'N', 'V', '}'}
+	handler := ExpandEnvResource(func(ctx context.Context, uri string) ([]byte, string, error) {
+		return binary, "application/octet-stream", nil
+	}, "APP_ENV")
+	data, _, err := handler(context.Background(), "bin://x")
+	if err != nil || string(data) != string(binary) {
+		t.Errorf("binary content = %q, %v; want unchanged", data, err)
+	}
+
+	wantErr := errors.New("boom")
+	handler = ExpandEnvResource(func(ctx context.Context, uri string) ([]byte, string, error) {
+		return nil, "", wantErr
+	}, "APP_ENV")
+	if _, _, err := handler(context.Background(), "x://y"); !errors.Is(err, wantErr) {
+		t.Errorf("error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestTemplateResource(t *testing.T) {
+	handler := TemplateResource("Server: {{.Name}} ({{.URI}})\nTools: {{len .Tools}}\n",
+		func(ctx context.Context, uri string) (interface{}, error) {
+			return map[string]interface{}{
+				"Name":  "example",
+				"URI":   uri,
+				"Tools": []string{"echo", "add"},
+			}, nil
+		})
+
+	data, mimeType, err := handler(context.Background(), "example://info")
+	if err != nil {
+		t.Fatalf("handler() error = %v", err)
+	}
+	if want := "Server: example (example://info)\nTools: 2\n"; string(data) != want {
+		t.Errorf("handler() = %q, want %q", data, want)
+	}
+	if mimeType != "text/plain" {
+		t.Errorf("mimeType = %q, want text/plain", mimeType)
+	}
+}
+
+func TestTemplateResource_Errors(t *testing.T) {
+	ctx := context.Background()
+	noData := func(ctx context.Context, uri string) (interface{}, error) {
+		return map[string]interface{}{}, nil
+	}
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		data    func(ctx context.Context, uri string) (interface{}, error)
+		wantErr string
+	}{
+		{name: "parse error", tmpl: "{{.Name", data: noData, wantErr: "invalid resource template"},
+		{name: "missing key", tmpl: "{{.Name}}", data: noData, wantErr: "failed to render"},
+		{
+			name: "data error",
+			tmpl: "{{.}}",
+			data: func(ctx context.Context, uri string) (interface{}, error) {
+				return nil, errors.New("backend down")
+			},
+			wantErr: "backend down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := TemplateResource(tt.tmpl, tt.data)(ctx, "example://info")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("handler() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -23,8 +23,9 @@ import (
 
 // MCPServer abstracts MCP server functionality
 type MCPServer interface {
-	// RegisterTool registers a tool handler
-	RegisterTool(name, description string, schema types.ToolSchema, handler ToolHandler) error
+	// RegisterTool registers a tool handler. opts set optional settings such
+	// as ToolAnnotations, ToolOutputSchema and ToolTimeout.
+	RegisterTool(name, description string, schema types.ToolSchema, handler ToolHandler, opts ...ToolOption) error
 
 	// RegisterPrompt registers a prompt template
 	RegisterPrompt(name, description string, handler PromptHandler) error
@@ -42,7 +43,7 @@ type MCPServer interface {
 	// CallTool executes a tool directly (for CLI mode)
 	CallTool(ctx context.Context, name string, args json.RawMessage) ([]types.TextContent, error)
 
-	// ListTools returns all registered tools
+	// ListTools returns all registered tools, sorted by name
 	ListTools() []types.ToolInfo
 }
 
@@ -52,11 +53,19 @@ type JsonRawMessage = json.RawMessage
 // ToolHandler handles tool execution
 type ToolHandler func(ctx context.Context, args json.RawMessage) ([]types.TextContent, error)
 
+// RichToolHandler handles execution of tools whose results may include
+// images or embedded resources as well as text
+type RichToolHandler func(ctx context.Context, args json.RawMessage) ([]types.Content, error)
+
 // PromptHandler handles prompt requests
 type PromptHandler func(ctx context.Context, args map[string]interface{}) (string, error)
 
 // ResourceHandler handles resource requests
 type ResourceHandler func(ctx context.Context, uri string) ([]byte, string, error)
 
+// ResourceTemplateHandler handles requests for resources matching a URI
+// template. vars holds the value of each template variable in uri.
+type ResourceTemplateHandler func(ctx context.Context, uri string, vars map[string]string) ([]byte, string, error)
+
 // Transport is defined in transport.go
 // Imported here for backward compatibility
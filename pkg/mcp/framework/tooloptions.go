@@ -0,0 +1,62 @@
+package framework
+
+import (
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// ToolOption configures optional settings of a tool passed to
+// MCPServer.RegisterTool
+type ToolOption func(*ToolConfig)
+
+// ToolConfig holds the optional settings of a tool, as set by ToolOptions
+type ToolConfig struct {
+	// Annotations describe the tool's side effects; nil if not set
+	Annotations *types.ToolAnnotations
+
+	// OutputSchema describes the JSON document the tool returns; nil if
+	// the tool's output is unstructured
+	OutputSchema *types.ToolSchema
+
+	// Timeout bounds each call. 0 uses the server's default; negative
+	// means the tool never times out.
+	Timeout time.Duration
+}
+
+// NewToolConfig applies opts to an empty ToolConfig
+func NewToolConfig(opts ...ToolOption) ToolConfig {
+	var cfg ToolConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// ToolAnnotations sets annotations describing the tool's side effects. They
+// are advertised to clients in tools/list and reported by ListTools.
+func ToolAnnotations(annotations types.ToolAnnotations) ToolOption {
+	return func(c *ToolConfig) {
+		c.Annotations = &annotations
+	}
+}
+
+// ToolOutputSchema declares that the tool's result is a JSON document
+// described by schema. Servers advertise the schema to clients and report
+// results that don't match it as tool errors.
+func ToolOutputSchema(schema types.ToolSchema) ToolOption {
+	return func(c *ToolConfig) {
+		c.OutputSchema = &schema
+	}
+}
+
+// ToolTimeout cancels the tool's calls after d, overriding the server's
+// default timeout. A d <= 0 lets the tool run until the client cancels.
+func ToolTimeout(d time.Duration) ToolOption {
+	return func(c *ToolConfig) {
+		if d <= 0 {
+			d = -1
+		}
+		c.Timeout = d
+	}
+}
@@ -0,0 +1,37 @@
+package framework
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+func TestNewToolConfig(t *testing.T) {
+	if cfg := NewToolConfig(); cfg.Annotations != nil || cfg.OutputSchema != nil || cfg.Timeout != 0 {
+		t.Errorf("NewToolConfig() = %+v, want zero config", cfg)
+	}
+
+	cfg := NewToolConfig(
+		ToolAnnotations(types.ToolAnnotations{ReadOnlyHint: true}),
+		ToolOutputSchema(types.ToolSchema{Type: "object"}),
+		ToolTimeout(time.Second),
+	)
+	if cfg.Annotations == nil || !cfg.Annotations.ReadOnlyHint {
+		t.Errorf("Annotations = %+v, want ReadOnlyHint", cfg.Annotations)
+	}
+	if cfg.OutputSchema == nil || cfg.OutputSchema.Type != "object" {
+		t.Errorf("OutputSchema = %+v, want object schema", cfg.OutputSchema)
+	}
+	if cfg.Timeout != time.Second {
+		t.Errorf("Timeout = %v, want 1s", cfg.Timeout)
+	}
+}
+
+func TestToolTimeout_Disable(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		if cfg := NewToolConfig(ToolTimeout(d)); cfg.Timeout >= 0 {
+			t.Errorf("ToolTimeout(%v) Timeout = %v, want negative (no timeout)", d, cfg.Timeout)
+		}
+	}
+}
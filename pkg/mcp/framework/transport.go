@@ -2,9 +2,15 @@ package framework
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"sync"
+	"time"
 )
 
 // Transport abstracts transport mechanism for MCP servers
@@ -40,11 +46,50 @@ func (t *StdioTransport) Type() string {
 	return "stdio"
 }
 
+// DefaultSSEHeartbeatInterval is how often SSETransport pings idle
+// connections unless changed with SetHeartbeatInterval
+const DefaultSSEHeartbeatInterval = 15 * time.Second
+
+// SSEWriteTimeout bounds each write to an SSE client, so a slow or stuck
+// client cannot hold up writes to the others or Stop
+const SSEWriteTimeout = 10 * time.Second
+
+// sseClosingEvent is sent to every client when Stop begins draining
+const sseClosingEvent = `{"type":"connection","status":"closing"}`
+
+// SSESessionHeader is the response header carrying the session ID assigned
+// to an SSE connection
+const SSESessionHeader = "Mcp-Session-Id"
+
+// sseIncomingBuffer is how many posted messages are queued for
+// ReadSessionMessage
+const sseIncomingBuffer = 64
+
+// sseMaxMessageSize bounds the body of a message posted by a client
+const sseMaxMessageSize = 4 << 20
+
+// SSEMessage is a message posted by an SSE client
+type SSEMessage struct {
+	// SessionID identifies the connection the message was posted for
+	SessionID string
+
+	// Data is the message payload
+	Data []byte
+}
+
 // SSETransport represents Server-Sent Events transport
 // This transport is used for HTTP-based MCP servers
 //
 // SSE transport requires an HTTP server to be set up separately.
 // The transport manages the SSE connection lifecycle and message handling.
+//
+// Each connection is assigned a session ID, sent to the client in the
+// SSESessionHeader response header and in the initial "connection" event.
+// Use WriteToConnection to reply to one client; WriteMessage broadcasts.
+//
+// Clients send messages by POSTing them to Endpoint with their session ID
+// in the SSESessionHeader request header. ReadSessionMessage returns them,
+// in arrival order, together with the session ID.
 type SSETransport struct {
 	// Server is the HTTP server that will handle SSE connections
 	Server *http.Server
@@ -52,8 +97,9 @@ type SSETransport struct {
 	// Endpoint is the path where SSE connections will be accepted
 	Endpoint string
 
-	// Port is the port number for the HTTP server (default: 8080)
-	Port int
+	// ListenPort is the port number for the HTTP server. 0 lets the OS
+	// choose a free port; BoundPort reports it once started.
+	ListenPort int
 
 	// mu protects the server state
 	mu sync.RWMutex
@@ -61,85 +107,412 @@ type SSETransport struct {
 	// started indicates if the transport has been started
 	started bool
 
-	// connections tracks active SSE connections
-	connections map[*http.Request]http.ResponseWriter
+	// draining is set while Stop waits for clients to disconnect
+	draining bool
+
+	// drained is closed when the last connection goes away while draining
+	drained chan struct{}
+
+	// connections tracks active SSE connections by session ID
+	connections map[string]*sseConn
+
+	// listener is the bound socket the server is serving on (nil until Start)
+	listener net.Listener
+
+	// middlewares wrap the SSE handler (applied in registration order)
+	middlewares []func(http.Handler) http.Handler
+
+	// heartbeatInterval is how often connections are pinged (0 = never)
+	heartbeatInterval time.Duration
+
+	// heartbeatStop is closed by Stop to end the heartbeat goroutine
+	heartbeatStop chan struct{}
+
+	// ownsServer is set when Start created Server, so the next Start
+	// replaces it (a stopped http.Server cannot serve again)
+	ownsServer bool
+
+	// serveDone is closed when the serve goroutine returns
+	serveDone chan struct{}
+
+	// stopped is closed when the Stop in progress finishes, so concurrent
+	// Stops can wait for it
+	stopped chan struct{}
+
+	// incoming queues messages posted by clients for ReadSessionMessage
+	incoming chan SSEMessage
+
+	// done is closed by Stop to unblock readers
+	done chan struct{}
 }
 
-// NewSSETransport creates a new SSE transport with the given endpoint and port
+// NewSSETransport creates a new SSE transport with the given endpoint and
+// port. An empty endpoint defaults to "/sse"; port 0 lets the OS choose one.
 func NewSSETransport(endpoint string, port int) *SSETransport {
 	if endpoint == "" {
 		endpoint = "/sse"
 	}
-	if port == 0 {
-		port = 8080
-	}
 
 	return &SSETransport{
-		Endpoint:    endpoint,
-		Port:        port,
-		connections: make(map[*http.Request]http.ResponseWriter),
+		Endpoint:          endpoint,
+		ListenPort:        port,
+		connections:       make(map[string]*sseConn),
+		heartbeatInterval: DefaultSSEHeartbeatInterval,
+	}
+}
+
+// SetHeartbeatInterval sets how often an SSE comment (": ping") is sent to
+// every connection, so reverse proxies and load balancers do not close them
+// as idle. Zero disables the heartbeat. Takes effect at the next Start.
+func (t *SSETransport) SetHeartbeatInterval(interval time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.heartbeatInterval = interval
+}
+
+// sseConn is an active SSE client connection
+type sseConn struct {
+	w       http.ResponseWriter
+	writeMu sync.Mutex         // serializes writes to w
+	closed  bool               // set under writeMu once the handler returns
+	ctx     context.Context    // done when the client disconnects
+	cancel  context.CancelFunc // ends the connection's handler
+}
+
+// write sends an SSE event carrying data and flushes it
+func (c *sseConn) write(data []byte) error {
+	return c.send("data: " + string(data) + "\n\n")
+}
+
+// ping sends an SSE comment line, which clients ignore, and flushes it
+func (c *sseConn) ping() error {
+	return c.send(": ping\n\n")
+}
+
+// send writes event to the connection and flushes it, failing if the client
+// does not take it within SSEWriteTimeout
+func (c *sseConn) send(event string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closed {
+		return fmt.Errorf("SSE connection closed")
+	}
+
+	rc := http.NewResponseController(c.w)
+	if err := rc.SetWriteDeadline(time.Now().Add(SSEWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if _, err := c.w.Write([]byte(event)); err != nil {
+		return err
 	}
+	if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
 }
 
-// Start initializes the SSE transport and starts the HTTP server
+// close ends the connection's handler and waits for a write in progress,
+// after which the ResponseWriter is no longer used
+func (c *sseConn) close() {
+	c.cancel()
+	c.writeMu.Lock()
+	c.closed = true
+	c.writeMu.Unlock()
+}
+
+// newSessionID returns a random 128-bit hex session ID
+func newSessionID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// Use adds HTTP middleware around the SSE endpoint handler.
+// Middleware is applied in registration order (first registered runs first)
+// and must be added before Start. It only applies when the transport creates
+// its own HTTP server (i.e. Server is nil at Start).
+//
+// Example:
+//
+//	transport := framework.NewSSETransport("/sse", 8080)
+//	transport.Use(security.RateLimitMiddleware(rl, nil))
+func (t *SSETransport) Use(middlewares ...func(http.Handler) http.Handler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.middlewares = append(t.middlewares, middlewares...)
+}
+
+// Start initializes the SSE transport and starts the HTTP server.
+// It fails if the transport is already started or still stopping. Start and
+// Stop may be called concurrently; a transport stopped with Stop can be
+// started again unless it was given its own Server.
 func (t *SSETransport) Start(ctx context.Context) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	if t.draining {
+		return fmt.Errorf("SSE transport is stopping")
+	}
 	if t.started {
 		return fmt.Errorf("SSE transport already started")
 	}
 
 	// Create HTTP server if not already set
-	if t.Server == nil {
+	if t.Server == nil || t.ownsServer {
+		var handler http.Handler = http.HandlerFunc(t.handleSSE)
+		for i := len(t.middlewares) - 1; i >= 0; i-- {
+			handler = t.middlewares[i](handler)
+		}
+
 		mux := http.NewServeMux()
-		mux.HandleFunc(t.Endpoint, t.handleSSE)
+		mux.Handle(t.Endpoint, handler)
 
 		t.Server = &http.Server{
-			Addr:    fmt.Sprintf(":%d", t.Port),
+			Addr:    fmt.Sprintf(":%d", t.ListenPort),
 			Handler: mux,
 		}
+		t.ownsServer = true
 	}
 
-	// Start server in a goroutine
+	// Bind synchronously so errors such as "address already in use" are
+	// reported to the caller instead of being lost in the serve goroutine
+	addr := t.Server.Addr
+	if addr == "" {
+		addr = ":http" // Same default as http.Server.ListenAndServe
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	t.listener = listener
+	t.incoming = make(chan SSEMessage, sseIncomingBuffer)
+	t.done = make(chan struct{})
+
+	// Serve in a goroutine; it uses its own copy of the server so it never
+	// reads t's fields without the lock
+	server := t.Server
+	serveDone := make(chan struct{})
+	t.serveDone = serveDone
 	go func() {
-		if err := t.Server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		defer close(serveDone)
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
 			// Log error (would need logger integration)
 			_ = err
 		}
 	}()
 
+	if t.heartbeatInterval > 0 {
+		t.heartbeatStop = make(chan struct{})
+		go t.heartbeat(t.heartbeatInterval, t.heartbeatStop)
+	}
+
 	t.started = true
 	return nil
 }
 
-// Stop shuts down the SSE transport and closes all connections
-func (t *SSETransport) Stop(ctx context.Context) error {
+// heartbeat pings all connections every interval until stop is closed
+func (t *SSETransport) heartbeat(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		// Pings are written outside the lock, so a slow client delays only
+		// its own ping
+		t.eachConnection(func(sessionID string, conn *sseConn) {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if err := conn.ping(); err != nil {
+				// Dead connection: prune it now and free its handler
+				t.removeConnection(sessionID, conn)
+			}
+		})
+	}
+}
+
+// eachConnection calls fn concurrently for every live connection, outside
+// the lock, and waits for the calls to return
+func (t *SSETransport) eachConnection(fn func(sessionID string, conn *sseConn)) {
+	t.mu.RLock()
+	conns := make(map[string]*sseConn, len(t.connections))
+	for sessionID, conn := range t.connections {
+		if conn.ctx.Err() == nil {
+			conns[sessionID] = conn
+		}
+	}
+	t.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for sessionID, conn := range conns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(sessionID, conn)
+		}()
+	}
+	wg.Wait()
+}
+
+// removeConnection unregisters conn, if it is still registered under
+// sessionID, and ends its handler
+func (t *SSETransport) removeConnection(sessionID string, conn *sseConn) {
 	t.mu.Lock()
-	defer t.mu.Unlock()
+	if t.connections[sessionID] == conn {
+		delete(t.connections, sessionID)
+	}
+	t.mu.Unlock()
+	conn.cancel()
+}
+
+// Addr returns the address the transport is listening on, or nil if it is
+// not started. Unlike Server.Addr, this reports the concrete port chosen by
+// the OS when ListenPort is 0.
+func (t *SSETransport) Addr() net.Addr {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.listener == nil {
+		return nil
+	}
+	return t.listener.Addr()
+}
 
+// BoundPort returns the TCP port the transport is listening on, or 0 if it
+// is not started
+func (t *SSETransport) BoundPort() int {
+	if tcpAddr, ok := t.Addr().(*net.TCPAddr); ok {
+		return tcpAddr.Port
+	}
+	return 0
+}
+
+// Port returns the TCP port the transport is listening on, or 0 if it is
+// not started.
+//
+// Deprecated: Use BoundPort.
+func (t *SSETransport) Port() int {
+	return t.BoundPort()
+}
+
+// Stop shuts down the SSE transport gracefully.
+//
+// Each client is sent a {"type":"connection","status":"closing"} event, and
+// Stop waits for the clients to disconnect until ctx is done. Connections
+// still open at that point are closed forcibly and an error wrapping the
+// context error is returned. New connections are refused while draining.
+//
+// A Stop called while another is in progress waits for it to finish (or
+// for its own ctx to be done), so the transport is stopped once any Stop
+// returns nil.
+func (t *SSETransport) Stop(ctx context.Context) error {
+	t.mu.Lock()
+	if t.draining {
+		stopped := t.stopped
+		t.mu.Unlock()
+		select {
+		case <-stopped:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for SSE transport to stop: %w", ctx.Err())
+		}
+	}
 	if !t.started {
+		t.mu.Unlock()
 		return nil
 	}
+	t.draining = true
+	t.stopped = make(chan struct{})
+	close(t.done)
+
+	// End the heartbeat
+	if t.heartbeatStop != nil {
+		close(t.heartbeatStop)
+		t.heartbeatStop = nil
+	}
+	var drained chan struct{}
+	if len(t.connections) > 0 {
+		drained = make(chan struct{})
+		t.drained = drained
+	}
+	t.mu.Unlock()
 
-	// Close all active connections
-	for req, w := range t.connections {
-		if flusher, ok := w.(http.Flusher); ok {
-			flusher.Flush()
+	// Tell clients to disconnect. The writes are not waited for: a client
+	// that does not read holds up only its own write, which fails when the
+	// connection is closed below.
+	go t.eachConnection(func(sessionID string, conn *sseConn) {
+		if err := conn.write([]byte(sseClosingEvent)); err != nil {
+			t.removeConnection(sessionID, conn)
 		}
-		// Connection will be closed when request context is cancelled
-		_ = req
+	})
+
+	// Wait for the handlers to unregister (without holding the lock they
+	// need to do so)
+	var drainErr error
+	if drained != nil {
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			drainErr = ctx.Err()
+		}
+	}
+
+	// Force-close whatever is left
+	t.mu.Lock()
+	for _, conn := range t.connections {
+		conn.cancel()
 	}
-	t.connections = make(map[*http.Request]http.ResponseWriter)
+	t.connections = make(map[string]*sseConn)
+	t.drained = nil
+	server, listener, serveDone := t.Server, t.listener, t.serveDone
+	t.mu.Unlock()
 
-	// Shutdown HTTP server
-	if t.Server != nil {
-		if err := t.Server.Shutdown(ctx); err != nil {
-			return fmt.Errorf("failed to shutdown SSE transport server: %w", err)
+	// Shutdown HTTP server; cancelled handlers still need the lock to
+	// unregister, so it must not be held here
+	var shutdownErr error
+	if server != nil {
+		if drainErr != nil {
+			shutdownErr = server.Close()
+		} else {
+			shutdownErr = server.Shutdown(ctx)
 		}
 	}
 
+	// Shutdown only closes listeners Serve has begun tracking; close ours
+	// directly so the port is released even if Serve hasn't run yet
+	if listener != nil {
+		_ = listener.Close()
+	}
+
+	// Serve returns as soon as its listener is closed
+	if serveDone != nil {
+		<-serveDone
+	}
+
+	t.mu.Lock()
+	t.listener = nil
+	t.serveDone = nil
 	t.started = false
+	t.draining = false
+	close(t.stopped)
+	t.stopped = nil
+	t.mu.Unlock()
+
+	if drainErr != nil {
+		return fmt.Errorf("SSE clients did not disconnect before deadline, closed forcibly: %w", drainErr)
+	}
+	if shutdownErr != nil {
+		return fmt.Errorf("failed to shutdown SSE transport server: %w", shutdownErr)
+	}
 	return nil
 }
 
@@ -148,8 +521,14 @@ func (t *SSETransport) Type() string {
 	return "sse"
 }
 
-// handleSSE handles incoming SSE connection requests
+// handleSSE handles incoming SSE connection requests, and messages posted
+// by connected clients
 func (t *SSETransport) handleSSE(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		t.handleMessage(w, r)
+		return
+	}
+
 	// Set SSE headers
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
@@ -163,55 +542,174 @@ func (t *SSETransport) handleSSE(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Register connection
+	sessionID, err := newSessionID()
+	if err != nil {
+		http.Error(w, "Failed to create session", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(SSESessionHeader, sessionID)
+
+	// Register connection; the cancel func lets WriteMessage end the
+	// handler as soon as a write fails. The connection is registered with
+	// its write lock held until the initial connection message is sent, so
+	// other messages cannot come before it, and so the session is routable
+	// once the client sees its ID.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	conn := &sseConn{w: w, ctx: ctx, cancel: cancel}
+	conn.writeMu.Lock()
 	t.mu.Lock()
-	t.connections[r] = w
+	if t.draining {
+		t.mu.Unlock()
+		conn.writeMu.Unlock()
+		http.Error(w, "Server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	t.connections[sessionID] = conn
 	t.mu.Unlock()
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(SSEWriteTimeout))
+	fmt.Fprintf(w, "data: {\"type\":\"connection\",\"status\":\"connected\",\"session_id\":%q}\n\n", sessionID)
+	flusher.Flush()
+	conn.writeMu.Unlock()
 
-	// Cleanup on disconnect
+	// Cleanup on disconnect, letting a draining Stop finish once the last
+	// client is gone. No writes reach w once conn is closed.
 	defer func() {
+		conn.close()
 		t.mu.Lock()
-		delete(t.connections, r)
+		if t.connections[sessionID] == conn {
+			delete(t.connections, sessionID)
+		}
+		if t.drained != nil && len(t.connections) == 0 {
+			close(t.drained)
+			t.drained = nil
+		}
 		t.mu.Unlock()
 	}()
 
-	// Send initial connection message
-	fmt.Fprintf(w, "data: {\"type\":\"connection\",\"status\":\"connected\"}\n\n")
-	flusher.Flush()
+	// Keep connection alive and wait for disconnect or a failed write
+	<-ctx.Done()
+}
+
+// handleMessage queues a message posted by a client for ReadSessionMessage.
+// It replies 202 Accepted once the message is queued; the response to it, if
+// any, is sent on the client's event stream.
+func (t *SSETransport) handleMessage(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.Header.Get(SSESessionHeader)
+
+	t.mu.RLock()
+	conn, ok := t.connections[sessionID]
+	draining, incoming, done := t.draining, t.incoming, t.done
+	t.mu.RUnlock()
+
+	if draining {
+		http.Error(w, "Server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	if !ok || conn.ctx.Err() != nil {
+		http.Error(w, "Unknown session", http.StatusNotFound)
+		return
+	}
 
-	// Keep connection alive and wait for context cancellation
-	<-r.Context().Done()
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, sseMaxMessageSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "Message too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Failed to read message", http.StatusBadRequest)
+		return
+	}
+
+	select {
+	case incoming <- SSEMessage{SessionID: sessionID, Data: data}:
+		w.WriteHeader(http.StatusAccepted)
+	case <-conn.ctx.Done():
+		http.Error(w, "Session closed", http.StatusNotFound)
+	case <-done:
+		http.Error(w, "Server is shutting down", http.StatusServiceUnavailable)
+	case <-r.Context().Done():
+	}
+}
+
+// ReadSessionMessage returns the next message posted by any connected
+// client together with its session ID. It blocks until a message arrives,
+// the transport is stopped or ctx is done.
+func (t *SSETransport) ReadSessionMessage(ctx context.Context) (SSEMessage, error) {
+	t.mu.RLock()
+	started, incoming, done := t.started, t.incoming, t.done
+	t.mu.RUnlock()
+
+	if !started {
+		return SSEMessage{}, fmt.Errorf("SSE transport not started")
+	}
+
+	select {
+	case msg := <-incoming:
+		return msg, nil
+	case <-done:
+		return SSEMessage{}, fmt.Errorf("SSE transport stopped")
+	case <-ctx.Done():
+		return SSEMessage{}, ctx.Err()
+	}
+}
+
+// ConnectionDone returns a channel that is closed when the connection with
+// the given session ID ends. It is already closed for unknown sessions.
+func (t *SSETransport) ConnectionDone(sessionID string) <-chan struct{} {
+	t.mu.RLock()
+	conn, ok := t.connections[sessionID]
+	t.mu.RUnlock()
+	if !ok {
+		closed := make(chan struct{})
+		close(closed)
+		return closed
+	}
+	return conn.ctx.Done()
 }
 
 // WriteMessage sends a message to all connected SSE clients
+// Connections whose write fails are removed and their handlers cancelled
+// immediately rather than lingering until the client disconnects. Writes
+// happen outside the transport lock, so a slow client delays only its own
+// write.
 func (t *SSETransport) WriteMessage(data []byte) error {
 	t.mu.RLock()
-	defer t.mu.RUnlock()
-
-	if !t.started {
+	started := t.started
+	t.mu.RUnlock()
+	if !started {
 		return fmt.Errorf("SSE transport not started")
 	}
 
-	message := fmt.Sprintf("data: %s\n\n", string(data))
+	t.eachConnection(func(sessionID string, conn *sseConn) {
+		if err := conn.write(data); err != nil {
+			// Dead connection: prune it now and free its handler
+			t.removeConnection(sessionID, conn)
+		}
+	})
 
-	for req, w := range t.connections {
-		// Check if connection is still alive
-		select {
-		case <-req.Context().Done():
-			// Connection closed, skip
-			continue
-		default:
-			// Write message
-			if _, err := fmt.Fprintf(w, message); err != nil {
-				// Connection error, will be cleaned up on next request
-				continue
-			}
+	return nil
+}
 
-			// Flush if possible
-			if flusher, ok := w.(http.Flusher); ok {
-				flusher.Flush()
-			}
-		}
+// WriteToConnection sends a message to the SSE client with the given
+// session ID only. If the write fails the connection is removed, as in
+// WriteMessage, and the error is returned.
+func (t *SSETransport) WriteToConnection(sessionID string, data []byte) error {
+	t.mu.RLock()
+	started := t.started
+	conn, ok := t.connections[sessionID]
+	t.mu.RUnlock()
+
+	if !started {
+		return fmt.Errorf("SSE transport not started")
+	}
+	if !ok || conn.ctx.Err() != nil {
+		return fmt.Errorf("SSE session %q not found", sessionID)
+	}
+	if err := conn.write(data); err != nil {
+		t.removeConnection(sessionID, conn)
+		return fmt.Errorf("failed to write to SSE session %q: %w", sessionID, err)
 	}
 
 	return nil
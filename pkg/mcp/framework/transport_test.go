@@ -1,10 +1,21 @@
 package framework
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/security"
 )
 
 func TestStdioTransport_Start(t *testing.T) {
@@ -50,7 +61,7 @@ func TestSSETransport_NewSSETransport(t *testing.T) {
 			name:     "default values",
 			endpoint: "",
 			port:     0,
-			wantPort: 8080,
+			wantPort: 0,
 		},
 		{
 			name:     "custom endpoint and port",
@@ -66,8 +77,8 @@ func TestSSETransport_NewSSETransport(t *testing.T) {
 			if transport == nil {
 				t.Fatal("NewSSETransport() returned nil")
 			}
-			if transport.Port != tt.wantPort {
-				t.Errorf("transport.Port = %d, want %d", transport.Port, tt.wantPort)
+			if transport.ListenPort != tt.wantPort {
+				t.Errorf("transport.ListenPort = %d, want %d", transport.ListenPort, tt.wantPort)
 			}
 			expectedEndpoint := tt.endpoint
 			if expectedEndpoint == "" {
@@ -81,7 +92,7 @@ func TestSSETransport_NewSSETransport(t *testing.T) {
 }
 
 func TestSSETransport_Start(t *testing.T) {
-	transport := NewSSETransport("/test", 0) // Use port 0 to get random port
+	transport := NewSSETransport("/test", 0)
 	ctx := context.Background()
 
 	// Start transport
@@ -227,3 +238,635 @@ func TestSSETransport_handleSSE(t *testing.T) {
 	defer cancel()
 	_ = transport.Stop(stopCtx)
 }
+
+func TestSSETransport_Use(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+
+	var calls []string
+	transport.Use(
+		func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, "first")
+				next.ServeHTTP(w, r)
+			})
+		},
+		func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, "second")
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			})
+		},
+	)
+
+	if err := transport.Start(context.Background()); err != nil {
+		t.Fatalf("SSETransport.Start() error = %v, want nil", err)
+	}
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = transport.Stop(stopCtx)
+	}()
+
+	rec := httptest.NewRecorder()
+	transport.Server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if got := rec.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("X-RateLimit-Remaining = %q, want %q", got, "0")
+	}
+	if len(calls) != 2 || calls[0] != "first" || calls[1] != "second" {
+		t.Errorf("middleware calls = %v, want [first second]", calls)
+	}
+}
+
+func TestSSETransport_IPFilter(t *testing.T) {
+	filter, err := security.NewIPFilter([]string{"10.0.0.0/8"}, nil)
+	if err != nil {
+		t.Fatalf("NewIPFilter() error = %v", err)
+	}
+
+	transport := NewSSETransport("/test", 0)
+	transport.Use(security.IPFilterMiddleware(filter))
+
+	if err := transport.Start(context.Background()); err != nil {
+		t.Fatalf("SSETransport.Start() error = %v, want nil", err)
+	}
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = transport.Stop(stopCtx)
+	}()
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	rec := httptest.NewRecorder()
+	transport.Server.Handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	if count := transport.ConnectionCount(); count != 0 {
+		t.Errorf("ConnectionCount() = %d, want 0 (rejected before SSE handling)", count)
+	}
+}
+
+func TestSSETransport_StartBindError(t *testing.T) {
+	// Find a free port
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	_ = ln.Close()
+
+	first := NewSSETransport("/test", port)
+	if err := first.Start(context.Background()); err != nil {
+		t.Fatalf("first SSETransport.Start() error = %v, want nil", err)
+	}
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = first.Stop(stopCtx)
+	}()
+
+	second := NewSSETransport("/test", port)
+	if err := second.Start(context.Background()); err == nil {
+		t.Fatal("second SSETransport.Start() on same port error = nil, want bind error")
+	}
+	if second.started {
+		t.Error("SSETransport.Start() set started flag despite bind error")
+	}
+}
+
+func TestSSETransport_BoundPort(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+
+	if addr := transport.Addr(); addr != nil {
+		t.Errorf("Addr() before Start = %v, want nil", addr)
+	}
+	if err := transport.Start(context.Background()); err != nil {
+		t.Fatalf("SSETransport.Start() error = %v, want nil", err)
+	}
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = transport.Stop(stopCtx)
+	}()
+
+	port := transport.BoundPort()
+	if port == 0 {
+		t.Fatal("BoundPort() = 0, want the OS-assigned port")
+	}
+	if addr := transport.Addr().(*net.TCPAddr); addr.Port != port {
+		t.Errorf("Addr().Port = %d, want %d", addr.Port, port)
+	}
+	if got := transport.Port(); got != port {
+		t.Errorf("Port() = %d, want %d", got, port)
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)), time.Second)
+	if err != nil {
+		t.Fatalf("dial bound port %d: %v", port, err)
+	}
+	_ = conn.Close()
+}
+
+// failingWriter is a ResponseWriter whose writes always fail
+type failingWriter struct {
+	httptest.ResponseRecorder
+}
+
+func (w *failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestSSETransport_WriteMessage_PrunesFailedConnection(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+	transport.started = true // exercise WriteMessage without a listener
+
+	good := httptest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	transport.connections["good"] = &sseConn{w: good, ctx: context.Background(), cancel: func() {}}
+	transport.connections["bad"] = &sseConn{w: &failingWriter{}, ctx: ctx, cancel: cancel}
+
+	if err := transport.WriteMessage([]byte(`{"hello":"world"}`)); err != nil {
+		t.Fatalf("WriteMessage() error = %v", err)
+	}
+
+	if count := transport.ConnectionCount(); count != 1 {
+		t.Errorf("ConnectionCount() = %d, want 1 after failed write", count)
+	}
+	if _, ok := transport.connections["bad"]; ok {
+		t.Error("failed connection still registered")
+	}
+	if ctx.Err() == nil {
+		t.Error("failed connection's context was not cancelled")
+	}
+	if !strings.Contains(good.Body.String(), `data: {"hello":"world"}`) {
+		t.Errorf("healthy connection body = %q, want message", good.Body.String())
+	}
+}
+
+// sseClient is a test client for one SSE connection
+type sseClient struct {
+	sessionID string
+	events    chan string // data of each event received
+	resp      *http.Response
+}
+
+// dialSSE opens an SSE connection to the transport and waits for its
+// initial connection event
+func dialSSE(t *testing.T, transport *SSETransport) *sseClient {
+	t.Helper()
+	url := fmt.Sprintf("http://127.0.0.1:%d%s", transport.BoundPort(), transport.Endpoint)
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	client := &sseClient{
+		sessionID: resp.Header.Get(SSESessionHeader),
+		events:    make(chan string, 16),
+		resp:      resp,
+	}
+	if client.sessionID == "" {
+		t.Fatalf("response has no %s header", SSESessionHeader)
+	}
+
+	// Like a well-behaved client, disconnect when the server says so
+	go func() {
+		defer close(client.events)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+				client.events <- data
+				if data == sseClosingEvent {
+					resp.Body.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	connected := client.next(t)
+	if !strings.Contains(connected, `"session_id":"`+client.sessionID+`"`) {
+		t.Errorf("connection event = %q, want session ID %q", connected, client.sessionID)
+	}
+	return client
+}
+
+// next returns the data of the next event received
+func (c *sseClient) next(t *testing.T) string {
+	t.Helper()
+	select {
+	case data, ok := <-c.events:
+		if !ok {
+			t.Fatal("SSE stream closed")
+		}
+		return data
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for SSE event")
+	}
+	return ""
+}
+
+func TestSSETransport_WriteToConnection(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+	if err := transport.Start(context.Background()); err != nil {
+		t.Fatalf("SSETransport.Start() error = %v, want nil", err)
+	}
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = transport.Stop(stopCtx)
+	}()
+
+	a := dialSSE(t, transport)
+	b := dialSSE(t, transport)
+	if a.sessionID == b.sessionID {
+		t.Fatalf("connections share session ID %q", a.sessionID)
+	}
+	if count := transport.ConnectionCount(); count != 2 {
+		t.Errorf("ConnectionCount() = %d, want 2", count)
+	}
+
+	if err := transport.WriteToConnection(a.sessionID, []byte(`{"to":"a"}`)); err != nil {
+		t.Fatalf("WriteToConnection(a) error = %v", err)
+	}
+	if err := transport.WriteToConnection(b.sessionID, []byte(`{"to":"b"}`)); err != nil {
+		t.Fatalf("WriteToConnection(b) error = %v", err)
+	}
+
+	// Events arrive in order, so b's first event shows whether a's leaked
+	if got := a.next(t); got != `{"to":"a"}` {
+		t.Errorf("client a received %q, want its own message", got)
+	}
+	if got := b.next(t); got != `{"to":"b"}` {
+		t.Errorf("client b received %q, want only its own message", got)
+	}
+
+	// WriteMessage still broadcasts
+	if err := transport.WriteMessage([]byte(`{"to":"all"}`)); err != nil {
+		t.Fatalf("WriteMessage() error = %v", err)
+	}
+	for name, client := range map[string]*sseClient{"a": a, "b": b} {
+		if got := client.next(t); got != `{"to":"all"}` {
+			t.Errorf("client %s received %q, want broadcast", name, got)
+		}
+	}
+
+	// Disconnecting one client leaves the other registered
+	a.resp.Body.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for transport.ConnectionCount() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("ConnectionCount() = %d after disconnect, want 1", transport.ConnectionCount())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := transport.WriteToConnection(a.sessionID, []byte("{}")); err == nil {
+		t.Error("WriteToConnection() to disconnected session error = nil, want error")
+	}
+}
+
+func TestSSETransport_WriteToConnection_Errors(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+	if err := transport.WriteToConnection("missing", []byte("{}")); err == nil {
+		t.Error("WriteToConnection() without start should return error, got nil")
+	}
+
+	transport.started = true // exercise WriteToConnection without a listener
+	if err := transport.WriteToConnection("missing", []byte("{}")); err == nil {
+		t.Error("WriteToConnection() to unknown session should return error, got nil")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	transport.connections["bad"] = &sseConn{w: &failingWriter{}, ctx: ctx, cancel: cancel}
+	if err := transport.WriteToConnection("bad", []byte("{}")); err == nil {
+		t.Error("WriteToConnection() with failing writer should return error, got nil")
+	}
+	if count := transport.ConnectionCount(); count != 0 {
+		t.Errorf("ConnectionCount() = %d, want 0 after failed write", count)
+	}
+	if ctx.Err() == nil {
+		t.Error("failed connection's context was not cancelled")
+	}
+}
+
+// post sends data to the transport as a message from the given session and
+// returns the response status
+func post(t *testing.T, transport *SSETransport, sessionID, data string) int {
+	t.Helper()
+	url := fmt.Sprintf("http://127.0.0.1:%d%s", transport.BoundPort(), transport.Endpoint)
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	req.Header.Set(SSESessionHeader, sessionID)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST %s: %v", url, err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestSSETransport_ReadSessionMessage(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+	if _, err := transport.ReadSessionMessage(context.Background()); err == nil {
+		t.Error("ReadSessionMessage() without start should return error, got nil")
+	}
+	if err := transport.Start(context.Background()); err != nil {
+		t.Fatalf("SSETransport.Start() error = %v, want nil", err)
+	}
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = transport.Stop(stopCtx)
+	}()
+
+	client := dialSSE(t, transport)
+	if status := post(t, transport, client.sessionID, `{"hello":"world"}`); status != http.StatusAccepted {
+		t.Fatalf("POST status = %d, want %d", status, http.StatusAccepted)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	msg, err := transport.ReadSessionMessage(ctx)
+	if err != nil {
+		t.Fatalf("ReadSessionMessage() error = %v", err)
+	}
+	if msg.SessionID != client.sessionID || string(msg.Data) != `{"hello":"world"}` {
+		t.Errorf("ReadSessionMessage() = {%q, %s}, want {%q, posted message}", msg.SessionID, msg.Data, client.sessionID)
+	}
+
+	if status := post(t, transport, "unknown", "{}"); status != http.StatusNotFound {
+		t.Errorf("POST to unknown session status = %d, want %d", status, http.StatusNotFound)
+	}
+
+	select {
+	case <-transport.ConnectionDone(client.sessionID):
+		t.Error("ConnectionDone() closed for a connected client")
+	default:
+	}
+	client.resp.Body.Close()
+	select {
+	case <-transport.ConnectionDone(client.sessionID):
+	case <-time.After(2 * time.Second):
+		t.Error("ConnectionDone() not closed after the client disconnected")
+	}
+}
+
+func TestSSETransport_Heartbeat(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+	transport.SetHeartbeatInterval(50 * time.Millisecond)
+	if err := transport.Start(context.Background()); err != nil {
+		t.Fatalf("SSETransport.Start() error = %v, want nil", err)
+	}
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = transport.Stop(stopCtx)
+	}()
+
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/test", transport.BoundPort()))
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	pinged := make(chan struct{})
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if scanner.Text() == ": ping" {
+				close(pinged)
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-pinged:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no heartbeat received")
+	}
+}
+
+func TestSSETransport_HeartbeatInterval(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+	if transport.heartbeatInterval != DefaultSSEHeartbeatInterval {
+		t.Errorf("default heartbeat interval = %v, want %v", transport.heartbeatInterval, DefaultSSEHeartbeatInterval)
+	}
+
+	// Zero disables the heartbeat goroutine
+	transport.SetHeartbeatInterval(0)
+	if err := transport.Start(context.Background()); err != nil {
+		t.Fatalf("SSETransport.Start() error = %v, want nil", err)
+	}
+	if transport.heartbeatStop != nil {
+		t.Error("heartbeat started with zero interval")
+	}
+	if err := transport.Stop(context.Background()); err != nil {
+		t.Errorf("SSETransport.Stop() error = %v, want nil", err)
+	}
+}
+
+func TestSSETransport_HeartbeatStopRestart(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+	transport.SetHeartbeatInterval(time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		if err := transport.Start(context.Background()); err != nil {
+			t.Fatalf("SSETransport.Start() #%d error = %v, want nil", i, err)
+		}
+		time.Sleep(5 * time.Millisecond)
+		if err := transport.Stop(context.Background()); err != nil {
+			t.Fatalf("SSETransport.Stop() #%d error = %v, want nil", i, err)
+		}
+	}
+}
+
+func TestSSETransport_ConcurrentStartStop(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+	transport.SetHeartbeatInterval(time.Millisecond)
+
+	// Start may fail while another Start won or a Stop is draining; it must
+	// never race or leave the transport half started
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_ = transport.Start(context.Background())
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if err := transport.Stop(context.Background()); err != nil {
+					t.Errorf("SSETransport.Stop() error = %v, want nil", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := transport.Stop(context.Background()); err != nil {
+		t.Fatalf("SSETransport.Stop() error = %v, want nil", err)
+	}
+	if addr := transport.Addr(); addr != nil {
+		t.Errorf("SSETransport.Addr() after Stop = %v, want nil", addr)
+	}
+	if got := transport.ConnectionCount(); got != 0 {
+		t.Errorf("SSETransport.ConnectionCount() after Stop = %d, want 0", got)
+	}
+
+	// The transport still works after the races
+	if err := transport.Start(context.Background()); err != nil {
+		t.Fatalf("SSETransport.Start() error = %v, want nil", err)
+	}
+	dialSSE(t, transport)
+	if got := transport.ConnectionCount(); got != 1 {
+		t.Errorf("SSETransport.ConnectionCount() = %d, want 1", got)
+	}
+	stopCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := transport.Stop(stopCtx); err != nil {
+		t.Errorf("SSETransport.Stop() error = %v, want nil", err)
+	}
+}
+
+func TestSSETransport_StopDrains(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+	if err := transport.Start(context.Background()); err != nil {
+		t.Fatalf("SSETransport.Start() error = %v, want nil", err)
+	}
+	client := dialSSE(t, transport)
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	stopped := make(chan error, 1)
+	go func() { stopped <- transport.Stop(stopCtx) }()
+
+	if got := client.next(t); got != sseClosingEvent {
+		t.Errorf("client received %q, want closing event", got)
+	}
+
+	// The client disconnects on the closing event, so Stop need not wait
+	// for the deadline
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Errorf("SSETransport.Stop() error = %v, want nil", err)
+		}
+	case <-time.After(900 * time.Millisecond):
+		t.Fatal("SSETransport.Stop() did not return after clients disconnected")
+	}
+	if transport.started || transport.ConnectionCount() != 0 {
+		t.Error("transport still started or has connections after Stop")
+	}
+}
+
+func TestSSETransport_StopForceCloses(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+	if err := transport.Start(context.Background()); err != nil {
+		t.Fatalf("SSETransport.Start() error = %v, want nil", err)
+	}
+
+	// A client that ignores the closing event
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/test", transport.BoundPort()))
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for transport.ConnectionCount() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("connection never registered")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = transport.Stop(stopCtx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SSETransport.Stop() error = %v, want deadline exceeded", err)
+	}
+	if transport.started || transport.ConnectionCount() != 0 {
+		t.Error("transport still started or has connections after Stop")
+	}
+
+	// The stream ends once the connection is force-closed
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), sseClosingEvent) {
+		t.Errorf("stream = %q, want closing event before close", body)
+	}
+}
+
+func TestSSETransport_StopWithStuckClient(t *testing.T) {
+	transport := NewSSETransport("/test", 0)
+	if err := transport.Start(context.Background()); err != nil {
+		t.Fatalf("SSETransport.Start() error = %v, want nil", err)
+	}
+
+	// A client that connects and then never reads
+	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", transport.BoundPort()))
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+	_ = conn.(*net.TCPConn).SetReadBuffer(1024)
+	fmt.Fprint(conn, "GET /test HTTP/1.1\r\nHost: localhost\r\n\r\n")
+
+	var sessionID string
+	deadline := time.Now().Add(2 * time.Second)
+	for sessionID == "" {
+		if time.Now().After(deadline) {
+			t.Fatal("connection never registered")
+		}
+		transport.mu.RLock()
+		for id := range transport.connections {
+			sessionID = id
+		}
+		transport.mu.RUnlock()
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Fill the socket buffers until a write blocks
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		chunk := []byte(strings.Repeat("x", 1<<20))
+		for transport.WriteToConnection(sessionID, chunk) == nil {
+		}
+	}()
+	time.Sleep(200 * time.Millisecond)
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = transport.Stop(stopCtx)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("SSETransport.Stop() took %v with a stuck client, want about its 100ms deadline", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SSETransport.Stop() error = %v, want deadline exceeded", err)
+	}
+
+	// Closing the connection fails the blocked write
+	select {
+	case <-writerDone:
+	case <-time.After(2 * time.Second):
+		t.Error("blocked write did not fail after Stop")
+	}
+}
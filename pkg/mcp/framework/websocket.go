@@ -0,0 +1,395 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsIncomingBuffer is how many received messages are queued for ReadMessage
+const wsIncomingBuffer = 64
+
+// WSWriteTimeout bounds each write to a WebSocket client, so a slow or stuck
+// client cannot hold up writes to the others
+const WSWriteTimeout = 10 * time.Second
+
+// WSMessage is a message received from a WebSocket client
+type WSMessage struct {
+	// SessionID identifies the connection the message arrived on
+	SessionID string
+
+	// Data is the message payload
+	Data []byte
+}
+
+// WSTransport represents WebSocket transport
+// Unlike SSETransport it is full-duplex: clients send messages over the same
+// connection they receive them on.
+//
+// Each connection is assigned a session ID. Messages received from any
+// connection are delivered, in arrival order, by ReadMessage, or with their
+// session ID by ReadSessionMessage. Use WriteToConnection to reply to one
+// client; WriteMessage broadcasts.
+type WSTransport struct {
+	// Server is the HTTP server that will handle WebSocket connections
+	Server *http.Server
+
+	// Endpoint is the path where WebSocket connections will be accepted
+	Endpoint string
+
+	// Port is the port number for the HTTP server. 0 lets the OS choose a
+	// free port; BoundPort reports it once started.
+	Port int
+
+	// CheckOrigin decides whether to accept a connection based on its
+	// request. If nil, only same-origin browser requests (and non-browser
+	// clients, which send no Origin header) are accepted.
+	CheckOrigin func(r *http.Request) bool
+
+	// mu protects the server state
+	mu sync.RWMutex
+
+	// started indicates if the transport has been started
+	started bool
+
+	// connections tracks active WebSocket connections by session ID
+	connections map[string]*wsConn
+
+	// listener is the bound socket the server is serving on (nil until Start)
+	listener net.Listener
+
+	// incoming queues messages read from connections for ReadMessage
+	incoming chan WSMessage
+
+	// done is closed by Stop to unblock readers
+	done chan struct{}
+}
+
+// NewWSTransport creates a new WebSocket transport with the given endpoint
+// and port. An empty endpoint defaults to "/ws"; port 0 lets the OS choose one.
+func NewWSTransport(endpoint string, port int) *WSTransport {
+	if endpoint == "" {
+		endpoint = "/ws"
+	}
+
+	return &WSTransport{
+		Endpoint:    endpoint,
+		Port:        port,
+		connections: make(map[string]*wsConn),
+	}
+}
+
+// wsConn is an active WebSocket client connection
+type wsConn struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex         // websocket.Conn allows only one concurrent writer
+	ctx     context.Context    // done when the connection's handler ends
+	cancel  context.CancelFunc // ends the connection's handler
+}
+
+// write sends a text message on the connection, failing if the client does
+// not take it within WSWriteTimeout
+func (c *wsConn) write(data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.conn.SetWriteDeadline(time.Now().Add(WSWriteTimeout)); err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// Start initializes the WebSocket transport and starts the HTTP server
+func (t *WSTransport) Start(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.started {
+		return fmt.Errorf("WebSocket transport already started")
+	}
+
+	// Create HTTP server if not already set
+	if t.Server == nil {
+		mux := http.NewServeMux()
+		mux.HandleFunc(t.Endpoint, t.handleWS)
+
+		t.Server = &http.Server{
+			Addr:    fmt.Sprintf(":%d", t.Port),
+			Handler: mux,
+		}
+	}
+
+	// Bind synchronously so errors such as "address already in use" are
+	// reported to the caller instead of being lost in the serve goroutine
+	addr := t.Server.Addr
+	if addr == "" {
+		addr = ":http" // Same default as http.Server.ListenAndServe
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	t.listener = listener
+	t.incoming = make(chan WSMessage, wsIncomingBuffer)
+	t.done = make(chan struct{})
+
+	// Serve in a goroutine
+	go func() {
+		if err := t.Server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			// Log error (would need logger integration)
+			_ = err
+		}
+	}()
+
+	t.started = true
+	return nil
+}
+
+// Addr returns the address the transport is listening on, or nil if it is
+// not started
+func (t *WSTransport) Addr() net.Addr {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.listener == nil {
+		return nil
+	}
+	return t.listener.Addr()
+}
+
+// BoundPort returns the TCP port the transport is listening on, or 0 if it
+// is not started
+func (t *WSTransport) BoundPort() int {
+	if tcpAddr, ok := t.Addr().(*net.TCPAddr); ok {
+		return tcpAddr.Port
+	}
+	return 0
+}
+
+// Stop shuts down the WebSocket transport and closes all connections
+func (t *WSTransport) Stop(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.started {
+		return nil
+	}
+
+	// Close all active connections; their handlers exit and unregister
+	for _, conn := range t.connections {
+		conn.cancel()
+	}
+	t.connections = make(map[string]*wsConn)
+	close(t.done)
+
+	// Shutdown HTTP server (hijacked WebSocket connections are not tracked
+	// by Shutdown, which is why they are closed above)
+	if t.Server != nil {
+		if err := t.Server.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to shutdown WebSocket transport server: %w", err)
+		}
+	}
+
+	// Shutdown only closes listeners Serve has begun tracking; close ours
+	// directly so the port is released even if Serve hasn't run yet
+	if t.listener != nil {
+		_ = t.listener.Close()
+		t.listener = nil
+	}
+
+	t.started = false
+	return nil
+}
+
+// Type returns the transport type
+func (t *WSTransport) Type() string {
+	return "websocket"
+}
+
+// handleWS upgrades a request to a WebSocket connection and reads messages
+// from it until the client disconnects or the transport stops
+func (t *WSTransport) handleWS(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{CheckOrigin: t.CheckOrigin}
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // Upgrade has already replied with an HTTP error
+	}
+
+	sessionID, err := newSessionID()
+	if err != nil {
+		_ = ws.Close()
+		return
+	}
+
+	// Register connection; the cancel func lets Stop and WriteMessage end
+	// the handler
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	conn := &wsConn{conn: ws, ctx: ctx, cancel: cancel}
+
+	t.mu.Lock()
+	if !t.started {
+		t.mu.Unlock()
+		_ = ws.Close()
+		return
+	}
+	t.connections[sessionID] = conn
+	incoming, done := t.incoming, t.done
+	t.mu.Unlock()
+
+	// Cleanup on disconnect
+	defer func() {
+		t.mu.Lock()
+		if t.connections[sessionID] == conn {
+			delete(t.connections, sessionID)
+		}
+		t.mu.Unlock()
+		_ = ws.Close()
+	}()
+
+	// Closing the socket unblocks ReadMessage below once ctx ends
+	go func() {
+		<-ctx.Done()
+		_ = ws.Close()
+	}()
+
+	for {
+		_, data, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		select {
+		case incoming <- WSMessage{SessionID: sessionID, Data: data}:
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		}
+	}
+}
+
+// ReadMessage returns the next message received from any connected client.
+// It blocks until a message arrives or the transport is stopped.
+func (t *WSTransport) ReadMessage() ([]byte, error) {
+	return t.ReadMessageContext(context.Background())
+}
+
+// ReadMessageContext is like ReadMessage but also returns when ctx is done
+func (t *WSTransport) ReadMessageContext(ctx context.Context) ([]byte, error) {
+	msg, err := t.ReadSessionMessage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return msg.Data, nil
+}
+
+// ReadSessionMessage returns the next message received from any connected
+// client together with the session ID of its connection. It blocks until a
+// message arrives, the transport is stopped or ctx is done.
+func (t *WSTransport) ReadSessionMessage(ctx context.Context) (WSMessage, error) {
+	t.mu.RLock()
+	started, incoming, done := t.started, t.incoming, t.done
+	t.mu.RUnlock()
+
+	if !started {
+		return WSMessage{}, fmt.Errorf("WebSocket transport not started")
+	}
+
+	select {
+	case msg := <-incoming:
+		return msg, nil
+	case <-done:
+		return WSMessage{}, fmt.Errorf("WebSocket transport stopped")
+	case <-ctx.Done():
+		return WSMessage{}, ctx.Err()
+	}
+}
+
+// WriteMessage sends a message to all connected WebSocket clients
+// Connections whose write fails are removed and closed. Writes happen
+// outside the transport lock, so a slow client delays only its own write.
+func (t *WSTransport) WriteMessage(data []byte) error {
+	t.mu.RLock()
+	if !t.started {
+		t.mu.RUnlock()
+		return fmt.Errorf("WebSocket transport not started")
+	}
+	conns := make(map[string]*wsConn, len(t.connections))
+	for sessionID, conn := range t.connections {
+		conns[sessionID] = conn
+	}
+	t.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for sessionID, conn := range conns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := conn.write(data); err != nil {
+				// Dead connection: prune it now and free its handler
+				t.removeConnection(sessionID, conn)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// WriteToConnection sends a message to the WebSocket client with the given
+// session ID only. If the write fails the connection is removed, as in
+// WriteMessage, and the error is returned.
+func (t *WSTransport) WriteToConnection(sessionID string, data []byte) error {
+	t.mu.RLock()
+	started := t.started
+	conn, ok := t.connections[sessionID]
+	t.mu.RUnlock()
+
+	if !started {
+		return fmt.Errorf("WebSocket transport not started")
+	}
+	if !ok || conn.ctx.Err() != nil {
+		return fmt.Errorf("WebSocket session %q not found", sessionID)
+	}
+	if err := conn.write(data); err != nil {
+		t.removeConnection(sessionID, conn)
+		return fmt.Errorf("failed to write to WebSocket session %q: %w", sessionID, err)
+	}
+	return nil
+}
+
+// ConnectionDone returns a channel that is closed when the connection with
+// the given session ID ends. It is already closed for unknown sessions.
+func (t *WSTransport) ConnectionDone(sessionID string) <-chan struct{} {
+	t.mu.RLock()
+	conn, ok := t.connections[sessionID]
+	t.mu.RUnlock()
+	if !ok {
+		closed := make(chan struct{})
+		close(closed)
+		return closed
+	}
+	return conn.ctx.Done()
+}
+
+// removeConnection unregisters conn, if it is still registered under
+// sessionID, and ends its handler
+func (t *WSTransport) removeConnection(sessionID string, conn *wsConn) {
+	t.mu.Lock()
+	if t.connections[sessionID] == conn {
+		delete(t.connections, sessionID)
+	}
+	t.mu.Unlock()
+	conn.cancel()
+}
+
+// ConnectionCount returns the number of active WebSocket connections
+func (t *WSTransport) ConnectionCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.connections)
+}
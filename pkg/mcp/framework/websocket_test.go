@@ -0,0 +1,222 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// startWSTransport starts a WebSocket transport on an ephemeral port and
+// stops it when the test ends
+func startWSTransport(t *testing.T) *WSTransport {
+	t.Helper()
+	transport := NewWSTransport("/ws", 0)
+	if err := transport.Start(context.Background()); err != nil {
+		t.Fatalf("WSTransport.Start() error = %v", err)
+	}
+	t.Cleanup(func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = transport.Stop(stopCtx)
+	})
+	return transport
+}
+
+// dialWS connects a WebSocket client to transport and waits until the
+// transport has registered it
+func dialWS(t *testing.T, transport *WSTransport) *websocket.Conn {
+	t.Helper()
+	want := transport.ConnectionCount() + 1
+	url := fmt.Sprintf("ws://127.0.0.1:%d/ws", transport.BoundPort())
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial(%s) error = %v", url, err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	deadline := time.Now().Add(2 * time.Second)
+	for transport.ConnectionCount() < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("ConnectionCount() = %d, want %d", transport.ConnectionCount(), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return conn
+}
+
+func TestWSTransport_Type(t *testing.T) {
+	transport := NewWSTransport("/ws", 8080)
+	if got := transport.Type(); got != "websocket" {
+		t.Errorf("WSTransport.Type() = %q, want %q", got, "websocket")
+	}
+}
+
+func TestWSTransport_NewWSTransport(t *testing.T) {
+	transport := NewWSTransport("", 0)
+	if transport.Endpoint != "/ws" || transport.Port != 0 {
+		t.Errorf("NewWSTransport(\"\", 0) = %s:%d, want /ws:0", transport.Endpoint, transport.Port)
+	}
+}
+
+func TestWSTransport_NotStarted(t *testing.T) {
+	transport := NewWSTransport("/ws", 0)
+	if err := transport.WriteMessage([]byte("x")); err == nil {
+		t.Error("WriteMessage() before Start error = nil, want error")
+	}
+	if _, err := transport.ReadMessage(); err == nil {
+		t.Error("ReadMessage() before Start error = nil, want error")
+	}
+	if err := transport.Stop(context.Background()); err != nil {
+		t.Errorf("Stop() without Start error = %v, want nil", err)
+	}
+}
+
+func TestWSTransport_StartTwice(t *testing.T) {
+	transport := startWSTransport(t)
+	if err := transport.Start(context.Background()); err == nil {
+		t.Error("second Start() error = nil, want error")
+	}
+}
+
+func TestWSTransport_RoundTrip(t *testing.T) {
+	transport := startWSTransport(t)
+	client := dialWS(t, transport)
+
+	if err := client.WriteMessage(websocket.TextMessage, []byte(`{"ping":1}`)); err != nil {
+		t.Fatalf("client WriteMessage() error = %v", err)
+	}
+	data, err := transport.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	if string(data) != `{"ping":1}` {
+		t.Fatalf("ReadMessage() = %s, want {\"ping\":1}", data)
+	}
+
+	// Echo it back
+	if err := transport.WriteMessage(data); err != nil {
+		t.Fatalf("WriteMessage() error = %v", err)
+	}
+	_ = client.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, echoed, err := client.ReadMessage()
+	if err != nil {
+		t.Fatalf("client ReadMessage() error = %v", err)
+	}
+	if string(echoed) != `{"ping":1}` {
+		t.Errorf("client received %s, want {\"ping\":1}", echoed)
+	}
+}
+
+func TestWSTransport_WriteToConnection(t *testing.T) {
+	transport := startWSTransport(t)
+	clientA := dialWS(t, transport)
+	clientB := dialWS(t, transport)
+
+	if err := clientA.WriteMessage(websocket.TextMessage, []byte(`{"from":"a"}`)); err != nil {
+		t.Fatalf("client WriteMessage() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	msg, err := transport.ReadSessionMessage(ctx)
+	if err != nil {
+		t.Fatalf("ReadSessionMessage() error = %v", err)
+	}
+	if msg.SessionID == "" || string(msg.Data) != `{"from":"a"}` {
+		t.Fatalf("ReadSessionMessage() = %+v, want a message from client A with a session ID", msg)
+	}
+
+	// Reply to A only
+	if err := transport.WriteToConnection(msg.SessionID, []byte(`{"to":"a"}`)); err != nil {
+		t.Fatalf("WriteToConnection() error = %v", err)
+	}
+	_ = clientA.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, data, err := clientA.ReadMessage(); err != nil || string(data) != `{"to":"a"}` {
+		t.Errorf("client A received %s, %v; want {\"to\":\"a\"}", data, err)
+	}
+	_ = clientB.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	if _, data, err := clientB.ReadMessage(); err == nil {
+		t.Errorf("client B received %s, want nothing", data)
+	}
+
+	if err := transport.WriteToConnection("unknown", []byte(`{}`)); err == nil {
+		t.Error("WriteToConnection() to an unknown session error = nil, want error")
+	}
+
+	// The session ends when its client disconnects
+	done := transport.ConnectionDone(msg.SessionID)
+	_ = clientA.Close()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Error("ConnectionDone() not closed after the client disconnected")
+	}
+}
+
+func TestWSTransport_ConcurrentWrites(t *testing.T) {
+	transport := startWSTransport(t)
+	client := dialWS(t, transport)
+
+	const writers = 10
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		go func(i int) {
+			errs <- transport.WriteMessage([]byte(fmt.Sprintf(`{"n":%d}`, i)))
+		}(i)
+	}
+	for i := 0; i < writers; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("WriteMessage() error = %v", err)
+		}
+	}
+
+	_ = client.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for i := 0; i < writers; i++ {
+		if _, _, err := client.ReadMessage(); err != nil {
+			t.Fatalf("client ReadMessage() #%d error = %v", i, err)
+		}
+	}
+}
+
+func TestWSTransport_ReadMessageContext(t *testing.T) {
+	transport := startWSTransport(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := transport.ReadMessageContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("ReadMessageContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWSTransport_StopClosesConnections(t *testing.T) {
+	transport := startWSTransport(t)
+	client := dialWS(t, transport)
+
+	readErr := make(chan error, 1)
+	go func() {
+		_, err := transport.ReadMessage()
+		readErr <- err
+	}()
+
+	if err := transport.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if transport.ConnectionCount() != 0 {
+		t.Errorf("ConnectionCount() after Stop = %d, want 0", transport.ConnectionCount())
+	}
+	select {
+	case err := <-readErr:
+		if err == nil {
+			t.Error("ReadMessage() after Stop error = nil, want error")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("ReadMessage() still blocked after Stop")
+	}
+
+	_ = client.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := client.ReadMessage(); err == nil {
+		t.Error("client ReadMessage() after Stop error = nil, want closed connection")
+	}
+}
@@ -6,6 +6,7 @@ package logging
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
@@ -61,16 +62,23 @@ func (l LogLevel) toSlogLevel() slog.Level {
 }
 
 // Logger provides structured logging with levels, timestamps, and context.
-// All logs are written to stderr to maintain MCP protocol compatibility.
+// Logs are written to stderr by default to maintain MCP protocol
+// compatibility; see NewLoggerWithWriter and SetOutput.
 // Uses slog (Go 1.21+ standard library) for structured logging.
 type Logger struct {
 	mu            sync.Mutex
 	level         LogLevel
+	output        io.Writer // where logs are written
+	jsonFormat    bool      // JSON instead of text output
 	slogLogger    *slog.Logger
-	slowThreshold time.Duration // Threshold for performance logging
+	slowThreshold time.Duration       // Threshold for performance logging
+	maxContextLen int                 // Longest context logged in full; <= 0 means no limit
+	metrics       *perfRecorder       // Aggregated durations for Metrics()
+	redactKeys    map[string]struct{} // Lowercased param names masked by LogToolCall
+	attrs         []interface{}       // Persistent key/value fields added by With
 }
 
-// NewLogger creates a new logger instance.
+// NewLogger creates a new logger instance writing to stderr.
 // The log level is determined by environment variables:
 // - If MCP_DEBUG=1, log level is DEBUG (all messages)
 // - If GIT_HOOK=1, log level is WARN (suppress INFO messages)
@@ -78,41 +86,117 @@ type Logger struct {
 // Output format is determined by LOG_FORMAT:
 // - If LOG_FORMAT=json, uses JSON output format
 // - Otherwise, uses text output format (default)
+// The slow-operation threshold defaults to 100ms and can be overridden with
+// MCP_SLOW_THRESHOLD (a Go duration such as "250ms" or "2s"). An invalid
+// value is reported as a warning and the default is used.
 func NewLogger() *Logger {
+	return NewLoggerWithWriter(os.Stderr)
+}
+
+// NewLoggerWithWriter is NewLogger writing to w instead of stderr, e.g. a
+// RotatingWriter or a buffer in tests. Level and format come from the
+// environment as for NewLogger.
+func NewLoggerWithWriter(w io.Writer) *Logger {
 	level := LevelInfo
-	
+
 	// Check MCP_DEBUG first (for backward compatibility)
 	if os.Getenv("MCP_DEBUG") == "1" {
 		level = LevelDebug
 	}
-	
+
 	// GIT_HOOK overrides to WARN (suppress INFO in git hooks)
 	if os.Getenv("GIT_HOOK") == "1" || strings.ToLower(os.Getenv("GIT_HOOK")) == "true" {
 		level = LevelWarn
 	}
 
-	// Determine output format (JSON or text)
-	format := os.Getenv("LOG_FORMAT")
+	slowThreshold, thresholdErr := slowThresholdFromEnv()
+	l := &Logger{
+		level: level,
+		// Use JSON for machine-readable logs, text for humans
+		jsonFormat:    os.Getenv("LOG_FORMAT") == "json",
+		output:        w,
+		slowThreshold: slowThreshold,
+		maxContextLen: DefaultMaxContextLength,
+		metrics:       newPerfRecorder(),
+	}
+	l.rebuildHandler()
+	if thresholdErr != nil {
+		l.Warn("", "%v; using the default of %v", thresholdErr, DefaultSlowThreshold)
+	}
+	return l
+}
+
+// rebuildHandler recreates the slog logger for the current output, format
+// and level; the caller must hold l.mu (or own l exclusively)
+func (l *Logger) rebuildHandler() {
 	opts := &slog.HandlerOptions{
-		Level: level.toSlogLevel(),
+		Level: l.level.toSlogLevel(),
 	}
-	
-	var handler slog.Handler
-	if format == "json" {
-		// Use JSONHandler for machine-readable logs
-		handler = slog.NewJSONHandler(os.Stderr, opts)
+	if l.jsonFormat {
+		l.slogLogger = slog.New(slog.NewJSONHandler(l.output, opts))
 	} else {
-		// Use TextHandler for human-readable output to stderr (MCP protocol compatible)
-		handler = slog.NewTextHandler(os.Stderr, opts)
+		l.slogLogger = slog.New(slog.NewTextHandler(l.output, opts))
 	}
-	
-	slogLogger := slog.New(handler)
+	if len(l.attrs) > 0 {
+		l.slogLogger = l.slogLogger.With(l.attrs...)
+	}
+}
+
+// With returns a logger that adds the given key/value pairs (as for
+// slog.Logger.With) to every message it logs. It starts with l's level,
+// output, slow threshold, context length limit and redact keys and shares
+// l's metrics; changing its settings does not affect l. Calls can be chained
+// to accumulate fields.
+//
+// Example:
+//
+//	toolLogger := logger.With("tool", "search", "session", sessionID)
+//	toolLogger.Info("", "started")
+func (l *Logger) With(args ...interface{}) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 
+	attrs := make([]interface{}, 0, len(l.attrs)+len(args))
+	attrs = append(attrs, l.attrs...)
+	attrs = append(attrs, args...)
 	return &Logger{
-		level:         level,
-		slogLogger:    slogLogger,
-		slowThreshold: 100 * time.Millisecond, // Log operations taking >100ms
+		level:         l.level,
+		output:        l.output,
+		jsonFormat:    l.jsonFormat,
+		slogLogger:    l.slogLogger.With(args...),
+		slowThreshold: l.slowThreshold,
+		maxContextLen: l.maxContextLen,
+		metrics:       l.metrics,
+		redactKeys:    l.redactKeys,
+		attrs:         attrs,
+	}
+}
+
+// DefaultSlowThreshold is the duration above which operations are logged as slow
+const DefaultSlowThreshold = 100 * time.Millisecond
+
+// ParseSlowThreshold parses an MCP_SLOW_THRESHOLD value, which must be a
+// positive Go duration such as "250ms" or "2s"
+func ParseSlowThreshold(value string) (time.Duration, error) {
+	threshold, err := time.ParseDuration(value)
+	if err != nil || threshold <= 0 {
+		return 0, fmt.Errorf("invalid MCP_SLOW_THRESHOLD %q: must be a positive duration", value)
+	}
+	return threshold, nil
+}
+
+// slowThresholdFromEnv returns the MCP_SLOW_THRESHOLD duration, or
+// DefaultSlowThreshold if it is unset or invalid (with the parse error)
+func slowThresholdFromEnv() (time.Duration, error) {
+	value := os.Getenv("MCP_SLOW_THRESHOLD")
+	if value == "" {
+		return DefaultSlowThreshold, nil
+	}
+	threshold, err := ParseSlowThreshold(value)
+	if err != nil {
+		return DefaultSlowThreshold, err
 	}
+	return threshold, nil
 }
 
 // SetLevel sets the minimum log level.
@@ -120,16 +204,22 @@ func (l *Logger) SetLevel(level LogLevel) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.level = level
-	// Update slog handler level
-	opts := &slog.HandlerOptions{
-		Level: level.toSlogLevel(),
-	}
-	format := os.Getenv("LOG_FORMAT")
-	if format == "json" {
-		l.slogLogger = slog.New(slog.NewJSONHandler(os.Stderr, opts))
-	} else {
-		l.slogLogger = slog.New(slog.NewTextHandler(os.Stderr, opts))
-	}
+	l.rebuildHandler()
+}
+
+// SetOutput redirects logs to w, keeping the level and format.
+func (l *Logger) SetOutput(w io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.output = w
+	l.rebuildHandler()
+}
+
+// Level returns the current minimum log level.
+func (l *Logger) Level() LogLevel {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.level
 }
 
 // SetSlowThreshold sets the threshold for performance logging.
@@ -140,6 +230,38 @@ func (l *Logger) SetSlowThreshold(threshold time.Duration) {
 	l.slowThreshold = threshold
 }
 
+// DefaultMaxContextLength is the longest context, in characters, that is
+// logged in full; longer contexts are truncated
+const DefaultMaxContextLength = 128
+
+// contextEllipsis marks a truncated context
+const contextEllipsis = "..."
+
+// SetMaxContextLength sets the longest context, in characters, that is
+// logged in full. Longer contexts (e.g. an oversized request ID) are cut
+// to maxLen characters ending in "...". A maxLen <= 0 disables truncation.
+func (l *Logger) SetMaxContextLength(maxLen int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maxContextLen = maxLen
+}
+
+// truncateContext shortens context to at most maxLen characters, ending in
+// an ellipsis when cut
+func truncateContext(context string, maxLen int) string {
+	if maxLen <= 0 || len(context) <= maxLen {
+		return context
+	}
+	runes := []rune(context)
+	if len(runes) <= maxLen {
+		return context
+	}
+	if maxLen <= len(contextEllipsis) {
+		return string(runes[:maxLen])
+	}
+	return string(runes[:maxLen-len(contextEllipsis)]) + contextEllipsis
+}
+
 // log writes a log message with the specified level, context, and message.
 // Context is optional and can be used for request IDs, operation names, etc.
 // Maintains backward compatibility with existing API.
@@ -158,10 +280,31 @@ func (l *Logger) log(level LogLevel, context string, format string, args ...inte
 	// Build structured fields
 	fields := []interface{}{"msg", message}
 	if context != "" {
-		fields = append(fields, "context", context)
+		fields = append(fields, "context", truncateContext(context, l.maxContextLen))
+	}
+	l.emit(level, message, fields)
+}
+
+// logKV writes msg at level with the context and the key/value pairs kv as
+// structured attributes, as for slog.Logger.Info.
+func (l *Logger) logKV(level LogLevel, context string, msg string, kv ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if level < l.level {
+		return
+	}
+
+	fields := make([]interface{}, 0, len(kv)+2)
+	if context != "" {
+		fields = append(fields, "context", truncateContext(context, l.maxContextLen))
 	}
+	fields = append(fields, kv...)
+	l.emit(level, msg, fields)
+}
 
-	// Log using slog
+// emit logs message and fields with slog at level; the caller must hold l.mu
+func (l *Logger) emit(level LogLevel, message string, fields []interface{}) {
 	switch level {
 	case LevelDebug:
 		l.slogLogger.Debug(message, fields...)
@@ -194,6 +337,72 @@ func (l *Logger) Error(context string, format string, args ...interface{}) {
 	l.log(LevelError, context, format, args...)
 }
 
+// DebugKV logs a debug-level message with structured attributes given as
+// alternating keys and values (or slog.Attr values), as for slog.Logger.Debug.
+// Unlike Debug, msg is not a format string.
+//
+// Example:
+//
+//	logger.DebugKV("req:42", "cache lookup", "key", key, "hit", hit)
+func (l *Logger) DebugKV(context string, msg string, kv ...interface{}) {
+	l.logKV(LevelDebug, context, msg, kv...)
+}
+
+// InfoKV logs an info-level message with structured attributes, as for
+// DebugKV.
+func (l *Logger) InfoKV(context string, msg string, kv ...interface{}) {
+	l.logKV(LevelInfo, context, msg, kv...)
+}
+
+// WarnKV logs a warning-level message with structured attributes, as for
+// DebugKV.
+func (l *Logger) WarnKV(context string, msg string, kv ...interface{}) {
+	l.logKV(LevelWarn, context, msg, kv...)
+}
+
+// ErrorKV logs an error-level message with structured attributes, as for
+// DebugKV.
+func (l *Logger) ErrorKV(context string, msg string, kv ...interface{}) {
+	l.logKV(LevelError, context, msg, kv...)
+}
+
+// contextFromCtx formats the request ID and operation in ctx as a context
+// string ("req:<id> op:<operation>"), or "" if ctx carries neither
+func contextFromCtx(ctx context.Context) string {
+	var parts []string
+	if requestID := getRequestID(ctx); requestID != "" {
+		parts = append(parts, "req:"+requestID)
+	}
+	if operation := getOperation(ctx); operation != "" {
+		parts = append(parts, "op:"+operation)
+	}
+	return strings.Join(parts, " ")
+}
+
+// DebugCtx logs a debug-level message with the request ID and operation
+// from ctx (see WithRequestID and WithOperation) as its context.
+func (l *Logger) DebugCtx(ctx context.Context, format string, args ...interface{}) {
+	l.log(LevelDebug, contextFromCtx(ctx), format, args...)
+}
+
+// InfoCtx logs an info-level message with the request ID and operation
+// from ctx as its context.
+func (l *Logger) InfoCtx(ctx context.Context, format string, args ...interface{}) {
+	l.log(LevelInfo, contextFromCtx(ctx), format, args...)
+}
+
+// WarnCtx logs a warning-level message with the request ID and operation
+// from ctx as its context.
+func (l *Logger) WarnCtx(ctx context.Context, format string, args ...interface{}) {
+	l.log(LevelWarn, contextFromCtx(ctx), format, args...)
+}
+
+// ErrorCtx logs an error-level message with the request ID and operation
+// from ctx as its context.
+func (l *Logger) ErrorCtx(ctx context.Context, format string, args ...interface{}) {
+	l.log(LevelError, contextFromCtx(ctx), format, args...)
+}
+
 // LogRequest logs the start of a request with the given ID and method.
 func (l *Logger) LogRequest(requestID string, method string) {
 	l.Info(fmt.Sprintf("req:%s", requestID), "Processing request: %s", method)
@@ -209,14 +418,24 @@ func (l *Logger) LogRequestComplete(requestID string, method string, duration ti
 	}
 }
 
-// LogToolCall logs a tool call with parameters.
+// LogToolCall logs a tool call with parameters, masking the fields set
+// with SetRedactKeys.
 func (l *Logger) LogToolCall(requestID string, toolName string, params interface{}) {
+	l.LogToolCallRedacted(requestID, toolName, params)
+}
+
+// LogToolCallRedacted is LogToolCall also masking the fields named in keys
+// (case-insensitive) for this call only.
+func (l *Logger) LogToolCallRedacted(requestID string, toolName string, params interface{}, keys ...string) {
+	params = redact(params, l.redactKeySet(keys))
 	l.Debug(fmt.Sprintf("req:%s", requestID), "Tool call: %s with params: %v", toolName, params)
 }
 
-// LogToolCallComplete logs the completion of a tool call with duration.
+// LogToolCallComplete logs the completion of a tool call with duration
+// and records it for Metrics.
 func (l *Logger) LogToolCallComplete(requestID string, toolName string, duration time.Duration) {
 	context := fmt.Sprintf("req:%s", requestID)
+	l.metrics.record(ToolMetricPrefix+toolName, duration, duration > l.slowThreshold)
 	if duration > l.slowThreshold {
 		l.Warn(context, "Slow tool call: %s took %v", toolName, duration)
 	} else {
@@ -229,8 +448,9 @@ func (l *Logger) LogError(requestID string, operation string, err error) {
 	l.Error(fmt.Sprintf("req:%s", requestID), "%s failed: %v", operation, err)
 }
 
-// LogPerformance logs a performance metric.
+// LogPerformance logs a performance metric and records it for Metrics.
 func (l *Logger) LogPerformance(context string, operation string, duration time.Duration) {
+	l.metrics.record(operation, duration, duration > l.slowThreshold)
 	if duration > l.slowThreshold {
 		l.Warn(context, "Slow operation: %s took %v", operation, duration)
 	} else {
@@ -238,6 +458,11 @@ func (l *Logger) LogPerformance(context string, operation string, duration time.
 	}
 }
 
+// LogPerformanceCtx is LogPerformance taking its context from ctx.
+func (l *Logger) LogPerformanceCtx(ctx context.Context, operation string, duration time.Duration) {
+	l.LogPerformance(contextFromCtx(ctx), operation, duration)
+}
+
 // WithContext returns a logger that includes context information.
 // Extracts request ID, operation name, and other context fields.
 func (l *Logger) WithContext(ctx context.Context) *slog.Logger {
@@ -295,6 +520,11 @@ func WithRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, requestIDKey{}, requestID)
 }
 
+// RequestIDFromContext returns the request ID set with WithRequestID, or ""
+func RequestIDFromContext(ctx context.Context) string {
+	return getRequestID(ctx)
+}
+
 // WithOperation adds an operation name to the context
 func WithOperation(ctx context.Context, operation string) context.Context {
 	return context.WithValue(ctx, "operation", operation)
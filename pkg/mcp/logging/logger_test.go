@@ -3,6 +3,7 @@ package logging
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
@@ -80,6 +81,51 @@ func TestLogger_SetSlowThreshold(t *testing.T) {
 	}
 }
 
+func TestNewLogger_SlowThresholdFromEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "unset", value: "", want: DefaultSlowThreshold},
+		{name: "valid", value: "250ms", want: 250 * time.Millisecond},
+		{name: "seconds", value: "2s", want: 2 * time.Second},
+		{name: "invalid", value: "fast", want: DefaultSlowThreshold},
+		{name: "negative", value: "-1s", want: DefaultSlowThreshold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MCP_SLOW_THRESHOLD", tt.value)
+			logger := NewLogger()
+			if logger.slowThreshold != tt.want {
+				t.Errorf("slowThreshold = %v, want %v", logger.slowThreshold, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLogger_WarnsOnInvalidSlowThreshold(t *testing.T) {
+	tests := []struct {
+		value    string
+		wantWarn bool
+	}{
+		{value: "", wantWarn: false},
+		{value: "250ms", wantWarn: false},
+		{value: "fast", wantWarn: true},
+		{value: "-1s", wantWarn: true},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("MCP_SLOW_THRESHOLD", tt.value)
+		var buf bytes.Buffer
+		NewLoggerWithWriter(&buf)
+		if warned := strings.Contains(buf.String(), "MCP_SLOW_THRESHOLD"); warned != tt.wantWarn {
+			t.Errorf("MCP_SLOW_THRESHOLD=%q: warned = %v, want %v (output %q)", tt.value, warned, tt.wantWarn, buf.String())
+		}
+	}
+}
+
 func TestLogger_LogLevels(t *testing.T) {
 	var buf bytes.Buffer
 	logger := NewLogger()
@@ -209,6 +255,46 @@ func TestLogger_NoContext(t *testing.T) {
 	}
 }
 
+func TestLogger_ContextTruncation(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLoggerWithWriter(&buf)
+	logger.SetLevel(LevelInfo)
+
+	long := strings.Repeat("r", 1000)
+	logger.Info(long, "Test message")
+	output := buf.String()
+
+	want := "context=" + strings.Repeat("r", DefaultMaxContextLength-3) + "..."
+	if !strings.Contains(output, want+" ") && !strings.HasSuffix(strings.TrimSpace(output), want) {
+		t.Errorf("context not truncated to %d characters: %s", DefaultMaxContextLength, output)
+	}
+	if strings.Contains(output, strings.Repeat("r", DefaultMaxContextLength)) {
+		t.Error("log output contains the full context")
+	}
+
+	// Short contexts are logged as is
+	buf.Reset()
+	logger.SetMaxContextLength(10)
+	logger.Info("req:123", "Test message")
+	if !strings.Contains(buf.String(), "context=req:123") {
+		t.Errorf("short context changed: %s", buf.String())
+	}
+
+	// Truncation counts characters, not bytes
+	buf.Reset()
+	logger.Info(strings.Repeat("é", 20), "Test message")
+	if !strings.Contains(buf.String(), "context="+strings.Repeat("é", 7)+"...") {
+		t.Errorf("multi-byte context not truncated to 10 characters: %s", buf.String())
+	}
+
+	buf.Reset()
+	logger.SetMaxContextLength(0)
+	logger.Info(long, "Test message")
+	if !strings.Contains(buf.String(), "context="+long) {
+		t.Error("context truncated with the limit disabled")
+	}
+}
+
 func TestLogger_LogRequest(t *testing.T) {
 	var buf bytes.Buffer
 	logger := NewLogger()
@@ -478,6 +564,60 @@ func TestLogger_JSONOutput(t *testing.T) {
 	}
 }
 
+func TestLogger_KVMethods(t *testing.T) {
+	var buf bytes.Buffer
+	t.Setenv("LOG_FORMAT", "json")
+	logger := NewLoggerWithWriter(&buf)
+	logger.SetLevel(LevelDebug)
+
+	tests := []struct {
+		name  string
+		log   func(context, msg string, kv ...interface{})
+		level string
+	}{
+		{"DebugKV", logger.DebugKV, "DEBUG"},
+		{"InfoKV", logger.InfoKV, "INFO"},
+		{"WarnKV", logger.WarnKV, "WARN"},
+		{"ErrorKV", logger.ErrorKV, "ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.log("req:1", "cache lookup %s", "key", "user:7", "hits", 3, slog.Bool("fresh", true))
+
+			var entry map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("output is not a JSON object: %v\n%s", err, buf.String())
+			}
+			want := map[string]interface{}{
+				"level":   tt.level,
+				"msg":     "cache lookup %s", // not formatted
+				"context": "req:1",
+				"key":     "user:7",
+				"hits":    float64(3),
+				"fresh":   true,
+			}
+			for key, value := range want {
+				if entry[key] != value {
+					t.Errorf("%s = %#v, want %#v", key, entry[key], value)
+				}
+			}
+		})
+	}
+
+	// Levels below the logger's are dropped; an empty context is omitted
+	buf.Reset()
+	logger.SetLevel(LevelWarn)
+	logger.InfoKV("", "ignored", "key", "value")
+	if buf.Len() != 0 {
+		t.Errorf("InfoKV() below the level wrote %s", buf.String())
+	}
+	logger.WarnKV("", "kept", "key", "value")
+	if strings.Contains(buf.String(), `"context"`) {
+		t.Errorf("empty context logged: %s", buf.String())
+	}
+}
+
 func TestLogger_WithContext(t *testing.T) {
 	var buf bytes.Buffer
 	logger := NewLogger()
@@ -517,3 +657,165 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.message
 }
+
+func TestLogger_CtxMethods(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger()
+	opts := &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}
+	logger.slogLogger = slog.New(slog.NewTextHandler(&buf, opts))
+	logger.level = LevelDebug
+
+	ctx := WithRequestID(context.Background(), "abc123")
+	tests := []struct {
+		name  string
+		log   func(ctx context.Context, format string, args ...interface{})
+		level string
+	}{
+		{"DebugCtx", logger.DebugCtx, "DEBUG"},
+		{"InfoCtx", logger.InfoCtx, "INFO"},
+		{"WarnCtx", logger.WarnCtx, "WARN"},
+		{"ErrorCtx", logger.ErrorCtx, "ERROR"},
+	}
+	for _, tt := range tests {
+		buf.Reset()
+		tt.log(ctx, "handled %s", "tools/list")
+		output := buf.String()
+		if !strings.Contains(output, "level="+tt.level) {
+			t.Errorf("%s: level %s not found in %q", tt.name, tt.level, output)
+		}
+		if !strings.Contains(output, "context=req:abc123") {
+			t.Errorf("%s: request ID not found in %q", tt.name, output)
+		}
+		if !strings.Contains(output, "handled tools/list") {
+			t.Errorf("%s: message not found in %q", tt.name, output)
+		}
+	}
+
+	// Operation is included too; a bare context has no context field
+	buf.Reset()
+	logger.InfoCtx(WithOperation(ctx, "list"), "with operation")
+	if !strings.Contains(buf.String(), `context="req:abc123 op:list"`) {
+		t.Errorf("operation not found in %q", buf.String())
+	}
+	buf.Reset()
+	logger.InfoCtx(context.Background(), "no ids")
+	if strings.Contains(buf.String(), "context=") {
+		t.Errorf("unexpected context field in %q", buf.String())
+	}
+}
+
+func TestLogger_CtxMethodsLevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger()
+	logger.slogLogger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	logger.level = LevelWarn
+	logger.slowThreshold = 50 * time.Millisecond
+
+	ctx := WithRequestID(context.Background(), "abc123")
+	logger.DebugCtx(ctx, "debug")
+	logger.InfoCtx(ctx, "info")
+	logger.LogPerformanceCtx(ctx, "fast", 10*time.Millisecond)
+	if buf.Len() != 0 {
+		t.Errorf("messages below WARN were logged: %q", buf.String())
+	}
+
+	logger.LogPerformanceCtx(ctx, "slow", 100*time.Millisecond)
+	output := buf.String()
+	if !strings.Contains(output, "Slow operation: slow took") || !strings.Contains(output, "context=req:abc123") {
+		t.Errorf("slow operation not logged with request ID: %q", output)
+	}
+}
+
+func TestNewLoggerWithWriter(t *testing.T) {
+	t.Setenv("MCP_DEBUG", "")
+	t.Setenv("GIT_HOOK", "")
+	t.Setenv("LOG_FORMAT", "")
+
+	var buf bytes.Buffer
+	logger := NewLoggerWithWriter(&buf)
+	logger.Info("req:1", "hello %s", "world")
+	logger.Debug("", "hidden")
+
+	output := buf.String()
+	if !strings.Contains(output, "level=INFO") || !strings.Contains(output, `msg="hello world"`) || !strings.Contains(output, "context=req:1") {
+		t.Errorf("unexpected text output: %q", output)
+	}
+	if strings.Contains(output, "hidden") {
+		t.Errorf("debug message logged at INFO level: %q", output)
+	}
+}
+
+func TestLogger_SetOutput(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "json")
+
+	var first, second bytes.Buffer
+	logger := NewLoggerWithWriter(&first)
+	logger.SetLevel(LevelDebug)
+	logger.Debug("", "one")
+
+	// SetOutput keeps the level and format
+	logger.SetOutput(&second)
+	logger.Debug("", "two")
+
+	if !strings.Contains(first.String(), `"msg":"one"`) || strings.Contains(first.String(), "two") {
+		t.Errorf("first output = %q", first.String())
+	}
+	if !strings.Contains(second.String(), `"msg":"two"`) || !strings.Contains(second.String(), `"level":"DEBUG"`) {
+		t.Errorf("second output = %q, want JSON debug line", second.String())
+	}
+
+	// SetLevel keeps the writer
+	logger.SetLevel(LevelWarn)
+	logger.Warn("", "three")
+	logger.Info("", "four")
+	if !strings.Contains(second.String(), `"msg":"three"`) || strings.Contains(second.String(), "four") {
+		t.Errorf("output after SetLevel = %q", second.String())
+	}
+}
+
+func TestLogger_With(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "json")
+	t.Setenv("MCP_DEBUG", "")
+	t.Setenv("GIT_HOOK", "")
+
+	var buf bytes.Buffer
+	parent := NewLoggerWithWriter(&buf)
+	child := parent.With("a", 1).With("b", "two")
+
+	child.Info("req:1", "from child")
+	line := buf.String()
+	for _, want := range []string{`"a":1`, `"b":"two"`, `"msg":"from child"`, `"context":"req:1"`} {
+		if !strings.Contains(line, want) {
+			t.Errorf("child output missing %s: %q", want, line)
+		}
+	}
+
+	buf.Reset()
+	parent.Info("", "from parent")
+	if strings.Contains(buf.String(), `"a":`) || strings.Contains(buf.String(), `"b":`) {
+		t.Errorf("parent output has child fields: %q", buf.String())
+	}
+
+	// The child's settings are its own, and survive a handler rebuild
+	child.SetLevel(LevelDebug)
+	if parent.Level() != LevelInfo {
+		t.Errorf("child SetLevel changed parent level to %v", parent.Level())
+	}
+	buf.Reset()
+	child.Debug("", "debug from child")
+	parent.Debug("", "debug from parent")
+	if !strings.Contains(buf.String(), `"msg":"debug from child"`) || !strings.Contains(buf.String(), `"a":1`) {
+		t.Errorf("child debug output = %q, want fields kept after SetLevel", buf.String())
+	}
+	if strings.Contains(buf.String(), "debug from parent") {
+		t.Errorf("parent logged at DEBUG: %q", buf.String())
+	}
+
+	// Metrics are shared
+	child.LogPerformance("", "op", time.Millisecond)
+	if stats := parent.Metrics().Operations["op"]; stats.Count != 1 {
+		t.Errorf("parent metrics count = %d, want 1", stats.Count)
+	}
+}
@@ -0,0 +1,142 @@
+package logging
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxMetricSamples bounds the durations kept per operation for percentile
+// calculation. Older samples are overwritten once the limit is reached, so
+// percentiles reflect recent behavior while counts cover all calls.
+const maxMetricSamples = 1024
+
+// ToolMetricPrefix prefixes tool names in PerfMetrics so tool calls recorded
+// by LogToolCallComplete don't collide with LogPerformance operation names
+const ToolMetricPrefix = "tool:"
+
+// OperationStats summarizes recorded durations for one operation
+type OperationStats struct {
+	Count int           // Total calls recorded
+	Slow  int           // Calls that exceeded the slow threshold
+	Total time.Duration // Sum of all recorded durations
+	Min   time.Duration
+	Max   time.Duration
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+}
+
+// Mean returns the average duration, or 0 if nothing was recorded
+func (s OperationStats) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// PerfMetrics is a point-in-time snapshot of performance metrics by operation
+type PerfMetrics struct {
+	Operations map[string]OperationStats
+}
+
+// operationSamples accumulates durations for one operation
+type operationSamples struct {
+	stats   OperationStats
+	samples []time.Duration // ring buffer of recent durations
+	next    int
+}
+
+// perfRecorder accumulates operation durations for Logger.Metrics
+type perfRecorder struct {
+	mu         sync.Mutex
+	operations map[string]*operationSamples
+}
+
+func newPerfRecorder() *perfRecorder {
+	return &perfRecorder{operations: make(map[string]*operationSamples)}
+}
+
+// record adds a duration sample for the named operation
+func (r *perfRecorder) record(operation string, duration time.Duration, slow bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	op, ok := r.operations[operation]
+	if !ok {
+		op = &operationSamples{stats: OperationStats{Min: duration, Max: duration}}
+		r.operations[operation] = op
+	}
+
+	op.stats.Count++
+	op.stats.Total += duration
+	if slow {
+		op.stats.Slow++
+	}
+	if duration < op.stats.Min {
+		op.stats.Min = duration
+	}
+	if duration > op.stats.Max {
+		op.stats.Max = duration
+	}
+
+	if len(op.samples) < maxMetricSamples {
+		op.samples = append(op.samples, duration)
+	} else {
+		op.samples[op.next] = duration
+		op.next = (op.next + 1) % maxMetricSamples
+	}
+}
+
+// snapshot computes percentiles and returns a copy of all stats
+func (r *perfRecorder) snapshot() PerfMetrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	metrics := PerfMetrics{Operations: make(map[string]OperationStats, len(r.operations))}
+	for name, op := range r.operations {
+		sorted := make([]time.Duration, len(op.samples))
+		copy(sorted, op.samples)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		stats := op.stats
+		stats.P50 = percentile(sorted, 50)
+		stats.P90 = percentile(sorted, 90)
+		stats.P99 = percentile(sorted, 99)
+		metrics.Operations[name] = stats
+	}
+	return metrics
+}
+
+// reset discards all recorded samples
+func (r *perfRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.operations = make(map[string]*operationSamples)
+}
+
+// percentile returns the nearest-rank percentile p (0-100) of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Metrics returns a snapshot of operation durations recorded by
+// LogPerformance and LogToolCallComplete (tool names are prefixed with
+// ToolMetricPrefix). Percentiles are computed over the most recent
+// samples of each operation.
+func (l *Logger) Metrics() PerfMetrics {
+	return l.metrics.snapshot()
+}
+
+// ResetMetrics discards all recorded performance metrics
+func (l *Logger) ResetMetrics() {
+	l.metrics.reset()
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLogger_Metrics(t *testing.T) {
+	t.Setenv("MCP_SLOW_THRESHOLD", "")
+	logger := NewLogger()
+	logger.SetLevel(LevelError) // keep test output quiet
+
+	// 1ms..100ms for "query"
+	for i := 1; i <= 100; i++ {
+		logger.LogPerformance("", "query", time.Duration(i)*time.Millisecond)
+	}
+	logger.LogToolCallComplete("1", "search", 20*time.Millisecond)
+	logger.LogToolCallComplete("2", "search", 40*time.Millisecond)
+
+	metrics := logger.Metrics()
+
+	query, ok := metrics.Operations["query"]
+	if !ok {
+		t.Fatal("Metrics() missing operation \"query\"")
+	}
+	tests := []struct {
+		name string
+		got  time.Duration
+		want time.Duration
+	}{
+		{"Min", query.Min, 1 * time.Millisecond},
+		{"Max", query.Max, 100 * time.Millisecond},
+		{"P50", query.P50, 50 * time.Millisecond},
+		{"P90", query.P90, 90 * time.Millisecond},
+		{"P99", query.P99, 99 * time.Millisecond},
+		{"Mean", query.Mean(), 50500 * time.Microsecond},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("query.%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if query.Count != 100 {
+		t.Errorf("query.Count = %d, want 100", query.Count)
+	}
+	// Default threshold is 100ms and only strictly slower calls count as slow
+	if query.Slow != 0 {
+		t.Errorf("query.Slow = %d, want 0", query.Slow)
+	}
+
+	search := metrics.Operations[ToolMetricPrefix+"search"]
+	if search.Count != 2 || search.P50 != 20*time.Millisecond || search.Max != 40*time.Millisecond {
+		t.Errorf("tool:search stats = %+v, want 2 calls with P50 20ms and Max 40ms", search)
+	}
+
+	logger.ResetMetrics()
+	if n := len(logger.Metrics().Operations); n != 0 {
+		t.Errorf("Metrics() after ResetMetrics has %d operations, want 0", n)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{10, 20, 30, 40}
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 10},
+		{25, 10},
+		{50, 20},
+		{75, 30},
+		{100, 40},
+	}
+	for _, tt := range tests {
+		if got := percentile(sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("percentile(nil) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,113 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// RedactedValue replaces the values of redacted fields in logged tool params
+const RedactedValue = "***REDACTED***"
+
+// SetRedactKeys sets the field names (case-insensitive) whose values
+// LogToolCall masks with RedactedValue, at any depth of nested maps, e.g.
+// "password" or "token". Passing no keys turns redaction off.
+func (l *Logger) SetRedactKeys(keys []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(keys) == 0 {
+		l.redactKeys = nil
+		return
+	}
+	l.redactKeys = make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		l.redactKeys[strings.ToLower(key)] = struct{}{}
+	}
+}
+
+// redactKeySet returns the configured redact keys plus extra, or nil if
+// there are none
+func (l *Logger) redactKeySet(extra []string) map[string]struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(extra) == 0 {
+		return l.redactKeys
+	}
+	keys := make(map[string]struct{}, len(l.redactKeys)+len(extra))
+	for key := range l.redactKeys {
+		keys[key] = struct{}{}
+	}
+	for _, key := range extra {
+		keys[strings.ToLower(key)] = struct{}{}
+	}
+	return keys
+}
+
+// redact returns a copy of value with the values of fields named in keys
+// replaced by RedactedValue, recursing into maps and slices. JSON objects
+// and arrays given as a string or json.RawMessage (such as raw tool
+// arguments) are decoded, redacted and returned as a JSON string. Other
+// values, and value itself if keys is empty, are returned unchanged.
+func redact(value interface{}, keys map[string]struct{}) interface{} {
+	if len(keys) == 0 {
+		return value
+	}
+	switch v := value.(type) {
+	case string:
+		if redacted, ok := redactJSON([]byte(v), keys); ok {
+			return redacted
+		}
+		return v
+	case json.RawMessage:
+		if redacted, ok := redactJSON(v, keys); ok {
+			return redacted
+		}
+		return string(v)
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for name, field := range v {
+			if _, ok := keys[strings.ToLower(name)]; ok {
+				redacted[name] = RedactedValue
+			} else {
+				redacted[name] = redact(field, keys)
+			}
+		}
+		return redacted
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for name, field := range v {
+			if _, ok := keys[strings.ToLower(name)]; ok {
+				redacted[name] = RedactedValue
+			} else {
+				redacted[name] = field
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = redact(item, keys)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+// redactJSON redacts a JSON object or array, reporting false if data is not
+// one
+func redactJSON(data []byte, keys map[string]struct{}) (string, bool) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return "", false
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(trimmed, &decoded); err != nil {
+		return "", false
+	}
+	redacted, err := json.Marshal(redact(decoded, keys))
+	if err != nil {
+		return "", false
+	}
+	return string(redacted), true
+}
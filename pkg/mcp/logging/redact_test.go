@@ -0,0 +1,117 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// newDebugLogger returns a text logger at DEBUG level writing to buf
+func newDebugLogger(t *testing.T, buf *bytes.Buffer) *Logger {
+	t.Helper()
+	t.Setenv("LOG_FORMAT", "")
+	logger := NewLoggerWithWriter(buf)
+	logger.SetLevel(LevelDebug)
+	return logger
+}
+
+func TestLogger_SetRedactKeys(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newDebugLogger(t, &buf)
+	logger.SetRedactKeys([]string{"Password", "token"})
+
+	logger.LogToolCall("1", "login", map[string]interface{}{
+		"user":     "alice",
+		"PASSWORD": "hunter2",
+		"auth": map[string]interface{}{
+			"token":  "s3cret",
+			"scheme": "bearer",
+		},
+		"items": []interface{}{map[string]interface{}{"token": "nested-s3cret"}},
+	})
+
+	output := buf.String()
+	for _, secret := range []string{"hunter2", "s3cret"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("output leaks %q: %q", secret, output)
+		}
+	}
+	for _, masked := range []string{"PASSWORD:" + RedactedValue, "token:" + RedactedValue + "]"} {
+		if !strings.Contains(output, masked) {
+			t.Errorf("output missing %q: %q", masked, output)
+		}
+	}
+	for _, kept := range []string{"user:alice", "scheme:bearer"} {
+		if !strings.Contains(output, kept) {
+			t.Errorf("output missing %q: %q", kept, output)
+		}
+	}
+}
+
+func TestLogger_RedactJSONParams(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newDebugLogger(t, &buf)
+	logger.SetRedactKeys([]string{"token"})
+
+	logger.LogToolCall("1", "call", `{"query":"x","token":"s3cret"}`)
+	logger.LogToolCall("2", "call", json.RawMessage(`[{"token":"raw-s3cret"}]`))
+	logger.LogToolCall("3", "call", "plain text token")
+
+	output := strings.ReplaceAll(buf.String(), `\"`, `"`) // unquote the text format
+	if strings.Contains(output, "s3cret") {
+		t.Errorf("output leaks a token: %q", output)
+	}
+	for _, want := range []string{`{"query":"x","token":"` + RedactedValue + `"}`, `[{"token":"` + RedactedValue + `"}]`, "plain text token"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q: %q", want, output)
+		}
+	}
+}
+
+func TestLogger_RedactionDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newDebugLogger(t, &buf)
+
+	params := map[string]interface{}{"password": "hunter2"}
+	logger.LogToolCall("1", "login", params)
+	if !strings.Contains(buf.String(), "password:hunter2") {
+		t.Errorf("without redact keys params should be logged as is: %q", buf.String())
+	}
+
+	// Clearing the keys turns redaction back off
+	buf.Reset()
+	logger.SetRedactKeys([]string{"password"})
+	logger.SetRedactKeys(nil)
+	logger.LogToolCall("1", "login", params)
+	if !strings.Contains(buf.String(), "password:hunter2") {
+		t.Errorf("after SetRedactKeys(nil) params should be logged as is: %q", buf.String())
+	}
+}
+
+func TestLogger_LogToolCallRedacted(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newDebugLogger(t, &buf)
+	logger.SetRedactKeys([]string{"password"})
+
+	params := map[string]interface{}{"password": "hunter2", "api_key": "abc123", "query": "weather"}
+	logger.LogToolCallRedacted("1", "search", params, "API_KEY")
+
+	output := buf.String()
+	if strings.Contains(output, "hunter2") || strings.Contains(output, "abc123") {
+		t.Errorf("output leaks a secret: %q", output)
+	}
+	if !strings.Contains(output, "query:weather") {
+		t.Errorf("output missing unredacted field: %q", output)
+	}
+
+	// The extra keys apply to that call only, and params is not modified
+	if params["password"] != "hunter2" {
+		t.Error("redaction modified the caller's params")
+	}
+	buf.Reset()
+	logger.LogToolCall("2", "search", params)
+	if !strings.Contains(buf.String(), "api_key:abc123") {
+		t.Errorf("extra keys leaked into later calls: %q", buf.String())
+	}
+}
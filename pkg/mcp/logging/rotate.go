@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// RotatingWriter is an io.Writer appending to a file that is rotated by
+// size: when a write would take the file past maxBytes, it is renamed to
+// path.1 (shifting path.1 to path.2 and so on) and a new file is started.
+// Only maxBackups rotated files are kept. It is safe for concurrent use.
+//
+// Pass it to NewLoggerWithWriter or SetOutput to keep long-running servers
+// from filling the disk:
+//
+//	w, err := logging.NewRotatingWriter("/var/log/server.log", 10<<20, 5)
+//	if err != nil {
+//	    return err
+//	}
+//	defer w.Close()
+//	logger := logging.NewLoggerWithWriter(w)
+type RotatingWriter struct {
+	mu         sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	file       *os.File
+	size       int64 // bytes in the active file
+}
+
+// NewRotatingWriter opens path for appending, creating it if needed.
+// maxBytes must be positive; maxBackups may be 0 to discard rotated data.
+// A single write larger than maxBytes is not split, so a file may exceed
+// maxBytes by one write.
+func NewRotatingWriter(path string, maxBytes int64, maxBackups int) (*RotatingWriter, error) {
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("maxBytes must be positive, got %d", maxBytes)
+	}
+	if maxBackups < 0 {
+		return nil, fmt.Errorf("maxBackups cannot be negative, got %d", maxBackups)
+	}
+
+	w := &RotatingWriter{path: path, maxBytes: maxBytes, maxBackups: maxBackups}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write appends p to the active file, rotating first if p would take it
+// past maxBytes.
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+	if w.size > 0 && w.size+int64(len(p)) > w.maxBytes {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the active file. Writes after Close fail.
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// open opens the active file for appending; the caller must hold w.mu (or
+// own w exclusively)
+func (w *RotatingWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+// rotate shifts the backups, moves the active file to path.1 (or removes
+// it if no backups are kept) and opens a new active file; the caller must
+// hold w.mu. If shifting fails, the active file is reopened for appending,
+// so later writes still succeed, and the rotation error is returned.
+func (w *RotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	w.file = nil
+
+	if err := w.shift(); err != nil {
+		if openErr := w.open(); openErr != nil {
+			return errors.Join(err, openErr)
+		}
+		return err
+	}
+	return w.open()
+}
+
+// shift moves the closed active file and its backups along by one, dropping
+// the oldest; the caller must hold w.mu
+func (w *RotatingWriter) shift() error {
+	if w.maxBackups == 0 {
+		if err := os.Remove(w.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove log file: %w", err)
+		}
+		return nil
+	}
+
+	// The oldest backup falls off the end
+	if err := os.Remove(w.backupPath(w.maxBackups)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove old log backup: %w", err)
+	}
+	for i := w.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(w.backupPath(i), w.backupPath(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to rotate log backup: %w", err)
+		}
+	}
+	if err := os.Rename(w.path, w.backupPath(1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return nil
+}
+
+// backupPath returns the path of the nth most recent backup
+func (w *RotatingWriter) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", w.path, n)
+}
@@ -0,0 +1,197 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fileSize returns the size of path, failing the test if it does not exist
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat %s: %v", path, err)
+	}
+	return info.Size()
+}
+
+func TestRotatingWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	w, err := NewRotatingWriter(path, 100, 2)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error = %v", err)
+	}
+	defer w.Close()
+
+	// Two 40-byte records fit in 100 bytes, so 6 records rotate twice
+	record := []byte(strings.Repeat("x", 39) + "\n")
+	for i := 0; i < 6; i++ {
+		if _, err := w.Write(record); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		if size := fileSize(t, p); size != 80 {
+			t.Errorf("%s size = %d, want 80", filepath.Base(p), size)
+		}
+	}
+
+	// A third rotation drops the oldest backup beyond maxBackups
+	if _, err := w.Write(record); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if size := fileSize(t, path); size != 40 {
+		t.Errorf("active file size = %d, want 40", size)
+	}
+	if _, err := os.Stat(path + ".3"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("backup beyond maxBackups exists (stat error = %v)", err)
+	}
+}
+
+func TestRotatingWriter_AppendsToExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	if err := os.WriteFile(path, bytes.Repeat([]byte("x"), 90), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewRotatingWriter(path, 100, 1)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error = %v", err)
+	}
+	defer w.Close()
+
+	// The existing 90 bytes count towards maxBytes
+	if _, err := w.Write([]byte("0123456789abc")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if size := fileSize(t, path+".1"); size != 90 {
+		t.Errorf("backup size = %d, want 90", size)
+	}
+	if size := fileSize(t, path); size != 13 {
+		t.Errorf("active file size = %d, want 13", size)
+	}
+}
+
+func TestRotatingWriter_NoBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	w, err := NewRotatingWriter(path, 10, 0)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error = %v", err)
+	}
+	defer w.Close()
+
+	w.Write([]byte("123456789\n"))
+	w.Write([]byte("abc\n"))
+	data, _ := os.ReadFile(path)
+	if string(data) != "abc\n" {
+		t.Errorf("active file = %q, want only the latest write", data)
+	}
+	if _, err := os.Stat(path + ".1"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("backup exists with maxBackups 0 (stat error = %v)", err)
+	}
+}
+
+func TestRotatingWriter_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewRotatingWriter(filepath.Join(dir, "a.log"), 0, 1); err == nil {
+		t.Error("NewRotatingWriter() with maxBytes 0 should fail")
+	}
+	if _, err := NewRotatingWriter(filepath.Join(dir, "a.log"), 10, -1); err == nil {
+		t.Error("NewRotatingWriter() with negative maxBackups should fail")
+	}
+	if _, err := NewRotatingWriter(filepath.Join(dir, "missing", "a.log"), 10, 1); err == nil {
+		t.Error("NewRotatingWriter() in a missing directory should fail")
+	}
+
+	w, err := NewRotatingWriter(filepath.Join(dir, "b.log"), 10, 1)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error = %v", err)
+	}
+	w.Close()
+	if _, err := w.Write([]byte("x")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Write() after Close error = %v, want os.ErrClosed", err)
+	}
+}
+
+func TestRotatingWriter_RotateErrorKeepsWriting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	w, err := NewRotatingWriter(path, 10, 1)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error = %v", err)
+	}
+	defer w.Close()
+
+	// A non-empty directory where the backup goes can't be removed
+	if err := os.MkdirAll(filepath.Join(path+".1", "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	w.Write([]byte("123456789\n"))
+	if _, err := w.Write([]byte("abc\n")); err == nil {
+		t.Fatal("Write() needing a failed rotation should return the error")
+	}
+
+	// The active file was reopened, so the writer is still usable
+	if err := os.RemoveAll(path + ".1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("def\n")); err != nil {
+		t.Fatalf("Write() after a failed rotation error = %v", err)
+	}
+	backup, _ := os.ReadFile(path + ".1")
+	active, _ := os.ReadFile(path)
+	if string(backup) != "123456789\n" || string(active) != "def\n" {
+		t.Errorf("backup = %q, active = %q; want the first write rotated out", backup, active)
+	}
+}
+
+func TestRotatingWriter_ConcurrentLogger(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "server.log")
+	w, err := NewRotatingWriter(path, 1024, 100)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error = %v", err)
+	}
+	logger := NewLoggerWithWriter(w)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				logger.Info("", "worker %d line %d", i, j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	w.Close()
+
+	// Every line lands whole in exactly one file
+	files, _ := filepath.Glob(path + "*")
+	if len(files) < 3 {
+		t.Fatalf("got %d log files, want several rotations", len(files))
+	}
+	lines := 0
+	for _, file := range files {
+		if size := fileSize(t, file); size > 1024 {
+			t.Errorf("%s size = %d, want at most 1024", filepath.Base(file), size)
+		}
+		data, _ := os.ReadFile(file)
+		for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+			if !strings.HasPrefix(line, "time=") {
+				t.Errorf("%s has a broken line: %q", filepath.Base(file), line)
+			}
+			lines++
+		}
+	}
+	if lines != 400 {
+		t.Errorf("got %d lines, want 400", lines)
+	}
+}
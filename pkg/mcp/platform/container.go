@@ -0,0 +1,58 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// cgroupMarkers are substrings of /proc/1/cgroup entries that indicate the
+// process runs under a container runtime
+var cgroupMarkers = []string{"docker", "kubepods", "containerd", "libpod", "lxc"}
+
+// IsContainer reports whether the process appears to run in a container
+// (Docker, Podman, Kubernetes, LXC and similar).
+//
+// It checks, in order:
+//   - KUBERNETES_SERVICE_HOST, set in every Kubernetes pod
+//   - the "container" variable set by Podman, systemd-nspawn and LXC
+//   - the /.dockerenv and /run/.containerenv marker files
+//   - container runtime paths in /proc/1/cgroup (cgroup v1)
+//
+// Detection is best effort: a container with none of these markers (e.g. a
+// cgroup v2 Docker container started with a scrubbed environment and the
+// marker file removed) is reported as false.
+func IsContainer() bool {
+	return isContainer("/", os.Getenv)
+}
+
+// isContainer implements IsContainer against a filesystem rooted at root
+func isContainer(root string, getenv func(string) string) bool {
+	if getenv("KUBERNETES_SERVICE_HOST") != "" || getenv("container") != "" {
+		return true
+	}
+
+	for _, marker := range []string{".dockerenv", "run/.containerenv"} {
+		if _, err := os.Stat(filepath.Join(root, marker)); err == nil {
+			return true
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "proc", "1", "cgroup"))
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		// Format: hierarchy-ID:controllers:path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		for _, marker := range cgroupMarkers {
+			if strings.Contains(parts[2], marker) {
+				return true
+			}
+		}
+	}
+	return false
+}
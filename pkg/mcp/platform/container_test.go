@@ -0,0 +1,81 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsContainer(t *testing.T) {
+	noEnv := func(string) string { return "" }
+
+	tests := []struct {
+		name   string
+		files  map[string]string // relative path -> content
+		env    map[string]string
+		expect bool
+	}{
+		{
+			name:   "bare host",
+			files:  map[string]string{"proc/1/cgroup": "12:cpu,cpuacct:/\n0::/init.scope\n"},
+			expect: false,
+		},
+		{
+			name:   "no markers or cgroup file",
+			expect: false,
+		},
+		{
+			name:   "dockerenv",
+			files:  map[string]string{".dockerenv": ""},
+			expect: true,
+		},
+		{
+			name:   "podman containerenv",
+			files:  map[string]string{"run/.containerenv": ""},
+			expect: true,
+		},
+		{
+			name:   "docker cgroup",
+			files:  map[string]string{"proc/1/cgroup": "12:cpu,cpuacct:/docker/0123456789abcdef\n"},
+			expect: true,
+		},
+		{
+			name:   "kubernetes cgroup",
+			files:  map[string]string{"proc/1/cgroup": "11:memory:/kubepods/burstable/pod1234/abcd\n"},
+			expect: true,
+		},
+		{
+			name:   "kubernetes env",
+			env:    map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1"},
+			expect: true,
+		},
+		{
+			name:   "container env",
+			env:    map[string]string{"container": "podman"},
+			expect: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for rel, content := range tt.files {
+				path := filepath.Join(root, rel)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			getenv := noEnv
+			if tt.env != nil {
+				getenv = func(key string) string { return tt.env[key] }
+			}
+
+			if got := isContainer(root, getenv); got != tt.expect {
+				t.Errorf("isContainer() = %v, want %v", got, tt.expect)
+			}
+		})
+	}
+}
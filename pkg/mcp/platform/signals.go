@@ -0,0 +1,31 @@
+package platform
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+// ShutdownSignals returns the signals that request a graceful shutdown on the
+// current platform
+func ShutdownSignals() []os.Signal {
+	signals := make([]os.Signal, len(shutdownSignals))
+	copy(signals, shutdownSignals)
+	return signals
+}
+
+// NotifyShutdown returns a copy of parent that is canceled when a shutdown
+// signal (see ShutdownSignals) is received, when parent is done, or when the
+// returned cancel function is called, whichever happens first.
+//
+// Calling cancel stops the signal registration, restoring the default
+// behavior; it should be called as soon as the context is no longer needed.
+//
+// Example:
+//
+//	ctx, cancel := platform.NotifyShutdown(context.Background())
+//	defer cancel()
+//	<-ctx.Done() // SIGINT or SIGTERM received
+func NotifyShutdown(parent context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(parent, shutdownSignals...)
+}
@@ -0,0 +1,12 @@
+//go:build !windows
+
+package platform
+
+import (
+	"os"
+	"syscall"
+)
+
+// shutdownSignals are SIGINT (Ctrl+C) and SIGTERM (sent by process managers
+// such as systemd, Docker and Kubernetes)
+var shutdownSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
@@ -0,0 +1,64 @@
+//go:build !windows
+
+package platform
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestShutdownSignals(t *testing.T) {
+	signals := ShutdownSignals()
+	want := map[os.Signal]bool{os.Interrupt: true, syscall.SIGTERM: true}
+	if len(signals) != len(want) {
+		t.Fatalf("ShutdownSignals() = %v, want SIGINT and SIGTERM", signals)
+	}
+	for _, sig := range signals {
+		if !want[sig] {
+			t.Errorf("unexpected shutdown signal %v", sig)
+		}
+	}
+}
+
+func TestNotifyShutdown_Signal(t *testing.T) {
+	for _, sig := range []syscall.Signal{syscall.SIGINT, syscall.SIGTERM} {
+		t.Run(sig.String(), func(t *testing.T) {
+			ctx, cancel := NotifyShutdown(context.Background())
+			defer cancel()
+
+			if err := syscall.Kill(os.Getpid(), sig); err != nil {
+				t.Fatalf("failed to send %v: %v", sig, err)
+			}
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(5 * time.Second):
+				t.Fatalf("context not canceled after %v", sig)
+			}
+		})
+	}
+}
+
+func TestNotifyShutdown_ParentCanceled(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := NotifyShutdown(parent)
+	defer cancel()
+
+	cancelParent()
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context not canceled with its parent")
+	}
+}
+
+func TestNotifyShutdown_Cancel(t *testing.T) {
+	ctx, cancel := NotifyShutdown(context.Background())
+	cancel()
+	if ctx.Err() == nil {
+		t.Error("context not canceled by cancel func")
+	}
+}
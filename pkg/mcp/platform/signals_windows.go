@@ -0,0 +1,13 @@
+//go:build windows
+
+package platform
+
+import (
+	"os"
+	"syscall"
+)
+
+// shutdownSignals are os.Interrupt (Ctrl+C and Ctrl+Break) and SIGTERM.
+// Windows has no real SIGTERM; the Go runtime delivers it for the console
+// close, logoff and shutdown events.
+var shutdownSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
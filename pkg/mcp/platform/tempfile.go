@@ -0,0 +1,43 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+)
+
+// SecurePerm returns the file mode for files only the current user may read
+// and write.
+//
+// On Unix this is 0600. Windows ignores permission bits other than the write
+// bit (files without it are read-only), so 0600 only keeps the file writable
+// there; access control comes from the ACL inherited from the parent
+// directory. Create sensitive files under the user's profile or TempFile's
+// directory (%TEMP%, which is per-user) rather than a shared location.
+func SecurePerm() os.FileMode {
+	return 0600
+}
+
+// TempFile creates a new temporary file readable and writable only by the
+// current user (see SecurePerm) and opens it for reading and writing.
+//
+// The file is created in the OS temporary directory: $TMPDIR (or /tmp) on
+// Unix and %TMP%, %TEMP% or %USERPROFILE% on Windows. The pattern is used as
+// for os.CreateTemp: a "*" in it is replaced by a random string, otherwise
+// the random string is appended. The caller is responsible for removing the
+// file when done.
+func TempFile(pattern string) (*os.File, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	// os.CreateTemp already uses 0600 (less umask), but set it explicitly so
+	// the result does not depend on that implementation detail
+	if err := file.Chmod(SecurePerm()); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, fmt.Errorf("failed to set temp file permissions: %w", err)
+	}
+
+	return file, nil
+}
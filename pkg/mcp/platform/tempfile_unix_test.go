@@ -0,0 +1,54 @@
+//go:build !windows
+
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSecurePerm(t *testing.T) {
+	if got := SecurePerm(); got != 0600 {
+		t.Errorf("SecurePerm() = %o, want 600", got)
+	}
+}
+
+func TestTempFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+
+	file, err := TempFile("mcp-*.json")
+	if err != nil {
+		t.Fatalf("TempFile() error = %v", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if filepath.Dir(file.Name()) != dir {
+		t.Errorf("TempFile() created %q, want it in $TMPDIR %q", file.Name(), dir)
+	}
+	base := filepath.Base(file.Name())
+	if !strings.HasPrefix(base, "mcp-") || !strings.HasSuffix(base, ".json") {
+		t.Errorf("TempFile() name = %q, want it to match mcp-*.json", base)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != SecurePerm() {
+		t.Errorf("TempFile() perm = %o, want %o", perm, SecurePerm())
+	}
+
+	if _, err := file.WriteString("data"); err != nil {
+		t.Errorf("WriteString() error = %v", err)
+	}
+}
+
+func TestTempFile_InvalidPattern(t *testing.T) {
+	if _, err := TempFile("bad/pattern"); err == nil {
+		t.Error("TempFile() with path separator in pattern should fail")
+	}
+}
@@ -44,6 +44,12 @@ type JSONRPCError struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// Error implements the error interface, so a JSON-RPC error response can be
+// returned as a Go error
+func (e *JSONRPCError) Error() string {
+	return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
 // JSON-RPC 2.0 error codes
 const (
 	ErrCodeParseError     = -32700
@@ -53,6 +59,31 @@ const (
 	ErrCodeInternalError  = -32603
 )
 
+// LatestProtocolVersion is the newest MCP protocol revision supported
+const LatestProtocolVersion = "2025-06-18"
+
+// supportedProtocolVersions lists every supported revision, newest first
+var supportedProtocolVersions = []string{LatestProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// SupportedProtocolVersions returns the MCP protocol revisions this library
+// can speak, newest first. A client requests the first; a server may answer
+// with any of them.
+func SupportedProtocolVersions() []string {
+	versions := make([]string, len(supportedProtocolVersions))
+	copy(versions, supportedProtocolVersions)
+	return versions
+}
+
+// IsSupportedProtocolVersion reports whether version is a supported revision
+func IsSupportedProtocolVersion(version string) bool {
+	for _, supported := range supportedProtocolVersions {
+		if version == supported {
+			return true
+		}
+	}
+	return false
+}
+
 // InitializeParams represents the initialize request parameters
 type InitializeParams struct {
 	ProtocolVersion string             `json:"protocolVersion"`
@@ -61,10 +92,22 @@ type InitializeParams struct {
 }
 
 // ClientCapabilities represents client capabilities
+// A nil field means the client does not offer that feature.
 type ClientCapabilities struct {
+	Roots        *RootsCapability       `json:"roots,omitempty"`
+	Sampling     *SamplingCapability    `json:"sampling,omitempty"`
 	Experimental map[string]interface{} `json:"experimental,omitempty"`
 }
 
+// RootsCapability declares that the client can list filesystem roots
+type RootsCapability struct {
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// SamplingCapability declares that the client can sample from an LLM on
+// the server's behalf
+type SamplingCapability struct{}
+
 // ClientInfo represents client information
 type ClientInfo struct {
 	Name    string `json:"name"`
@@ -79,16 +122,41 @@ type InitializeResult struct {
 }
 
 // ServerCapabilities represents server capabilities
+// A nil field means the server did not advertise that feature.
 type ServerCapabilities struct {
-	Tools     *ToolsCapability     `json:"tools,omitempty"`
-	Resources *ResourcesCapability `json:"resources,omitempty"`
+	Tools        *ToolsCapability       `json:"tools,omitempty"`
+	Resources    *ResourcesCapability   `json:"resources,omitempty"`
+	Prompts      *PromptsCapability     `json:"prompts,omitempty"`
+	Logging      *LoggingCapability     `json:"logging,omitempty"`
+	Completions  *CompletionsCapability `json:"completions,omitempty"`
+	Experimental map[string]interface{} `json:"experimental,omitempty"`
 }
 
 // ToolsCapability indicates tools support
-type ToolsCapability struct{}
+type ToolsCapability struct {
+	// ListChanged is true if the server sends tools/list_changed notifications
+	ListChanged bool `json:"listChanged,omitempty"`
+}
 
 // ResourcesCapability indicates resources support
-type ResourcesCapability struct{}
+type ResourcesCapability struct {
+	// Subscribe is true if clients can subscribe to resource updates
+	Subscribe bool `json:"subscribe,omitempty"`
+	// ListChanged is true if the server sends resources/list_changed notifications
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// PromptsCapability indicates prompts support
+type PromptsCapability struct {
+	// ListChanged is true if the server sends prompts/list_changed notifications
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// LoggingCapability indicates the server can send log messages to the client
+type LoggingCapability struct{}
+
+// CompletionsCapability indicates argument autocompletion support
+type CompletionsCapability struct{}
 
 // ServerInfo represents server information
 type ServerInfo struct {
@@ -111,10 +179,17 @@ type Resource struct {
 	MimeType    string `json:"mimeType,omitempty"`
 }
 
+// RequestIDMetaKey is the _meta key carrying a request's correlation ID,
+// so client and server log the same call under the same ID. It is prefixed
+// as the MCP spec asks of implementation-specific _meta keys, so it can't
+// collide with keys defined by the spec or other implementations.
+const RequestIDMetaKey = "io.github.davidl71/requestId"
+
 // ToolCallParams represents parameters for a tool call
 type ToolCallParams struct {
 	Name      string                 `json:"name"`
 	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Meta      map[string]interface{} `json:"_meta,omitempty"`
 }
 
 // ResourceReadParams represents parameters for reading a resource
@@ -122,6 +197,66 @@ type ResourceReadParams struct {
 	URI string `json:"uri"`
 }
 
+// Sampling roles and stop reasons
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+
+	StopReasonEndTurn      = "endTurn"
+	StopReasonStopSequence = "stopSequence"
+	StopReasonMaxTokens    = "maxTokens"
+)
+
+// SamplingContent is the content of a sampling message: text (Text) or
+// image/audio (base64 Data with MimeType)
+type SamplingContent struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	Data     string `json:"data,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// SamplingMessage is one message in a sampling conversation
+type SamplingMessage struct {
+	Role    string          `json:"role"`
+	Content SamplingContent `json:"content"`
+}
+
+// ModelHint suggests a model by (partial) name
+type ModelHint struct {
+	Name string `json:"name,omitempty"`
+}
+
+// ModelPreferences are the server's model selection preferences; the
+// priorities range from 0 to 1
+type ModelPreferences struct {
+	Hints                []ModelHint `json:"hints,omitempty"`
+	CostPriority         float64     `json:"costPriority,omitempty"`
+	SpeedPriority        float64     `json:"speedPriority,omitempty"`
+	IntelligencePriority float64     `json:"intelligencePriority,omitempty"`
+}
+
+// CreateMessageParams represents a sampling/createMessage request from a
+// server asking the client for an LLM completion
+type CreateMessageParams struct {
+	Messages         []SamplingMessage      `json:"messages"`
+	ModelPreferences *ModelPreferences      `json:"modelPreferences,omitempty"`
+	SystemPrompt     string                 `json:"systemPrompt,omitempty"`
+	IncludeContext   string                 `json:"includeContext,omitempty"`
+	Temperature      *float64               `json:"temperature,omitempty"`
+	MaxTokens        int                    `json:"maxTokens"`
+	StopSequences    []string               `json:"stopSequences,omitempty"`
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// CreateMessageResult represents the client's answer to sampling/createMessage
+type CreateMessageResult struct {
+	Role       string          `json:"role"`
+	Content    SamplingContent `json:"content"`
+	Model      string          `json:"model"`
+	StopReason string          `json:"stopReason,omitempty"`
+}
+
 // Helper functions for creating responses
 
 // NewErrorResponse creates a JSON-RPC error response
@@ -166,3 +166,104 @@ func TestToolCallParams_Marshal(t *testing.T) {
 		t.Errorf("Expected argument value1, got %v", unmarshaled.Arguments["arg1"])
 	}
 }
+
+func TestInitializeResult_Capabilities(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(t *testing.T, caps ServerCapabilities)
+	}{
+		{
+			name:  "none",
+			input: `{}`,
+			check: func(t *testing.T, caps ServerCapabilities) {
+				if caps.Tools != nil || caps.Resources != nil || caps.Prompts != nil || caps.Logging != nil || caps.Completions != nil {
+					t.Errorf("capabilities = %+v, want none", caps)
+				}
+			},
+		},
+		{
+			name:  "tools only",
+			input: `{"tools":{}}`,
+			check: func(t *testing.T, caps ServerCapabilities) {
+				if caps.Tools == nil || caps.Tools.ListChanged {
+					t.Errorf("Tools = %+v, want present without listChanged", caps.Tools)
+				}
+				if caps.Resources != nil || caps.Prompts != nil {
+					t.Errorf("unexpected resources/prompts capability: %+v", caps)
+				}
+			},
+		},
+		{
+			name:  "all",
+			input: `{"tools":{"listChanged":true},"resources":{"subscribe":true,"listChanged":true},"prompts":{"listChanged":true},"logging":{},"completions":{},"experimental":{"x":{}}}`,
+			check: func(t *testing.T, caps ServerCapabilities) {
+				if caps.Tools == nil || !caps.Tools.ListChanged {
+					t.Errorf("Tools = %+v, want listChanged", caps.Tools)
+				}
+				if caps.Resources == nil || !caps.Resources.Subscribe || !caps.Resources.ListChanged {
+					t.Errorf("Resources = %+v, want subscribe and listChanged", caps.Resources)
+				}
+				if caps.Prompts == nil || !caps.Prompts.ListChanged {
+					t.Errorf("Prompts = %+v, want listChanged", caps.Prompts)
+				}
+				if caps.Logging == nil || caps.Completions == nil {
+					t.Errorf("Logging = %v, Completions = %v, want both present", caps.Logging, caps.Completions)
+				}
+				if _, ok := caps.Experimental["x"]; !ok {
+					t.Errorf("Experimental = %v, want x", caps.Experimental)
+				}
+			},
+		},
+		{
+			name:  "resources and logging",
+			input: `{"resources":{"subscribe":true},"logging":{}}`,
+			check: func(t *testing.T, caps ServerCapabilities) {
+				if caps.Resources == nil || !caps.Resources.Subscribe || caps.Resources.ListChanged {
+					t.Errorf("Resources = %+v, want subscribe only", caps.Resources)
+				}
+				if caps.Logging == nil {
+					t.Error("Logging = nil, want present")
+				}
+				if caps.Tools != nil || caps.Prompts != nil || caps.Completions != nil {
+					t.Errorf("unexpected capability: %+v", caps)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"protocolVersion":"2025-06-18","capabilities":` + tt.input + `,"serverInfo":{"name":"s","version":"1"}}`
+			var result InitializeResult
+			if err := json.Unmarshal([]byte(data), &result); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			tt.check(t, result.Capabilities)
+		})
+	}
+}
+
+func TestSupportedProtocolVersions(t *testing.T) {
+	versions := SupportedProtocolVersions()
+	if len(versions) == 0 || versions[0] != LatestProtocolVersion {
+		t.Fatalf("SupportedProtocolVersions() = %v, want %q first", versions, LatestProtocolVersion)
+	}
+
+	// The returned slice is a copy
+	versions[0] = "mutated"
+	if SupportedProtocolVersions()[0] != LatestProtocolVersion {
+		t.Error("SupportedProtocolVersions() exposed its backing array")
+	}
+
+	for _, version := range []string{LatestProtocolVersion, "2025-03-26", "2024-11-05"} {
+		if !IsSupportedProtocolVersion(version) {
+			t.Errorf("IsSupportedProtocolVersion(%q) = false", version)
+		}
+	}
+	for _, version := range []string{"", "1.0", "2023-01-01"} {
+		if IsSupportedProtocolVersion(version) {
+			t.Errorf("IsSupportedProtocolVersion(%q) = true", version)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package request
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+// CoerceNumbers converts numeric strings in params to numbers for properties
+// the schema declares as "number" or "integer". Some clients send numbers as
+// strings ("5" instead of 5), which breaks params["limit"].(float64).
+//
+// Coerced values are float64, exactly as encoding/json decodes JSON numbers.
+// "integer" properties only accept integral strings. Strings that don't parse,
+// properties with other types, and undeclared keys are left unchanged, so
+// schema validation still reports them. params is modified in place.
+//
+// Coercion is opt-in: ParseRequestWithSchema applies it while parsing, or
+// call it on params yourself:
+//
+//	_, params, err := request.ParseRequest(args, newRequest)
+//	if err != nil {
+//		return nil, err
+//	}
+//	request.CoerceNumbers(params, schema)
+//	limit := params["limit"].(float64) // works for both 5 and "5"
+func CoerceNumbers(params map[string]interface{}, schema types.ToolSchema) {
+	for key, value := range params {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		switch propertyType(schema.Properties[key]) {
+		case "number":
+			if n, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil && !math.IsInf(n, 0) && !math.IsNaN(n) {
+				params[key] = n
+			}
+		case "integer":
+			if n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil {
+				params[key] = float64(n)
+			}
+		}
+	}
+}
+
+// propertyType returns the "type" of a schema property, which may be a raw
+// map or a types.PropertySchema
+func propertyType(property interface{}) string {
+	switch p := property.(type) {
+	case map[string]interface{}:
+		t, _ := p["type"].(string)
+		return t
+	case types.PropertySchema:
+		return p.Type
+	case *types.PropertySchema:
+		if p != nil {
+			return p.Type
+		}
+	}
+	return ""
+}
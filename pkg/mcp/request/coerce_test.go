@@ -0,0 +1,55 @@
+package request
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
+)
+
+func TestCoerceNumbers(t *testing.T) {
+	schema := types.ToolSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"ratio":   map[string]interface{}{"type": "number"},
+			"limit":   map[string]interface{}{"type": "integer"},
+			"page":    types.IntegerProperty("Page"),
+			"offset":  types.NumberProperty("Offset"),
+			"name":    map[string]interface{}{"type": "string"},
+			"bad":     map[string]interface{}{"type": "number"},
+			"partial": map[string]interface{}{"type": "integer"},
+			"already": map[string]interface{}{"type": "number"},
+			"inf":     map[string]interface{}{"type": "number"},
+		},
+	}
+	params := map[string]interface{}{
+		"ratio":      "0.5",
+		"limit":      " 10 ",
+		"page":       "3",
+		"offset":     "-1e3",
+		"name":       "42",
+		"bad":        "five",
+		"partial":    "2.5",
+		"already":    float64(7),
+		"inf":        "Inf",
+		"undeclared": "9",
+	}
+
+	CoerceNumbers(params, schema)
+
+	want := map[string]interface{}{
+		"ratio":      0.5,
+		"limit":      float64(10),
+		"page":       float64(3),
+		"offset":     float64(-1000),
+		"name":       "42",
+		"bad":        "five",
+		"partial":    "2.5",
+		"already":    float64(7),
+		"inf":        "Inf",
+		"undeclared": "9",
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("CoerceNumbers() = %v, want %v", params, want)
+	}
+}
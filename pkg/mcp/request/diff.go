@@ -0,0 +1,45 @@
+package request
+
+import "reflect"
+
+// DiffParams compares two parameter maps, e.g. a record before and after a
+// tool modified it, and returns the keys whose values differ.
+//
+// Each changed key maps to a map[string]interface{} with "old" and/or "new"
+// entries: added keys have only "new", removed keys only "old", and changed
+// keys both. Nested values are compared deeply and reported whole. Returns
+// nil if nothing changed.
+//
+// Example:
+//
+//	changes := request.DiffParams(
+//		map[string]interface{}{"status": "open", "owner": "ann"},
+//		map[string]interface{}{"status": "closed", "labels": []interface{}{"bug"}},
+//	)
+//	// changes["status"] = {"old": "open", "new": "closed"}
+//	// changes["owner"]  = {"old": "ann"}
+//	// changes["labels"] = {"new": ["bug"]}
+func DiffParams(before, after map[string]interface{}) map[string]interface{} {
+	var changes map[string]interface{}
+	record := func(key string, change map[string]interface{}) {
+		if changes == nil {
+			changes = make(map[string]interface{})
+		}
+		changes[key] = change
+	}
+
+	for key, oldValue := range before {
+		newValue, exists := after[key]
+		if !exists {
+			record(key, map[string]interface{}{"old": oldValue})
+		} else if !reflect.DeepEqual(oldValue, newValue) {
+			record(key, map[string]interface{}{"old": oldValue, "new": newValue})
+		}
+	}
+	for key, newValue := range after {
+		if _, exists := before[key]; !exists {
+			record(key, map[string]interface{}{"new": newValue})
+		}
+	}
+	return changes
+}
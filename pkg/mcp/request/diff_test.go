@@ -0,0 +1,51 @@
+package request
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffParams(t *testing.T) {
+	before := map[string]interface{}{
+		"status":   "open",
+		"owner":    "ann",
+		"priority": float64(2),
+		"labels":   []interface{}{"bug"},
+		"meta":     map[string]interface{}{"a": 1},
+	}
+	after := map[string]interface{}{
+		"status":   "closed",
+		"priority": float64(2),
+		"labels":   []interface{}{"bug", "ui"},
+		"meta":     map[string]interface{}{"a": 1},
+		"assignee": "bob",
+		"note":     nil,
+	}
+
+	want := map[string]interface{}{
+		"status":   map[string]interface{}{"old": "open", "new": "closed"},
+		"owner":    map[string]interface{}{"old": "ann"},
+		"labels":   map[string]interface{}{"old": []interface{}{"bug"}, "new": []interface{}{"bug", "ui"}},
+		"assignee": map[string]interface{}{"new": "bob"},
+		"note":     map[string]interface{}{"new": nil},
+	}
+
+	got := DiffParams(before, after)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffParams() = %v, want %v", got, want)
+	}
+}
+
+func TestDiffParams_NoChanges(t *testing.T) {
+	params := map[string]interface{}{"a": 1, "b": []interface{}{"x"}}
+	if got := DiffParams(params, map[string]interface{}{"a": 1, "b": []interface{}{"x"}}); got != nil {
+		t.Errorf("DiffParams() = %v, want nil", got)
+	}
+	if got := DiffParams(nil, nil); got != nil {
+		t.Errorf("DiffParams(nil, nil) = %v, want nil", got)
+	}
+	got := DiffParams(nil, map[string]interface{}{"a": 1})
+	if !reflect.DeepEqual(got, map[string]interface{}{"a": map[string]interface{}{"new": 1}}) {
+		t.Errorf("DiffParams(nil, ...) = %v, want a added", got)
+	}
+}
@@ -0,0 +1,111 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// NormalizeLenientJSON converts human-authored, JSON5-ish input into strict
+// JSON. It accepts:
+//   - line comments (// ...) and block comments (/* ... */)
+//   - trailing commas before a closing } or ]
+//
+// Anything else must already be valid JSON; the result is checked and an
+// error is returned otherwise. Comment markers and commas inside strings are
+// left alone.
+//
+// Example:
+//
+//	raw, err := request.NormalizeLenientJSON([]byte(`{
+//		"path": "a.txt", // file to read
+//		"limit": 10,
+//	}`))
+//	// raw: {"path":"a.txt","limit":10}
+func NormalizeLenientJSON(data []byte) (json.RawMessage, error) {
+	stripped, err := stripJSONComments(data)
+	if err != nil {
+		return nil, err
+	}
+	stripped = stripTrailingCommas(stripped)
+
+	var out bytes.Buffer
+	if err := json.Compact(&out, stripped); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// stripJSONComments replaces comments outside strings with whitespace
+func stripJSONComments(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			out = append(out, '\n')
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSON: unterminated block comment")
+			}
+			i += 2 + end + 1
+			out = append(out, ' ')
+		default:
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// stripTrailingCommas removes commas outside strings that are followed only
+// by whitespace and a closing } or ]
+func stripTrailingCommas(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		if c == '"' {
+			inString = true
+		} else if c == ',' {
+			j := i + 1
+			for j < len(data) && (data[j] == ' ' || data[j] == '\t' || data[j] == '\n' || data[j] == '\r') {
+				j++
+			}
+			if j < len(data) && (data[j] == '}' || data[j] == ']') {
+				continue
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,34 @@
+package request
+
+import "testing"
+
+func TestNormalizeLenientJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "strict JSON", input: `{"a": 1, "b": [1, 2]}`, want: `{"a":1,"b":[1,2]}`},
+		{name: "trailing commas", input: "{\"a\": [1, 2,],\n \"b\": 2,\n}", want: `{"a":[1,2],"b":2}`},
+		{name: "line comments", input: "{\n  // the path\n  \"path\": \"a.txt\" // inline\n}", want: `{"path":"a.txt"}`},
+		{name: "block comment", input: `{/* note */"a": /* one */ 1}`, want: `{"a":1}`},
+		{name: "markers inside strings", input: `{"url": "http://x/*y*/", "s": "a,}"}`, want: `{"url":"http://x/*y*/","s":"a,}"}`},
+		{name: "escaped quote in string", input: `{"q": "say \"hi\", // ok",}`, want: `{"q":"say \"hi\", // ok"}`},
+		{name: "unterminated block comment", input: `{"a": 1 /* oops`, wantErr: true},
+		{name: "still invalid", input: `{a: 1}`, wantErr: true},
+		{name: "double comma", input: `{"a": 1,,}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeLenientJSON([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeLenientJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("NormalizeLenientJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
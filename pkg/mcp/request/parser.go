@@ -22,13 +22,15 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
 	"google.golang.org/protobuf/proto"
 )
 
 // ParseRequest is a generic function for parsing protobuf or JSON requests.
 //
 // It attempts to parse the input as a protobuf message first. If that fails,
-// it falls back to parsing as JSON into a map[string]interface{}.
+// or types.DetectEncoding identifies the input as JSON, it parses the input
+// as JSON into a map[string]interface{}.
 //
 // T must be a protobuf message type that implements proto.Message.
 // newMessage is a function that returns a new zero-value instance of T.
@@ -54,11 +56,14 @@ func ParseRequest[T proto.Message](
 ) (T, map[string]interface{}, error) {
 	var zero T
 
-	// Try protobuf binary first
-	req := newMessage()
-	if err := proto.Unmarshal(args, req); err == nil {
-		// Successfully parsed as protobuf
-		return req, nil, nil
+	// Try protobuf binary first, unless the payload is clearly JSON (JSON
+	// bytes can occasionally happen to decode as a protobuf message)
+	if types.DetectEncoding(args) != types.EncodingJSON {
+		req := newMessage()
+		if err := proto.Unmarshal(args, req); err == nil {
+			// Successfully parsed as protobuf
+			return req, nil, nil
+		}
 	}
 
 	// Fall back to JSON
@@ -70,3 +75,30 @@ func ParseRequest[T proto.Message](
 	// Successfully parsed as JSON
 	return zero, params, nil
 }
+
+// ParseRequestWithSchema is ParseRequest with number coercion for JSON
+// requests: after parsing, CoerceNumbers converts numeric strings in params
+// to numbers for the properties schema declares as "number" or "integer".
+// Protobuf requests are returned unchanged.
+//
+// Example:
+//
+//	_, params, err := ParseRequestWithSchema(args, newRequest, schema)
+//	if err != nil {
+//		return nil, err
+//	}
+//	limit := params["limit"].(float64) // works for both 5 and "5"
+func ParseRequestWithSchema[T proto.Message](
+	args json.RawMessage,
+	newMessage func() T,
+	schema types.ToolSchema,
+) (T, map[string]interface{}, error) {
+	req, params, err := ParseRequest(args, newMessage)
+	if err != nil {
+		return req, nil, err
+	}
+	if params != nil {
+		CoerceNumbers(params, schema)
+	}
+	return req, params, nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/davidl71/mcp-go-core/pkg/mcp/types"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -157,3 +158,62 @@ func TestParseRequest_ProtobufPriority(t *testing.T) {
 		t.Errorf("ParseRequest() req.GetStringValue() = %q, want %q", req.GetStringValue(), "protobuf_value")
 	}
 }
+
+func TestParseRequestWithSchema_CoercesNumbers(t *testing.T) {
+	schema := types.ToolSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"limit": types.IntegerProperty("Limit"),
+			"ratio": types.NumberProperty("Ratio"),
+			"name":  map[string]interface{}{"type": "string"},
+		},
+	}
+	args := json.RawMessage(`{"limit": "5", "ratio": "abc", "name": "42", "extra": "7"}`)
+
+	_, params, err := ParseRequestWithSchema(args, func() *structpb.Value {
+		return &structpb.Value{}
+	}, schema)
+	if err != nil {
+		t.Fatalf("ParseRequestWithSchema() error = %v, want nil", err)
+	}
+
+	if params["limit"] != float64(5) {
+		t.Errorf("params[limit] = %#v, want float64(5)", params["limit"])
+	}
+	// Non-numeric strings, string properties and undeclared keys stay strings
+	if params["ratio"] != "abc" {
+		t.Errorf("params[ratio] = %#v, want %q", params["ratio"], "abc")
+	}
+	if params["name"] != "42" {
+		t.Errorf("params[name] = %#v, want %q", params["name"], "42")
+	}
+	if params["extra"] != "7" {
+		t.Errorf("params[extra] = %#v, want %q", params["extra"], "7")
+	}
+}
+
+func TestParseRequest_DoesNotCoerce(t *testing.T) {
+	args := json.RawMessage(`{"limit": "5"}`)
+
+	_, params, err := ParseRequest(args, func() *structpb.Value {
+		return &structpb.Value{}
+	})
+	if err != nil {
+		t.Fatalf("ParseRequest() error = %v, want nil", err)
+	}
+	if params["limit"] != "5" {
+		t.Errorf("params[limit] = %#v, want %q (coercion is opt-in)", params["limit"], "5")
+	}
+}
+
+func TestParseRequestWithSchema_InvalidInput(t *testing.T) {
+	_, params, err := ParseRequestWithSchema(json.RawMessage("not json"), func() *structpb.Value {
+		return &structpb.Value{}
+	}, types.ToolSchema{})
+	if err == nil {
+		t.Fatal("ParseRequestWithSchema() error = nil, want error")
+	}
+	if params != nil {
+		t.Errorf("params = %v, want nil", params)
+	}
+}
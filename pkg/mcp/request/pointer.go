@@ -0,0 +1,67 @@
+package request
+
+import (
+	"strconv"
+	"strings"
+)
+
+// GetByPointer looks up a value in decoded tool arguments using an RFC 6901
+// JSON Pointer such as "/filter/tags/0".
+//
+// The empty pointer "" refers to params itself. "~1" and "~0" in a reference
+// token decode to "/" and "~". Array elements are addressed by decimal index
+// (no leading zeros). Returns false if the pointer is malformed or any part of
+// the path is missing.
+//
+// Example:
+//
+//	// params: {"filter": {"tags": ["a", "b"]}}
+//	tag, ok := request.GetByPointer(params, "/filter/tags/1") // "b", true
+func GetByPointer(params map[string]interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return params, params != nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+
+	var current interface{} = params
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, ok := arrayIndex(token, len(node))
+			if !ok {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// arrayIndex parses an RFC 6901 array index token, checking it is in range
+func arrayIndex(token string, length int) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	for _, c := range token {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index >= length {
+		return 0, false
+	}
+	return index, true
+}
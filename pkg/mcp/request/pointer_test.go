@@ -0,0 +1,61 @@
+package request
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestGetByPointer(t *testing.T) {
+	var params map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+		"filter": {"tags": ["a", "b"], "owner": {"name": "ann"}},
+		"items": [{"id": 1}, {"id": 2}],
+		"a/b": "slash",
+		"m~n": "tilde",
+		"": "empty key",
+		"nothing": null
+	}`), &params); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		pointer string
+		want    interface{}
+		wantOK  bool
+	}{
+		{name: "nested object", pointer: "/filter/owner/name", want: "ann", wantOK: true},
+		{name: "array index", pointer: "/filter/tags/1", want: "b", wantOK: true},
+		{name: "object in array", pointer: "/items/0/id", want: float64(1), wantOK: true},
+		{name: "whole array", pointer: "/filter/tags", want: []interface{}{"a", "b"}, wantOK: true},
+		{name: "escaped slash", pointer: "/a~1b", want: "slash", wantOK: true},
+		{name: "escaped tilde", pointer: "/m~0n", want: "tilde", wantOK: true},
+		{name: "empty key", pointer: "/", want: "empty key", wantOK: true},
+		{name: "null value", pointer: "/nothing", want: nil, wantOK: true},
+		{name: "missing key", pointer: "/filter/missing"},
+		{name: "missing nested path", pointer: "/missing/name"},
+		{name: "index out of range", pointer: "/filter/tags/2"},
+		{name: "negative index", pointer: "/filter/tags/-1"},
+		{name: "leading zero index", pointer: "/filter/tags/01"},
+		{name: "append marker", pointer: "/filter/tags/-"},
+		{name: "descend into scalar", pointer: "/filter/owner/name/x"},
+		{name: "no leading slash", pointer: "filter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GetByPointer(params, tt.pointer)
+			if ok != tt.wantOK {
+				t.Fatalf("GetByPointer(%q) ok = %v, want %v", tt.pointer, ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetByPointer(%q) = %v, want %v", tt.pointer, got, tt.want)
+			}
+		})
+	}
+
+	if got, ok := GetByPointer(params, ""); !ok || !reflect.DeepEqual(got, params) {
+		t.Errorf("GetByPointer(\"\") = %v, %v; want the whole document", got, ok)
+	}
+}
@@ -0,0 +1,94 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidationError collects per-field validation failures for a request, so
+// a tool can report every problem at once in machine-readable form.
+//
+// Example:
+//
+//	verr := &request.ValidationError{}
+//	if params["name"] == "" {
+//		verr.Add("name", "is required")
+//	}
+//	if limit < 1 {
+//		verr.Addf("limit", "must be at least 1, got %d", limit)
+//	}
+//	if err := verr.Err(); err != nil {
+//		return nil, err
+//	}
+type ValidationError struct {
+	FieldErrors map[string][]string
+}
+
+// Add records a message for field
+func (e *ValidationError) Add(field, message string) {
+	if e.FieldErrors == nil {
+		e.FieldErrors = make(map[string][]string)
+	}
+	e.FieldErrors[field] = append(e.FieldErrors[field], message)
+}
+
+// Addf records a formatted message for field
+func (e *ValidationError) Addf(field, format string, args ...interface{}) {
+	e.Add(field, fmt.Sprintf(format, args...))
+}
+
+// HasErrors reports whether any field errors were recorded
+func (e *ValidationError) HasErrors() bool {
+	return len(e.FieldErrors) > 0
+}
+
+// Err returns e if any field errors were recorded, or nil
+func (e *ValidationError) Err() error {
+	if !e.HasErrors() {
+		return nil
+	}
+	return e
+}
+
+// Error summarizes all field errors, ordered by field name
+func (e *ValidationError) Error() string {
+	if !e.HasErrors() {
+		return "validation failed"
+	}
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = fmt.Sprintf("%s: %s", field, strings.Join(e.FieldErrors[field], ", "))
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+// MarshalJSON encodes the error as
+// {"error": "<summary>", "field_errors": {"<field>": ["<message>", ...]}}
+func (e *ValidationError) MarshalJSON() ([]byte, error) {
+	fieldErrors := e.FieldErrors
+	if fieldErrors == nil {
+		fieldErrors = map[string][]string{}
+	}
+	return json.Marshal(struct {
+		Error       string              `json:"error"`
+		FieldErrors map[string][]string `json:"field_errors"`
+	}{
+		Error:       e.Error(),
+		FieldErrors: fieldErrors,
+	})
+}
+
+// IsValidationError checks if an error is (or wraps) a ValidationError
+func IsValidationError(err error) bool {
+	var verr *ValidationError
+	return errors.As(err, &verr)
+}
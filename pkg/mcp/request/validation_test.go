@@ -0,0 +1,65 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestValidationError(t *testing.T) {
+	verr := &ValidationError{}
+	if verr.Err() != nil {
+		t.Fatalf("Err() on empty ValidationError = %v, want nil", verr.Err())
+	}
+
+	verr.Add("name", "is required")
+	verr.Addf("limit", "must be at least %d", 1)
+	verr.Add("limit", "must be an integer")
+
+	err := verr.Err()
+	if err == nil {
+		t.Fatal("Err() = nil, want error")
+	}
+	want := "validation failed: limit: must be at least 1, must be an integer; name: is required"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if !IsValidationError(fmt.Errorf("tool failed: %w", err)) {
+		t.Error("IsValidationError(wrapped) = false, want true")
+	}
+	if IsValidationError(fmt.Errorf("other")) {
+		t.Error("IsValidationError(other) = true, want false")
+	}
+}
+
+func TestValidationError_JSON(t *testing.T) {
+	verr := &ValidationError{}
+	verr.Add("name", "is required")
+	verr.Add("limit", "must be positive")
+
+	data, err := json.Marshal(verr)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"error": "validation failed: limit: must be positive; name: is required",
+		"field_errors": map[string]interface{}{
+			"name":  []interface{}{"is required"},
+			"limit": []interface{}{"must be positive"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON = %s, want %v", data, want)
+	}
+
+	empty, err := json.Marshal(&ValidationError{})
+	if err != nil || string(empty) != `{"error":"validation failed","field_errors":{}}` {
+		t.Errorf("empty JSON = %s, %v", empty, err)
+	}
+}
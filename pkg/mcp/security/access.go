@@ -2,6 +2,8 @@ package security
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"sync"
 )
 
@@ -18,13 +20,93 @@ const (
 )
 
 // AccessControl manages tool and resource access permissions
+//
+// Names are checked against rules in this order, and the first that applies
+// decides:
+//
+//  1. exact deny (DenyTool, DenyResource)
+//  2. exact allow (AllowTool, AllowResource)
+//  3. deny by the most specific tool group rule (DenyToolGroup) or by any
+//     pattern (DenyToolPattern, DenyResourcePattern)
+//  4. allow by the most specific tool group rule (AllowToolGroup) or by any
+//     pattern (AllowToolPattern, AllowResourcePattern)
+//  5. the resource's scheme default (SetResourceSchemeDefault)
+//  6. the default policy
+//
+// So an exact rule always overrides a group or pattern, a rule on group
+// "db.admin" overrides one on "db", and a deny group or pattern overrides an
+// overlapping allow group or pattern.
 type AccessControl struct {
-	mu            sync.RWMutex
-	toolPerms     map[string]Permission // tool name -> permission
-	resourcePerms map[string]Permission // resource URI -> permission
-	defaultPolicy Permission            // default permission if not specified
-	allowedTools  map[string]bool       // explicit allow list (if default is deny)
-	deniedTools   map[string]bool       // explicit deny list (if default is allow)
+	mu               sync.RWMutex
+	toolPerms        map[string]Permission // tool name -> permission
+	resourcePerms    map[string]Permission // resource URI -> permission
+	toolPatterns     []accessPattern       // tool name patterns, in order added
+	resourcePatterns []accessPattern       // resource URI patterns, in order added
+	toolGroups       map[string]Permission // tool group -> permission
+	schemeDefaults   map[string]Permission // lowercase URI scheme -> default permission
+	defaultPolicy    Permission            // default permission if not specified
+	allowedTools     map[string]bool       // explicit allow list (if default is deny)
+	deniedTools      map[string]bool       // explicit deny list (if default is allow)
+
+	// AuditFunc, if set, is called with the decision of every check. It runs
+	// without ac's lock held, so it may call back into ac. Set it before ac is
+	// shared between goroutines.
+	AuditFunc func(event AuditEvent)
+}
+
+// accessPattern is a compiled glob pattern with its permission
+type accessPattern struct {
+	pattern string
+	re      *regexp.Regexp
+	perm    Permission
+}
+
+// compileGlob compiles a glob pattern: * matches any run of characters
+// (including '/' and ':'), ? matches one character, anything else matches
+// itself. "admin_*" matches every name starting with "admin_".
+func compileGlob(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// setPattern adds pattern with perm to patterns, replacing the permission
+// if the pattern was already added
+func setPattern(patterns []accessPattern, pattern string, perm Permission) []accessPattern {
+	for i := range patterns {
+		if patterns[i].pattern == pattern {
+			patterns[i].perm = perm
+			return patterns
+		}
+	}
+	return append(patterns, accessPattern{pattern: pattern, re: compileGlob(pattern), perm: perm})
+}
+
+// matchPatterns returns the permission the patterns give name: deny if any
+// deny pattern matches, else allow if any allow pattern matches. ok is false
+// if no pattern matches.
+func matchPatterns(patterns []accessPattern, name string) (perm Permission, ok bool) {
+	for _, p := range patterns {
+		if !p.re.MatchString(name) {
+			continue
+		}
+		if p.perm == PermissionDeny {
+			return PermissionDeny, true
+		}
+		perm, ok = PermissionAllow, true
+	}
+	return perm, ok
 }
 
 // NewAccessControl creates a new access control manager
@@ -33,6 +115,7 @@ func NewAccessControl(defaultPolicy Permission) *AccessControl {
 	return &AccessControl{
 		toolPerms:     make(map[string]Permission),
 		resourcePerms: make(map[string]Permission),
+		toolGroups:    make(map[string]Permission),
 		defaultPolicy: defaultPolicy,
 		allowedTools:  make(map[string]bool),
 		deniedTools:   make(map[string]bool),
@@ -71,11 +154,125 @@ func (ac *AccessControl) DenyResource(uri string) {
 	ac.resourcePerms[uri] = PermissionDeny
 }
 
+// AllowToolPattern allows access to tools whose names match a glob pattern
+// (e.g. "read_*"). See AccessControl for how patterns and exact rules combine.
+func (ac *AccessControl) AllowToolPattern(pattern string) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.toolPatterns = setPattern(ac.toolPatterns, pattern, PermissionAllow)
+}
+
+// DenyToolPattern denies access to tools whose names match a glob pattern
+// (e.g. "admin_*"). See AccessControl for how patterns and exact rules combine.
+func (ac *AccessControl) DenyToolPattern(pattern string) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.toolPatterns = setPattern(ac.toolPatterns, pattern, PermissionDeny)
+}
+
+// ToolGroupSeparator separates the levels of a hierarchical tool name: tool
+// "db.admin.drop" is in group "db.admin", which is in group "db"
+const ToolGroupSeparator = "."
+
+// AllowToolGroup allows access to every tool in a group, i.e. whose name
+// starts with group followed by ToolGroupSeparator: group "db" covers
+// "db.read" and "db.admin.drop". A rule on a more specific group, or on the
+// tool itself, overrides it, and a matching deny pattern (DenyToolPattern)
+// wins over a group allow.
+func (ac *AccessControl) AllowToolGroup(group string) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.toolGroups[group] = PermissionAllow
+}
+
+// DenyToolGroup denies access to every tool in a group. See AllowToolGroup.
+func (ac *AccessControl) DenyToolGroup(group string) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.toolGroups[group] = PermissionDeny
+}
+
+// matchToolGroup returns the permission of the most specific group rule
+// covering toolName. ok is false if no group rule does.
+func (ac *AccessControl) matchToolGroup(toolName string) (perm Permission, ok bool) {
+	if len(ac.toolGroups) == 0 {
+		return PermissionDefault, false
+	}
+	group := toolName
+	for {
+		i := strings.LastIndex(group, ToolGroupSeparator)
+		if i < 0 {
+			return PermissionDefault, false
+		}
+		group = group[:i]
+		if perm, ok := ac.toolGroups[group]; ok {
+			return perm, true
+		}
+	}
+}
+
+// SetResourceSchemeDefault sets the default permission for resources whose
+// URIs have the given scheme (e.g. "file" for "file:///etc/hosts"), in place
+// of the default policy. Exact rules and patterns still override it. Schemes
+// are case-insensitive; PermissionDefault removes the scheme's default.
+func (ac *AccessControl) SetResourceSchemeDefault(scheme string, perm Permission) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	scheme = strings.ToLower(scheme)
+	if perm == PermissionDefault {
+		delete(ac.schemeDefaults, scheme)
+		return
+	}
+	if ac.schemeDefaults == nil {
+		ac.schemeDefaults = make(map[string]Permission)
+	}
+	ac.schemeDefaults[scheme] = perm
+}
+
+// resourceDefault returns the default permission for uri: its scheme's
+// default if set, else the default policy
+func (ac *AccessControl) resourceDefault(uri string) Permission {
+	if scheme, _, ok := strings.Cut(uri, ":"); ok {
+		if perm, ok := ac.schemeDefaults[strings.ToLower(scheme)]; ok {
+			return perm
+		}
+	}
+	return ac.defaultPolicy
+}
+
+// AllowResourcePattern allows access to resources whose URIs match a glob
+// pattern (e.g. "fs:*")
+func (ac *AccessControl) AllowResourcePattern(pattern string) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.resourcePatterns = setPattern(ac.resourcePatterns, pattern, PermissionAllow)
+}
+
+// DenyResourcePattern denies access to resources whose URIs match a glob
+// pattern (e.g. "file:///etc/*")
+func (ac *AccessControl) DenyResourcePattern(pattern string) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.resourcePatterns = setPattern(ac.resourcePatterns, pattern, PermissionDeny)
+}
+
 // CheckTool checks if a tool can be accessed
 func (ac *AccessControl) CheckTool(toolName string) error {
+	return ac.CheckToolForClient("", toolName)
+}
+
+// CheckToolForClient is CheckTool recording clientID in the audit event
+func (ac *AccessControl) CheckToolForClient(clientID, toolName string) error {
 	ac.mu.RLock()
-	defer ac.mu.RUnlock()
+	err := ac.checkTool(toolName)
+	ac.mu.RUnlock()
 
+	ac.audit(clientID, "tool", toolName, err)
+	return err
+}
+
+// checkTool decides tool access; the caller must hold ac.mu
+func (ac *AccessControl) checkTool(toolName string) error {
 	// Check explicit permission
 	if perm, exists := ac.toolPerms[toolName]; exists {
 		if perm == PermissionDeny {
@@ -89,6 +286,20 @@ func (ac *AccessControl) CheckTool(toolName string) error {
 		}
 	}
 
+	// Check groups (most specific first) and patterns; a deny from either
+	// wins over an allow from the other
+	groupPerm, groupOK := ac.matchToolGroup(toolName)
+	patternPerm, patternOK := matchPatterns(ac.toolPatterns, toolName)
+	if (groupOK && groupPerm == PermissionDeny) || (patternOK && patternPerm == PermissionDeny) {
+		return &AccessDeniedError{
+			Resource: "tool",
+			Name:     toolName,
+		}
+	}
+	if groupOK || patternOK {
+		return nil
+	}
+
 	// Check deny list
 	if ac.deniedTools[toolName] {
 		return &AccessDeniedError{
@@ -120,9 +331,22 @@ func (ac *AccessControl) CheckTool(toolName string) error {
 
 // CheckResource checks if a resource can be accessed
 func (ac *AccessControl) CheckResource(uri string) error {
+	return ac.CheckResourceForClient("", uri)
+}
+
+// CheckResourceForClient is CheckResource recording clientID in the audit
+// event
+func (ac *AccessControl) CheckResourceForClient(clientID, uri string) error {
 	ac.mu.RLock()
-	defer ac.mu.RUnlock()
+	err := ac.checkResource(uri)
+	ac.mu.RUnlock()
+
+	ac.audit(clientID, "resource", uri, err)
+	return err
+}
 
+// checkResource decides resource access; the caller must hold ac.mu
+func (ac *AccessControl) checkResource(uri string) error {
 	// Check explicit permission
 	if perm, exists := ac.resourcePerms[uri]; exists {
 		if perm == PermissionDeny {
@@ -136,8 +360,19 @@ func (ac *AccessControl) CheckResource(uri string) error {
 		}
 	}
 
-	// Use default policy
-	if ac.defaultPolicy == PermissionDeny {
+	// Check patterns
+	if perm, ok := matchPatterns(ac.resourcePatterns, uri); ok {
+		if perm == PermissionDeny {
+			return &AccessDeniedError{
+				Resource: "resource",
+				Name:     uri,
+			}
+		}
+		return nil
+	}
+
+	// Use the scheme's default or the default policy
+	if ac.resourceDefault(uri) == PermissionDeny {
 		return &AccessDeniedError{
 			Resource: "resource",
 			Name:     uri,
@@ -61,6 +61,181 @@ func TestAccessControl_Resource(t *testing.T) {
 	}
 }
 
+func TestAccessControl_ToolPatterns(t *testing.T) {
+	ac := NewAccessControl(PermissionAllow)
+	ac.DenyToolPattern("admin_*")
+	ac.AllowToolPattern("admin_read_*") // overlaps; deny pattern wins
+	ac.AllowTool("admin_status")        // exact allow overrides the deny pattern
+
+	tests := []struct {
+		tool    string
+		allowed bool
+	}{
+		{"admin_delete", false},
+		{"admin_", false},
+		{"admin_read_logs", false},
+		{"admin_status", true},
+		{"echo", true},
+		{"xadmin_delete", true}, // patterns are anchored
+	}
+	for _, tt := range tests {
+		err := ac.CheckTool(tt.tool)
+		if (err == nil) != tt.allowed {
+			t.Errorf("CheckTool(%q) error = %v, want allowed=%v", tt.tool, err, tt.allowed)
+		}
+	}
+}
+
+func TestAccessControl_ToolPatternsDefaultDeny(t *testing.T) {
+	ac := NewAccessControl(PermissionDeny)
+	ac.AllowToolPattern("read_*")
+	ac.AllowToolPattern("get_?")
+	ac.DenyTool("read_secrets") // exact deny overrides the allow pattern
+
+	tests := []struct {
+		tool    string
+		allowed bool
+	}{
+		{"read_file", true},
+		{"read_secrets", false},
+		{"get_x", true},
+		{"get_xy", false},
+		{"write_file", false},
+	}
+	for _, tt := range tests {
+		err := ac.CheckTool(tt.tool)
+		if (err == nil) != tt.allowed {
+			t.Errorf("CheckTool(%q) error = %v, want allowed=%v", tt.tool, err, tt.allowed)
+		}
+	}
+
+	// Re-adding a pattern replaces its permission
+	ac.DenyToolPattern("read_*")
+	if err := ac.CheckTool("read_file"); err == nil {
+		t.Error("read_file should be denied after DenyToolPattern(\"read_*\")")
+	}
+}
+
+func TestAccessControl_ToolGroups(t *testing.T) {
+	ac := NewAccessControl(PermissionDeny)
+	ac.AllowToolGroup("db")
+	ac.DenyTool("db.write")           // exact deny overrides the group allow
+	ac.DenyToolGroup("db.admin")      // more specific group overrides "db"
+	ac.AllowTool("db.admin.status")   // exact allow overrides the group deny
+	ac.DenyToolPattern("db.*_unsafe") // a deny pattern overrides the group allow
+	ac.AllowToolPattern("db.admin.*") // an allow pattern does not override the group deny
+
+	tests := []struct {
+		tool    string
+		allowed bool
+	}{
+		{"db.read", true},
+		{"db.read_unsafe", false},
+		{"db.write", false},
+		{"db.admin.drop", false},
+		{"db.admin.status", true},
+		{"db", false},       // a group does not cover a tool named like it
+		{"dbx.read", false}, // prefix must end at the separator
+		{"fs.read", false},
+	}
+	for _, tt := range tests {
+		err := ac.CheckTool(tt.tool)
+		if (err == nil) != tt.allowed {
+			t.Errorf("CheckTool(%q) error = %v, want allowed=%v", tt.tool, err, tt.allowed)
+		}
+	}
+
+	// Re-adding a group replaces its permission
+	ac.AllowToolGroup("db.admin")
+	if err := ac.CheckTool("db.admin.drop"); err != nil {
+		t.Errorf("db.admin.drop should be allowed after AllowToolGroup(\"db.admin\"): %v", err)
+	}
+}
+
+func TestAccessControl_DenyPatternOverridesGroup(t *testing.T) {
+	ac := NewAccessControl(PermissionAllow)
+	ac.AllowToolGroup("db")
+	ac.DenyToolPattern("db.*")
+	ac.AllowTool("db.status")
+
+	for tool, allowed := range map[string]bool{"db.read": false, "db.admin.drop": false, "db.status": true} {
+		if err := ac.CheckTool(tool); (err == nil) != allowed {
+			t.Errorf("CheckTool(%q) error = %v, want allowed=%v", tool, err, allowed)
+		}
+	}
+}
+
+func TestAccessControl_ResourcePatterns(t *testing.T) {
+	ac := NewAccessControl(PermissionDeny)
+	ac.AllowResourcePattern("fs:*")
+	ac.DenyResourcePattern("fs://secrets/*")
+	ac.AllowResource("fs://secrets/public.txt")
+	ac.AllowResourcePattern("https://example.com/*")
+
+	tests := []struct {
+		uri     string
+		allowed bool
+	}{
+		{"fs://docs/readme.md", true}, // * spans '/' in URIs
+		{"fs://secrets/key.pem", false},
+		{"fs://secrets/public.txt", true},
+		{"http://example.com", false},
+		{"https://example.com/a", true},
+		{"https://exampleXcom/a", false}, // '.' is literal, not a regexp wildcard
+	}
+	for _, tt := range tests {
+		err := ac.CheckResource(tt.uri)
+		if (err == nil) != tt.allowed {
+			t.Errorf("CheckResource(%q) error = %v, want allowed=%v", tt.uri, err, tt.allowed)
+		}
+	}
+}
+
+func TestAccessControl_ResourceSchemeDefaults(t *testing.T) {
+	ac := NewAccessControl(PermissionAllow)
+	ac.SetResourceSchemeDefault("file", PermissionDeny)
+	ac.SetResourceSchemeDefault("EXAMPLE", PermissionAllow)
+	ac.AllowResource("file:///srv/public.txt")    // exact allow overrides the scheme default
+	ac.AllowResourcePattern("file:///srv/docs/*") // so does a pattern
+	ac.DenyResource("example://secret")
+
+	tests := []struct {
+		uri     string
+		allowed bool
+	}{
+		{"file:///etc/passwd", false},
+		{"FILE:///etc/passwd", false}, // schemes are case-insensitive
+		{"file:///srv/public.txt", true},
+		{"file:///srv/docs/a.md", true},
+		{"example://anything", true},
+		{"example://secret", false},
+		{"http://example.com", true}, // no scheme default: default policy
+		{"no-scheme", true},
+	}
+	for _, tt := range tests {
+		err := ac.CheckResource(tt.uri)
+		if (err == nil) != tt.allowed {
+			t.Errorf("CheckResource(%q) error = %v, want allowed=%v", tt.uri, err, tt.allowed)
+		}
+	}
+
+	// A scheme default can allow under a deny-by-default policy
+	strict := NewAccessControl(PermissionDeny)
+	strict.SetResourceSchemeDefault("example", PermissionAllow)
+	if err := strict.CheckResource("example://a"); err != nil {
+		t.Errorf("example://a should be allowed by its scheme default: %v", err)
+	}
+	if err := strict.CheckResource("db://a"); err == nil {
+		t.Error("db://a should be denied by the default policy")
+	}
+
+	// PermissionDefault removes the scheme default
+	ac.SetResourceSchemeDefault("file", PermissionDefault)
+	if err := ac.CheckResource("file:///etc/passwd"); err != nil {
+		t.Errorf("file:///etc/passwd should fall back to the default policy: %v", err)
+	}
+}
+
 func TestAccessDeniedError(t *testing.T) {
 	err := &AccessDeniedError{
 		Resource: "tool",
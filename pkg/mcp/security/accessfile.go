@@ -0,0 +1,223 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AccessPolicy is the file format read by AccessControl.LoadFromFile, in
+// JSON or YAML:
+//
+//	default: deny
+//	tools:
+//	  allow: [echo]
+//	  allow_groups: [db]
+//	  deny_groups: [db.admin]
+//	resources:
+//	  allow_patterns: ["fs://docs/*"]
+//	scheme_defaults:
+//	  file: deny
+type AccessPolicy struct {
+	// Default is the default policy: "allow" or "deny"
+	Default   string      `json:"default" yaml:"default"`
+	Tools     AccessRules `json:"tools" yaml:"tools"`
+	Resources AccessRules `json:"resources" yaml:"resources"`
+
+	// SchemeDefaults maps URI schemes to their default policy, "allow" or
+	// "deny" (see AccessControl.SetResourceSchemeDefault)
+	SchemeDefaults map[string]string `json:"scheme_defaults,omitempty" yaml:"scheme_defaults,omitempty"`
+}
+
+// AccessRules lists the rules for tools or resources in an AccessPolicy.
+// Groups apply to tools only.
+type AccessRules struct {
+	Allow         []string `json:"allow,omitempty" yaml:"allow,omitempty"`
+	Deny          []string `json:"deny,omitempty" yaml:"deny,omitempty"`
+	AllowPatterns []string `json:"allow_patterns,omitempty" yaml:"allow_patterns,omitempty"`
+	DenyPatterns  []string `json:"deny_patterns,omitempty" yaml:"deny_patterns,omitempty"`
+	AllowGroups   []string `json:"allow_groups,omitempty" yaml:"allow_groups,omitempty"`
+	DenyGroups    []string `json:"deny_groups,omitempty" yaml:"deny_groups,omitempty"`
+}
+
+// ParseAccessPolicy parses a policy file's contents. Files ending in .json
+// are parsed as JSON, anything else as YAML. Unknown fields are rejected so
+// typos don't silently drop rules.
+func ParseAccessPolicy(path string, data []byte) (*AccessPolicy, error) {
+	var policy AccessPolicy
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&policy); err != nil {
+			return nil, fmt.Errorf("invalid access policy %s: %w", path, err)
+		}
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&policy); err != nil {
+			return nil, fmt.Errorf("invalid access policy %s: %w", path, err)
+		}
+	}
+	if _, err := parsePermission(policy.Default); err != nil {
+		return nil, fmt.Errorf("invalid access policy %s: %w", path, err)
+	}
+	for scheme, perm := range policy.SchemeDefaults {
+		if _, err := parsePermission(perm); err != nil {
+			return nil, fmt.Errorf("invalid access policy %s: scheme %q: %w", path, scheme, err)
+		}
+	}
+	if len(policy.Resources.AllowGroups) > 0 || len(policy.Resources.DenyGroups) > 0 {
+		return nil, fmt.Errorf("invalid access policy %s: groups apply to tools only", path)
+	}
+	return &policy, nil
+}
+
+// parsePermission parses a default policy name
+func parsePermission(s string) (Permission, error) {
+	switch strings.ToLower(s) {
+	case "allow":
+		return PermissionAllow, nil
+	case "deny":
+		return PermissionDeny, nil
+	default:
+		return PermissionDefault, fmt.Errorf("default policy must be \"allow\" or \"deny\", got %q", s)
+	}
+}
+
+// newAccessControlFromPolicy builds an AccessControl holding policy's rules
+func newAccessControlFromPolicy(policy *AccessPolicy) (*AccessControl, error) {
+	defaultPolicy, err := parsePermission(policy.Default)
+	if err != nil {
+		return nil, err
+	}
+	ac := NewAccessControl(defaultPolicy)
+	for _, name := range policy.Tools.Allow {
+		ac.AllowTool(name)
+	}
+	for _, name := range policy.Tools.Deny {
+		ac.DenyTool(name)
+	}
+	for _, pattern := range policy.Tools.AllowPatterns {
+		ac.AllowToolPattern(pattern)
+	}
+	for _, pattern := range policy.Tools.DenyPatterns {
+		ac.DenyToolPattern(pattern)
+	}
+	for _, group := range policy.Tools.AllowGroups {
+		ac.AllowToolGroup(group)
+	}
+	for _, group := range policy.Tools.DenyGroups {
+		ac.DenyToolGroup(group)
+	}
+	for _, uri := range policy.Resources.Allow {
+		ac.AllowResource(uri)
+	}
+	for _, uri := range policy.Resources.Deny {
+		ac.DenyResource(uri)
+	}
+	for _, pattern := range policy.Resources.AllowPatterns {
+		ac.AllowResourcePattern(pattern)
+	}
+	for _, pattern := range policy.Resources.DenyPatterns {
+		ac.DenyResourcePattern(pattern)
+	}
+	for scheme, name := range policy.SchemeDefaults {
+		perm, err := parsePermission(name)
+		if err != nil {
+			return nil, fmt.Errorf("scheme %q: %w", scheme, err)
+		}
+		ac.SetResourceSchemeDefault(scheme, perm)
+	}
+	return ac, nil
+}
+
+// LoadFromFile replaces all of ac's rules and its default policy with those
+// in a policy file (see AccessPolicy). The swap is atomic: concurrent checks
+// see either the old rules or the new ones. On error ac is unchanged.
+func (ac *AccessControl) LoadFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read access policy: %w", err)
+	}
+	policy, err := ParseAccessPolicy(path, data)
+	if err != nil {
+		return err
+	}
+	loaded, err := newAccessControlFromPolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.toolPerms = loaded.toolPerms
+	ac.resourcePerms = loaded.resourcePerms
+	ac.toolPatterns = loaded.toolPatterns
+	ac.resourcePatterns = loaded.resourcePatterns
+	ac.toolGroups = loaded.toolGroups
+	ac.schemeDefaults = loaded.schemeDefaults
+	ac.defaultPolicy = loaded.defaultPolicy
+	ac.allowedTools = loaded.allowedTools
+	ac.deniedTools = loaded.deniedTools
+	return nil
+}
+
+// DefaultWatchInterval is how often WatchFile checks the policy file when
+// interval is 0 or less
+const DefaultWatchInterval = 5 * time.Second
+
+// WatchFile loads a policy file with LoadFromFile, then keeps polling it
+// every interval and reloads it whenever its size or modification time
+// changes, until ctx is done. It returns the initial load's error, in which
+// case nothing is watched.
+//
+// A failed reload (e.g. a half-written or invalid file) keeps the previous
+// rules and is passed to onError, if set; the next change is retried.
+func (ac *AccessControl) WatchFile(ctx context.Context, path string, interval time.Duration, onError func(error)) error {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read access policy: %w", err)
+	}
+	if err := ac.LoadFromFile(path); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		last := info
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				if onError != nil {
+					onError(fmt.Errorf("failed to read access policy: %w", err))
+				}
+				continue
+			}
+			if info.Size() == last.Size() && info.ModTime().Equal(last.ModTime()) {
+				continue
+			}
+			last = info
+			if err := ac.LoadFromFile(path); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,190 @@
+package security
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAccessControl_LoadFromFile(t *testing.T) {
+	dir := t.TempDir()
+	yamlPolicy := `default: deny
+tools:
+  allow: [echo]
+  allow_groups: [db]
+  deny_groups: [db.admin]
+resources:
+  allow_patterns: ["fs://docs/*"]
+  allow: ["file:///srv/public.txt"]
+scheme_defaults:
+  file: deny
+  fs: deny
+`
+	jsonPolicy := `{
+  "default": "allow",
+  "tools": {"deny": ["shell"], "deny_patterns": ["admin_*"]},
+  "resources": {"deny": ["fs://secrets"]}
+}`
+
+	tests := []struct {
+		name      string
+		file      string
+		content   string
+		tools     map[string]bool
+		resources map[string]bool
+	}{
+		{
+			name:    "yaml",
+			file:    "policy.yaml",
+			content: yamlPolicy,
+			tools: map[string]bool{
+				"echo": true, "db.read": true, "db.admin.drop": false, "shell": false,
+			},
+			resources: map[string]bool{
+				"fs://docs/a.md": true, "fs://secrets": false,
+				"file:///etc/passwd": false, "file:///srv/public.txt": true,
+			},
+		},
+		{
+			name:    "json",
+			file:    "policy.json",
+			content: jsonPolicy,
+			tools: map[string]bool{
+				"echo": true, "shell": false, "admin_reset": false,
+			},
+			resources: map[string]bool{"fs://docs/a.md": true, "fs://secrets": false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			ac := NewAccessControl(PermissionAllow)
+			ac.DenyTool("echo") // replaced by the file's rules
+			if err := ac.LoadFromFile(path); err != nil {
+				t.Fatalf("LoadFromFile() error = %v", err)
+			}
+			for tool, allowed := range tt.tools {
+				if err := ac.CheckTool(tool); (err == nil) != allowed {
+					t.Errorf("CheckTool(%q) error = %v, want allowed=%v", tool, err, allowed)
+				}
+			}
+			for uri, allowed := range tt.resources {
+				if err := ac.CheckResource(uri); (err == nil) != allowed {
+					t.Errorf("CheckResource(%q) error = %v, want allowed=%v", uri, err, allowed)
+				}
+			}
+		})
+	}
+}
+
+func TestAccessControl_LoadFromFile_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]string{
+		"missing_default.yaml": "tools:\n  allow: [echo]\n",
+		"bad_default.yaml":     "default: maybe\n",
+		"unknown_field.yaml":   "default: allow\ntools:\n  alow: [echo]\n",
+		"resource_groups.yaml": "default: allow\nresources:\n  deny_groups: [fs]\n",
+		"bad_scheme.yaml":      "default: allow\nscheme_defaults:\n  file: nope\n",
+		"unknown_field.json":   `{"default": "allow", "tool": {}}`,
+	}
+	for file, content := range tests {
+		t.Run(file, func(t *testing.T) {
+			path := filepath.Join(dir, file)
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			ac := NewAccessControl(PermissionAllow)
+			ac.DenyTool("shell")
+			if err := ac.LoadFromFile(path); err == nil {
+				t.Fatal("LoadFromFile() should fail")
+			}
+			// Rules are unchanged after a failed load
+			if err := ac.CheckTool("shell"); err == nil {
+				t.Error("shell should still be denied after a failed load")
+			}
+			if err := ac.CheckTool("echo"); err != nil {
+				t.Errorf("echo should still be allowed after a failed load: %v", err)
+			}
+		})
+	}
+
+	if err := NewAccessControl(PermissionAllow).LoadFromFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("LoadFromFile() of a missing file should fail")
+	}
+}
+
+func TestAccessControl_WatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	write := func(content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		// Set the time explicitly: writes within the filesystem's timestamp
+		// granularity could otherwise look unchanged
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitFor := func(desc string, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", desc)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	start := time.Now().Add(-time.Hour)
+	write("default: allow\ntools:\n  deny: [shell]\n", start)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 10)
+	ac := NewAccessControl(PermissionAllow)
+	if err := ac.WatchFile(ctx, path, 10*time.Millisecond, func(err error) { errs <- err }); err != nil {
+		t.Fatalf("WatchFile() error = %v", err)
+	}
+	if err := ac.CheckTool("shell"); err == nil {
+		t.Fatal("shell should be denied by the initial policy")
+	}
+
+	write("default: allow\ntools:\n  deny: [echo]\n", start.Add(time.Second))
+	waitFor("reload", func() bool { return ac.CheckTool("shell") == nil && ac.CheckTool("echo") != nil })
+
+	// An invalid file is reported and the previous rules are kept
+	write("default: nope\n", start.Add(2*time.Second))
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Error("onError called with nil")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("invalid policy was not reported")
+	}
+	if err := ac.CheckTool("echo"); err == nil {
+		t.Error("echo should stay denied after an invalid reload")
+	}
+
+	// No reloads after ctx is done
+	cancel()
+	time.Sleep(30 * time.Millisecond)
+	write("default: deny\n", start.Add(3*time.Second))
+	time.Sleep(50 * time.Millisecond)
+	if err := ac.CheckTool("shell"); err != nil {
+		t.Errorf("policy reloaded after ctx was done: %v", err)
+	}
+
+	if err := NewAccessControl(PermissionAllow).WatchFile(context.Background(), path+".missing", 0, nil); err == nil {
+		t.Error("WatchFile() of a missing file should fail")
+	}
+}
@@ -0,0 +1,212 @@
+package security
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// AdaptiveConfig tunes how an AdaptiveRateLimiter reacts to errors
+type AdaptiveConfig struct {
+	// ErrorThreshold is the error rate (0.0-1.0) above which throttling begins.
+	// Default: 0.1 (10% errors)
+	ErrorThreshold float64
+
+	// MinRate is the lowest fraction of the base limit the limiter will scale
+	// down to, however high the error rate climbs. Default: 0.1
+	MinRate float64
+
+	// ErrorWindow is the period over which the error rate is measured.
+	// Older outcomes are forgotten, which is what lets the limit recover.
+	// Outcomes are counted in slices of a tenth of the window, so they may
+	// be forgotten up to one slice early.
+	// Default: the underlying limiter's window
+	ErrorWindow time.Duration
+
+	// MinSamples is the number of outcomes required in the error window
+	// before the limiter starts adapting. Default: 10
+	MinSamples int
+}
+
+// errorBuckets is the number of slices the error window is divided into.
+// Outcomes are counted per slice, so memory stays fixed however many
+// requests arrive, and old slices expire one at a time.
+const errorBuckets = 10
+
+// outcomeBucket counts the outcomes reported during one slice of the error
+// window
+type outcomeBucket struct {
+	start  time.Time
+	total  int
+	failed int
+}
+
+// AdaptiveRateLimiter wraps a RateLimiter and lowers the effective per-client
+// limit as the observed error rate rises, restoring it as errors subside.
+// This lets a struggling backend recover instead of being hammered.
+//
+// Callers report request outcomes via RecordSuccess/RecordError (or Record).
+// While the error rate is at or below ErrorThreshold the full limit applies;
+// above it the limit shrinks linearly towards MinRate as errors approach 100%.
+//
+// Example:
+//
+//	arl := security.NewAdaptiveRateLimiter(time.Minute, 100, security.AdaptiveConfig{})
+//	if !arl.Allow(clientID) {
+//		return rateLimited()
+//	}
+//	result, err := callBackend()
+//	arl.Record(err)
+type AdaptiveRateLimiter struct {
+	mu        sync.Mutex
+	limiter   *RateLimiter
+	config    AdaptiveConfig
+	buckets   [errorBuckets]outcomeBucket
+	bucketLen time.Duration
+	baseLimit int
+}
+
+// NewAdaptiveRateLimiter creates an adaptive rate limiter
+// window: time window (e.g., 1 minute)
+// maxRequests: maximum requests allowed in the window when healthy
+func NewAdaptiveRateLimiter(window time.Duration, maxRequests int, config AdaptiveConfig) *AdaptiveRateLimiter {
+	if config.ErrorThreshold <= 0 {
+		config.ErrorThreshold = 0.1
+	}
+	if config.MinRate <= 0 || config.MinRate > 1 {
+		config.MinRate = 0.1
+	}
+	if config.ErrorWindow <= 0 {
+		config.ErrorWindow = window
+	}
+	if config.MinSamples <= 0 {
+		config.MinSamples = 10
+	}
+
+	bucketLen := config.ErrorWindow / errorBuckets
+	if bucketLen <= 0 {
+		bucketLen = 1
+	}
+
+	return &AdaptiveRateLimiter{
+		limiter:   NewRateLimiter(window, maxRequests),
+		config:    config,
+		bucketLen: bucketLen,
+		baseLimit: maxRequests,
+	}
+}
+
+// Allow checks if a request from the given client should be allowed under
+// the current effective limit
+func (a *AdaptiveRateLimiter) Allow(clientID string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	used := a.baseLimit - a.limiter.GetRemaining(clientID)
+	if used >= a.effectiveLimitLocked() {
+		return false
+	}
+	return a.limiter.Allow(clientID)
+}
+
+// Record reports the outcome of a request; a non-nil err counts as an error
+func (a *AdaptiveRateLimiter) Record(err error) {
+	if err != nil {
+		a.RecordError()
+	} else {
+		a.RecordSuccess()
+	}
+}
+
+// RecordSuccess reports a successful request
+func (a *AdaptiveRateLimiter) RecordSuccess() {
+	a.record(false)
+}
+
+// RecordError reports a failed request
+func (a *AdaptiveRateLimiter) RecordError() {
+	a.record(true)
+}
+
+func (a *AdaptiveRateLimiter) record(failed bool) {
+	now := time.Now()
+	start := now.Truncate(a.bucketLen)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	b := &a.buckets[(start.UnixNano()/int64(a.bucketLen))%errorBuckets]
+	if !b.start.Equal(start) {
+		// The slot still holds a slice that has left the window
+		*b = outcomeBucket{start: start}
+	}
+	b.total++
+	if failed {
+		b.failed++
+	}
+}
+
+// ErrorRate returns the error rate (0.0-1.0) observed within the error window
+func (a *AdaptiveRateLimiter) ErrorRate() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.errorRateLocked()
+}
+
+// EffectiveLimit returns the per-client limit currently being enforced
+func (a *AdaptiveRateLimiter) EffectiveLimit() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.effectiveLimitLocked()
+}
+
+// Stop stops the underlying rate limiter and cleans up resources
+func (a *AdaptiveRateLimiter) Stop() {
+	a.limiter.Stop()
+}
+
+// countsLocked returns the number of outcomes, and of failed outcomes,
+// reported in slices that started within the error window
+// Caller must hold a.mu
+func (a *AdaptiveRateLimiter) countsLocked() (total, failed int) {
+	cutoff := time.Now().Add(-a.config.ErrorWindow)
+	for _, b := range a.buckets {
+		if b.start.After(cutoff) {
+			total += b.total
+			failed += b.failed
+		}
+	}
+	return total, failed
+}
+
+// errorRateLocked computes the error rate within the error window
+// Caller must hold a.mu
+func (a *AdaptiveRateLimiter) errorRateLocked() float64 {
+	total, failed := a.countsLocked()
+	if total == 0 {
+		return 0
+	}
+	return float64(failed) / float64(total)
+}
+
+// effectiveLimitLocked scales the base limit by the current error rate
+// Caller must hold a.mu
+func (a *AdaptiveRateLimiter) effectiveLimitLocked() int {
+	total, failed := a.countsLocked()
+	if total < a.config.MinSamples {
+		return a.baseLimit
+	}
+	rate := float64(failed) / float64(total)
+	if rate <= a.config.ErrorThreshold {
+		return a.baseLimit
+	}
+
+	// Scale linearly from 1.0 at the threshold down to MinRate at 100% errors
+	excess := (rate - a.config.ErrorThreshold) / (1 - a.config.ErrorThreshold)
+	factor := 1 - excess*(1-a.config.MinRate)
+
+	limit := int(math.Round(float64(a.baseLimit) * factor))
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
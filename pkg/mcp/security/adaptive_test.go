@@ -0,0 +1,136 @@
+package security
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveRateLimiter_Defaults(t *testing.T) {
+	arl := NewAdaptiveRateLimiter(time.Minute, 100, AdaptiveConfig{})
+	defer arl.Stop()
+
+	if arl.config.ErrorThreshold != 0.1 {
+		t.Errorf("ErrorThreshold = %v, want 0.1", arl.config.ErrorThreshold)
+	}
+	if arl.config.MinRate != 0.1 {
+		t.Errorf("MinRate = %v, want 0.1", arl.config.MinRate)
+	}
+	if arl.config.ErrorWindow != time.Minute {
+		t.Errorf("ErrorWindow = %v, want %v", arl.config.ErrorWindow, time.Minute)
+	}
+	if arl.config.MinSamples != 10 {
+		t.Errorf("MinSamples = %d, want 10", arl.config.MinSamples)
+	}
+	if limit := arl.EffectiveLimit(); limit != 100 {
+		t.Errorf("EffectiveLimit() = %d, want 100", limit)
+	}
+}
+
+func TestAdaptiveRateLimiter_DropsAndRecovers(t *testing.T) {
+	arl := NewAdaptiveRateLimiter(time.Minute, 100, AdaptiveConfig{
+		ErrorThreshold: 0.1,
+		MinRate:        0.1,
+		ErrorWindow:    100 * time.Millisecond,
+		MinSamples:     5,
+	})
+	defer arl.Stop()
+
+	// Healthy: all successes keep the full limit
+	for i := 0; i < 10; i++ {
+		arl.RecordSuccess()
+	}
+	if limit := arl.EffectiveLimit(); limit != 100 {
+		t.Fatalf("healthy EffectiveLimit() = %d, want 100", limit)
+	}
+
+	// Rising errors: 50% error rate should reduce the limit
+	for i := 0; i < 10; i++ {
+		arl.RecordError()
+	}
+	moderate := arl.EffectiveLimit()
+	if moderate >= 100 {
+		t.Errorf("EffectiveLimit() at 50%% errors = %d, want < 100", moderate)
+	}
+
+	// More errors: limit should drop further
+	for i := 0; i < 30; i++ {
+		arl.Record(errors.New("backend failure"))
+	}
+	severe := arl.EffectiveLimit()
+	if severe >= moderate {
+		t.Errorf("EffectiveLimit() at higher error rate = %d, want < %d", severe, moderate)
+	}
+	if severe < 10 {
+		t.Errorf("EffectiveLimit() = %d, want >= MinRate floor of 10", severe)
+	}
+
+	// Errors subside: once the error window passes, successes restore the limit
+	time.Sleep(150 * time.Millisecond)
+	for i := 0; i < 10; i++ {
+		arl.Record(nil)
+	}
+	if limit := arl.EffectiveLimit(); limit != 100 {
+		t.Errorf("recovered EffectiveLimit() = %d, want 100", limit)
+	}
+	if rate := arl.ErrorRate(); rate != 0 {
+		t.Errorf("recovered ErrorRate() = %v, want 0", rate)
+	}
+}
+
+func TestAdaptiveRateLimiter_AllowHonorsEffectiveLimit(t *testing.T) {
+	arl := NewAdaptiveRateLimiter(time.Minute, 10, AdaptiveConfig{
+		ErrorThreshold: 0.1,
+		MinRate:        0.2,
+		MinSamples:     1,
+	})
+	defer arl.Stop()
+
+	// 100% errors drives the limit to the floor: 10 * 0.2 = 2
+	arl.RecordError()
+	if limit := arl.EffectiveLimit(); limit != 2 {
+		t.Fatalf("EffectiveLimit() = %d, want 2", limit)
+	}
+
+	for i := 0; i < 2; i++ {
+		if !arl.Allow("client1") {
+			t.Errorf("request %d should be allowed", i+1)
+		}
+	}
+	if arl.Allow("client1") {
+		t.Error("request beyond effective limit should be denied")
+	}
+}
+
+func TestAdaptiveRateLimiter_MinSamples(t *testing.T) {
+	arl := NewAdaptiveRateLimiter(time.Minute, 50, AdaptiveConfig{MinSamples: 5})
+	defer arl.Stop()
+
+	// Too few samples to adapt, even at 100% errors
+	for i := 0; i < 4; i++ {
+		arl.RecordError()
+	}
+	if limit := arl.EffectiveLimit(); limit != 50 {
+		t.Errorf("EffectiveLimit() below MinSamples = %d, want 50", limit)
+	}
+}
+
+func TestAdaptiveRateLimiter_CountsOutcomesInFixedMemory(t *testing.T) {
+	arl := NewAdaptiveRateLimiter(time.Minute, 50, AdaptiveConfig{})
+	defer arl.Stop()
+
+	for i := 0; i < 100000; i++ {
+		arl.Record(nil)
+		arl.Record(errors.New("backend failure"))
+	}
+
+	arl.mu.Lock()
+	total, failed := arl.countsLocked()
+	arl.mu.Unlock()
+	if total != 200000 || failed != 100000 {
+		t.Errorf("counts = %d total, %d failed, want 200000 and 100000", total, failed)
+	}
+	if rate := arl.ErrorRate(); rate != 0.5 {
+		t.Errorf("ErrorRate() = %v, want 0.5", rate)
+	}
+}
@@ -0,0 +1,61 @@
+package security
+
+import (
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+)
+
+// AuditDecision is the outcome of an access check
+type AuditDecision string
+
+const (
+	// AuditAllow means access was granted
+	AuditAllow AuditDecision = "allow"
+	// AuditDeny means access was denied
+	AuditDeny AuditDecision = "deny"
+)
+
+// AuditEvent records one AccessControl check
+type AuditEvent struct {
+	ClientID  string        // client that asked, "" if unknown
+	Resource  string        // "tool" or "resource"
+	Name      string        // tool name or resource URI
+	Decision  AuditDecision // allow or deny
+	Timestamp time.Time
+}
+
+// audit reports a check to AuditFunc, if set. The caller must not hold ac.mu.
+func (ac *AccessControl) audit(clientID, resource, name string, err error) {
+	if ac.AuditFunc == nil {
+		return
+	}
+	decision := AuditAllow
+	if err != nil {
+		decision = AuditDeny
+	}
+	ac.AuditFunc(AuditEvent{
+		ClientID:  clientID,
+		Resource:  resource,
+		Name:      name,
+		Decision:  decision,
+		Timestamp: time.Now(),
+	})
+}
+
+// AuditToLogger returns an AuditFunc that logs allowed access at info level
+// and denied access at warn level, with the client ID as context.
+//
+// Example:
+//
+//	ac := security.NewAccessControl(security.PermissionDeny)
+//	ac.AuditFunc = security.AuditToLogger(logger)
+func AuditToLogger(l *logging.Logger) func(event AuditEvent) {
+	return func(event AuditEvent) {
+		if event.Decision == AuditDeny {
+			l.Warn(event.ClientID, "Access denied to %s %s", event.Resource, event.Name)
+			return
+		}
+		l.Info(event.ClientID, "Access allowed to %s %s", event.Resource, event.Name)
+	}
+}
@@ -0,0 +1,104 @@
+package security
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidl71/mcp-go-core/pkg/mcp/logging"
+)
+
+func TestAccessControl_AuditFunc(t *testing.T) {
+	ac := NewAccessControl(PermissionAllow)
+	ac.DenyTool("admin")
+	ac.DenyResourcePattern("secret://*")
+
+	var events []AuditEvent
+	ac.AuditFunc = func(event AuditEvent) {
+		events = append(events, event)
+	}
+
+	before := time.Now()
+	_ = ac.CheckToolForClient("client1", "echo")
+	_ = ac.CheckToolForClient("client1", "admin")
+	_ = ac.CheckResourceForClient("client2", "secret://key")
+	_ = ac.CheckResource("file://readme")
+
+	want := []AuditEvent{
+		{ClientID: "client1", Resource: "tool", Name: "echo", Decision: AuditAllow},
+		{ClientID: "client1", Resource: "tool", Name: "admin", Decision: AuditDeny},
+		{ClientID: "client2", Resource: "resource", Name: "secret://key", Decision: AuditDeny},
+		{ClientID: "", Resource: "resource", Name: "file://readme", Decision: AuditAllow},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d audit events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, event := range events {
+		if event.Timestamp.Before(before) || event.Timestamp.After(time.Now()) {
+			t.Errorf("event %d timestamp %v out of range", i, event.Timestamp)
+		}
+		event.Timestamp = time.Time{}
+		if event != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, event, want[i])
+		}
+	}
+}
+
+func TestAccessControl_AuditFuncReentrant(t *testing.T) {
+	ac := NewAccessControl(PermissionAllow)
+	done := make(chan struct{})
+	ac.AuditFunc = func(event AuditEvent) {
+		// Calling back into ac must not deadlock
+		if event.Name == "echo" {
+			ac.DenyTool("echo")
+		}
+	}
+
+	go func() {
+		_ = ac.CheckTool("echo")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("AuditFunc calling into AccessControl deadlocked")
+	}
+	if err := ac.CheckTool("echo"); err == nil {
+		t.Error("DenyTool from the audit hook did not take effect")
+	}
+}
+
+func TestAuditToLogger(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	original := os.Stderr
+	os.Stderr = w
+	logger := logging.NewLogger() // writes to the redirected stderr
+	os.Stderr = original
+
+	ac := NewAccessControl(PermissionDeny)
+	ac.AllowTool("echo")
+	ac.AuditFunc = AuditToLogger(logger)
+	_ = ac.CheckToolForClient("client1", "echo")
+	_ = ac.CheckToolForClient("client1", "admin")
+	w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read log output: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2:\n%s", len(lines), out)
+	}
+	if !strings.Contains(lines[0], "INFO") || !strings.Contains(lines[0], "Access allowed to tool echo") || !strings.Contains(lines[0], "client1") {
+		t.Errorf("allow line = %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "WARN") || !strings.Contains(lines[1], "Access denied to tool admin") {
+		t.Errorf("deny line = %s", lines[1])
+	}
+}
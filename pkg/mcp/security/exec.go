@@ -0,0 +1,204 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Default limits applied by SandboxedExec when the policy leaves them unset
+const (
+	DefaultExecTimeout        = 30 * time.Second
+	DefaultExecMaxOutputBytes = 1 << 20 // 1 MiB per stream
+)
+
+// ExecPolicy restricts what SandboxedExec may run and how
+type ExecPolicy struct {
+	// AllowedCommands lists the commands that may be executed. Entries are
+	// matched exactly against the cmd argument, so "git" does not permit
+	// "/usr/bin/git" and vice versa. An empty list denies everything.
+	AllowedCommands []string
+
+	// Root is the directory the working directory is jailed to. Required.
+	Root string
+
+	// Dir is the working directory, relative to Root (or absolute inside it).
+	// Defaults to Root.
+	Dir string
+
+	// Env is the process environment. When nil, the command gets a minimal
+	// environment holding only the server's PATH, so secrets in the server's
+	// environment are not leaked to it (unless InheritEnv is set).
+	Env []string
+
+	// InheritEnv passes the server's full environment to the command when
+	// Env is nil. Off by default.
+	InheritEnv bool
+
+	// MaxOutputBytes caps captured stdout and stderr independently.
+	// Output beyond the cap is discarded and the result is marked truncated.
+	// Defaults to DefaultExecMaxOutputBytes.
+	MaxOutputBytes int
+
+	// Timeout bounds the command's run time. Defaults to DefaultExecTimeout.
+	Timeout time.Duration
+}
+
+// ExecResult holds the outcome of a sandboxed command
+type ExecResult struct {
+	Stdout          string
+	Stderr          string
+	ExitCode        int
+	StdoutTruncated bool
+	StderrTruncated bool
+	Duration        time.Duration
+}
+
+// CommandDeniedError is returned when a command is not in the policy allowlist
+type CommandDeniedError struct {
+	Command string
+}
+
+func (e *CommandDeniedError) Error() string {
+	return fmt.Sprintf("command not allowed: %s", e.Command)
+}
+
+// IsCommandDenied checks if error is a CommandDeniedError
+func IsCommandDenied(err error) bool {
+	var denied *CommandDeniedError
+	return errors.As(err, &denied)
+}
+
+// SandboxedExec runs cmd with args under the given policy.
+//
+// The command must appear in policy.AllowedCommands and the working directory
+// must resolve inside policy.Root (checked with ValidatePath). Arguments are
+// passed directly to the process, never through a shell.
+//
+// A command that runs and exits non-zero is not an error: the exit code is
+// reported in the result. An error is returned when the command is denied,
+// cannot be started, or is killed by the timeout or context cancellation; in
+// the latter case the partial result is returned alongside the error.
+func SandboxedExec(ctx context.Context, cmd string, args []string, policy ExecPolicy) (*ExecResult, error) {
+	if !commandAllowed(cmd, policy.AllowedCommands) {
+		return nil, &CommandDeniedError{Command: cmd}
+	}
+
+	dir := policy.Dir
+	if dir == "" {
+		dir = "."
+	}
+	workDir, err := ValidatePathExists(dir, policy.Root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid working directory: %w", err)
+	}
+
+	timeout := policy.Timeout
+	if timeout <= 0 {
+		timeout = DefaultExecTimeout
+	}
+	maxOutput := policy.MaxOutputBytes
+	if maxOutput <= 0 {
+		maxOutput = DefaultExecMaxOutputBytes
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stdout := &limitedBuffer{limit: maxOutput}
+	stderr := &limitedBuffer{limit: maxOutput}
+
+	c := exec.CommandContext(ctx, cmd, args...)
+	c.Dir = workDir
+	c.Env = execEnv(policy)
+	c.Stdout = stdout
+	c.Stderr = stderr
+	// Don't wait forever on pipes held open by orphaned grandchildren
+	c.WaitDelay = time.Second
+
+	start := time.Now()
+	runErr := c.Run()
+	result := &ExecResult{
+		Stdout:          stdout.String(),
+		Stderr:          stderr.String(),
+		ExitCode:        -1,
+		StdoutTruncated: stdout.truncated,
+		StderrTruncated: stderr.truncated,
+		Duration:        time.Since(start),
+	}
+	if c.ProcessState != nil {
+		result.ExitCode = c.ProcessState.ExitCode()
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if errors.Is(ctxErr, context.DeadlineExceeded) {
+			return result, fmt.Errorf("command %s timed out after %v: %w", cmd, timeout, ctxErr)
+		}
+		return result, fmt.Errorf("command %s cancelled: %w", cmd, ctxErr)
+	}
+
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return nil, fmt.Errorf("failed to run command %s: %w", cmd, runErr)
+	}
+
+	return result, nil
+}
+
+// execEnv returns the environment for a command run under policy
+func execEnv(policy ExecPolicy) []string {
+	switch {
+	case policy.Env != nil:
+		return policy.Env
+	case policy.InheritEnv:
+		return os.Environ()
+	}
+	// A non-nil empty slice, so exec.Cmd doesn't inherit the environment
+	env := []string{}
+	if path, ok := os.LookupEnv("PATH"); ok {
+		env = append(env, "PATH="+path)
+	}
+	return env
+}
+
+// commandAllowed reports whether cmd exactly matches an allowlist entry
+func commandAllowed(cmd string, allowed []string) bool {
+	if cmd == "" {
+		return false
+	}
+	for _, entry := range allowed {
+		if entry == cmd {
+			return true
+		}
+	}
+	return false
+}
+
+// limitedBuffer is an io.Writer that keeps at most limit bytes and silently
+// discards the rest, so a chatty command cannot exhaust server memory
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if room := b.limit - b.buf.Len(); room < len(p) {
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		b.truncated = true
+		return n, nil
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,146 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSandboxedExec_Allowed(t *testing.T) {
+	root := t.TempDir()
+	policy := ExecPolicy{
+		AllowedCommands: []string{"sh"},
+		Root:            root,
+	}
+
+	result, err := SandboxedExec(context.Background(), "sh", []string{"-c", "pwd; echo oops >&2; exit 3"}, policy)
+	if err != nil {
+		t.Fatalf("SandboxedExec() error = %v", err)
+	}
+	if result.ExitCode != 3 {
+		t.Errorf("ExitCode = %d, want 3", result.ExitCode)
+	}
+	if !strings.HasSuffix(strings.TrimSpace(result.Stdout), root) {
+		t.Errorf("Stdout = %q, want working dir %q", result.Stdout, root)
+	}
+	if strings.TrimSpace(result.Stderr) != "oops" {
+		t.Errorf("Stderr = %q, want %q", result.Stderr, "oops")
+	}
+}
+
+func TestSandboxedExec_Denied(t *testing.T) {
+	root := t.TempDir()
+	policy := ExecPolicy{
+		AllowedCommands: []string{"echo"},
+		Root:            root,
+	}
+
+	tests := []struct {
+		name string
+		cmd  string
+	}{
+		{name: "not in allowlist", cmd: "rm"},
+		{name: "path to allowed name", cmd: "/bin/echo"},
+		{name: "empty", cmd: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SandboxedExec(context.Background(), tt.cmd, nil, policy)
+			if !IsCommandDenied(err) {
+				t.Errorf("SandboxedExec(%q) error = %v, want CommandDeniedError", tt.cmd, err)
+			}
+		})
+	}
+}
+
+func TestSandboxedExec_DirEscape(t *testing.T) {
+	policy := ExecPolicy{
+		AllowedCommands: []string{"echo"},
+		Root:            t.TempDir(),
+		Dir:             "../..",
+	}
+
+	if _, err := SandboxedExec(context.Background(), "echo", nil, policy); err == nil {
+		t.Error("SandboxedExec() with escaping Dir should fail")
+	}
+}
+
+func TestSandboxedExec_Timeout(t *testing.T) {
+	policy := ExecPolicy{
+		AllowedCommands: []string{"sleep"},
+		Root:            t.TempDir(),
+		Timeout:         100 * time.Millisecond,
+	}
+
+	start := time.Now()
+	_, err := SandboxedExec(context.Background(), "sleep", []string{"5"}, policy)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("SandboxedExec() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("SandboxedExec() took %v, want prompt timeout", elapsed)
+	}
+}
+
+func TestSandboxedExec_OutputLimit(t *testing.T) {
+	policy := ExecPolicy{
+		AllowedCommands: []string{"sh"},
+		Root:            t.TempDir(),
+		MaxOutputBytes:  5,
+	}
+
+	result, err := SandboxedExec(context.Background(), "sh", []string{"-c", "echo hello world"}, policy)
+	if err != nil {
+		t.Fatalf("SandboxedExec() error = %v", err)
+	}
+	if result.Stdout != "hello" {
+		t.Errorf("Stdout = %q, want %q", result.Stdout, "hello")
+	}
+	if !result.StdoutTruncated {
+		t.Error("StdoutTruncated = false, want true")
+	}
+}
+
+func TestSandboxedExec_Env(t *testing.T) {
+	t.Setenv("EXEC_TEST_SECRET", "hunter2")
+
+	tests := []struct {
+		name   string
+		policy ExecPolicy
+		want   string
+	}{
+		{"default is minimal", ExecPolicy{}, ""},
+		{"explicit env", ExecPolicy{Env: []string{"EXEC_TEST_SECRET=given"}}, "given"},
+		{"inherit opt-in", ExecPolicy{InheritEnv: true}, "hunter2"},
+		{"explicit env wins over inherit", ExecPolicy{Env: []string{}, InheritEnv: true}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := tt.policy
+			policy.AllowedCommands = []string{"sh"}
+			policy.Root = t.TempDir()
+
+			result, err := SandboxedExec(context.Background(), "sh", []string{"-c", "printf %s \"$EXEC_TEST_SECRET\""}, policy)
+			if err != nil {
+				t.Fatalf("SandboxedExec() error = %v", err)
+			}
+			if result.Stdout != tt.want {
+				t.Errorf("EXEC_TEST_SECRET = %q, want %q", result.Stdout, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecEnv_DefaultKeepsPath(t *testing.T) {
+	t.Setenv("PATH", "/usr/bin:/bin")
+	t.Setenv("EXEC_TEST_SECRET", "hunter2")
+
+	env := execEnv(ExecPolicy{})
+	if len(env) != 1 || env[0] != "PATH=/usr/bin:/bin" {
+		t.Errorf("execEnv() = %v, want [PATH=/usr/bin:/bin]", env)
+	}
+}
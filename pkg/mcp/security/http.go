@@ -0,0 +1,107 @@
+package security
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HTTP header names used to advertise rate limit state to clients
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRetryAfter         = "Retry-After"
+)
+
+// ClientIDFunc extracts a client identifier from an HTTP request
+type ClientIDFunc func(r *http.Request) string
+
+// RemoteAddrClientID identifies clients by the host portion of r.RemoteAddr
+func RemoteAddrClientID(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// RateLimitOption configures RateLimitMiddleware
+type RateLimitOption func(*rateLimitConfig)
+
+// rateLimitConfig holds optional RateLimitMiddleware settings
+type rateLimitConfig struct {
+	global *RateLimiter
+}
+
+// WithGlobalLimit adds a server-wide limiter checked alongside the per-client
+// limiter. It caps total throughput across all clients combined.
+func WithGlobalLimit(global *RateLimiter) RateLimitOption {
+	return func(c *rateLimitConfig) {
+		c.global = global
+	}
+}
+
+// RateLimitMiddleware returns HTTP middleware that enforces rl per client.
+//
+// Every response carries X-RateLimit-Limit and X-RateLimit-Remaining headers so
+// clients can self-throttle. Throttled requests are rejected with
+// 429 Too Many Requests and a Retry-After header (in whole seconds).
+// If clientID is nil, RemoteAddrClientID is used. rl may be nil to enforce
+// only a global limit, in which case the X-RateLimit headers are omitted.
+//
+// When a global limit is configured (WithGlobalLimit) and exhausted, requests
+// are rejected with 503 Service Unavailable instead, since the client itself
+// is within its own limit.
+//
+// Example:
+//
+//	rl := security.NewRateLimiter(time.Minute, 100)
+//	global := security.NewRateLimiter(time.Minute, 1000)
+//	transport.Use(security.RateLimitMiddleware(rl, nil, security.WithGlobalLimit(global)))
+func RateLimitMiddleware(rl *RateLimiter, clientID ClientIDFunc, opts ...RateLimitOption) func(http.Handler) http.Handler {
+	if clientID == nil {
+		clientID = RemoteAddrClientID
+	}
+
+	cfg := &rateLimitConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := clientID(r)
+			err := CheckRateLimits(rl, cfg.global, id)
+
+			if rl != nil {
+				w.Header().Set(HeaderRateLimitLimit, strconv.Itoa(rl.maxRequests))
+				w.Header().Set(HeaderRateLimitRemaining, strconv.Itoa(rl.GetRemaining(id)))
+			}
+
+			switch e := err.(type) {
+			case nil:
+				next.ServeHTTP(w, r)
+			case *GlobalRateLimitError:
+				w.Header().Set(HeaderRetryAfter, formatRetryAfter(e.RetryAfter))
+				http.Error(w, "server rate limit exceeded", http.StatusServiceUnavailable)
+			case *RateLimitError:
+				for name, value := range e.HTTPHeaders() {
+					w.Header().Set(name, value)
+				}
+				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			}
+		})
+	}
+}
+
+// formatRetryAfter renders a duration as a Retry-After value in whole seconds,
+// rounding up so clients never retry too early
+func formatRetryAfter(d time.Duration) string {
+	seconds := int(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.Itoa(seconds)
+}
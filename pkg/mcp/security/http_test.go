@@ -0,0 +1,149 @@
+package security
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimitMiddleware_Headers(t *testing.T) {
+	rl := NewRateLimiter(time.Minute, 2)
+	defer rl.Stop()
+
+	handler := RateLimitMiddleware(rl, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		wantStatus     int
+		wantRemaining  string
+		wantRetryAfter bool
+	}{
+		{name: "first allowed", wantStatus: http.StatusOK, wantRemaining: "1"},
+		{name: "second allowed", wantStatus: http.StatusOK, wantRemaining: "0"},
+		{name: "throttled", wantStatus: http.StatusTooManyRequests, wantRemaining: "0", wantRetryAfter: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/sse", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get(HeaderRateLimitLimit); got != "2" {
+				t.Errorf("%s = %q, want %q", HeaderRateLimitLimit, got, "2")
+			}
+			if got := rec.Header().Get(HeaderRateLimitRemaining); got != tt.wantRemaining {
+				t.Errorf("%s = %q, want %q", HeaderRateLimitRemaining, got, tt.wantRemaining)
+			}
+
+			retryAfter := rec.Header().Get(HeaderRetryAfter)
+			if !tt.wantRetryAfter {
+				if retryAfter != "" {
+					t.Errorf("%s = %q, want empty", HeaderRetryAfter, retryAfter)
+				}
+				return
+			}
+			seconds, err := strconv.Atoi(retryAfter)
+			if err != nil {
+				t.Fatalf("%s = %q is not an integer: %v", HeaderRetryAfter, retryAfter, err)
+			}
+			if seconds < 1 || seconds > 60 {
+				t.Errorf("%s = %d, want between 1 and 60", HeaderRetryAfter, seconds)
+			}
+		})
+	}
+}
+
+func TestRateLimitMiddleware_CustomClientID(t *testing.T) {
+	rl := NewRateLimiter(time.Minute, 1)
+	defer rl.Stop()
+
+	handler := RateLimitMiddleware(rl, func(r *http.Request) string {
+		return r.Header.Get("X-Client-ID")
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, client := range []string{"a", "b"} {
+		req := httptest.NewRequest(http.MethodGet, "/sse", nil)
+		req.Header.Set("X-Client-ID", client)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("client %q status = %d, want %d", client, rec.Code, http.StatusOK)
+		}
+	}
+}
+
+func TestRemoteAddrClientID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "198.51.100.7:5555"
+	if got := RemoteAddrClientID(req); got != "198.51.100.7" {
+		t.Errorf("RemoteAddrClientID() = %q, want %q", got, "198.51.100.7")
+	}
+
+	req.RemoteAddr = "no-port"
+	if got := RemoteAddrClientID(req); got != "no-port" {
+		t.Errorf("RemoteAddrClientID() = %q, want %q", got, "no-port")
+	}
+}
+
+func TestRateLimitMiddleware_GlobalLimit(t *testing.T) {
+	perClient := NewRateLimiter(time.Minute, 2)
+	defer perClient.Stop()
+	global := NewRateLimiter(time.Minute, 3)
+	defer global.Stop()
+
+	handler := RateLimitMiddleware(perClient, nil, WithGlobalLimit(global))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Three clients, each well within its own limit, share the global cap of 3
+	clients := []string{"192.0.2.1:1", "192.0.2.2:1", "192.0.2.3:1", "192.0.2.4:1"}
+	wantStatus := []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusServiceUnavailable}
+
+	for i, addr := range clients {
+		req := httptest.NewRequest(http.MethodGet, "/sse", nil)
+		req.RemoteAddr = addr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != wantStatus[i] {
+			t.Errorf("request %d from %s: status = %d, want %d", i+1, addr, rec.Code, wantStatus[i])
+		}
+		if rec.Code == http.StatusServiceUnavailable && rec.Header().Get(HeaderRetryAfter) == "" {
+			t.Errorf("request %d: missing %s header on global throttle", i+1, HeaderRetryAfter)
+		}
+	}
+}
+
+func TestRateLimitMiddleware_GlobalLimitOnly(t *testing.T) {
+	global := NewRateLimiter(time.Minute, 1)
+	defer global.Stop()
+
+	handler := RateLimitMiddleware(nil, nil, WithGlobalLimit(global))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	wantStatus := []int{http.StatusOK, http.StatusServiceUnavailable}
+	for i, want := range wantStatus {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+
+		if rec.Code != want {
+			t.Errorf("request %d: status = %d, want %d", i+1, rec.Code, want)
+		}
+		if limit := rec.Header().Get(HeaderRateLimitLimit); limit != "" {
+			t.Errorf("request %d: %s = %q without a per-client limiter, want none", i+1, HeaderRateLimitLimit, limit)
+		}
+	}
+}
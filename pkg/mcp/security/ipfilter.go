@@ -0,0 +1,172 @@
+package security
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// IPFilter gates requests by source IP using CIDR allow and deny lists.
+//
+// Evaluation order:
+//   - An address matching any deny entry is rejected
+//   - If the allow list is non-empty, the address must match an allow entry
+//   - Otherwise the address is accepted
+//
+// Entries may be CIDRs ("10.0.0.0/8") or single addresses ("192.0.2.1").
+type IPFilter struct {
+	allow   []*net.IPNet
+	deny    []*net.IPNet
+	proxies []*net.IPNet
+
+	// TrustForwardedFor takes the client IP from X-Forwarded-For. Only
+	// enable this behind a reverse proxy that appends to the header, since
+	// it is otherwise client-controlled. See ClientIP.
+	TrustForwardedFor bool
+}
+
+// NewIPFilter creates an IP filter from allow and deny lists
+// Returns an error if any entry is not a valid IP or CIDR.
+func NewIPFilter(allow, deny []string) (*IPFilter, error) {
+	allowNets, err := parseCIDRs(allow)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allow list: %w", err)
+	}
+	denyNets, err := parseCIDRs(deny)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deny list: %w", err)
+	}
+	return &IPFilter{
+		allow: allowNets,
+		deny:  denyNets,
+	}, nil
+}
+
+// parseCIDRs parses CIDR strings, treating bare IPs as single-host networks
+func parseCIDRs(entries []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address: %q", entry)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR: %q", entry)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// SetTrustedProxies sets the proxies (IPs or CIDRs) whose X-Forwarded-For
+// entries are skipped when looking for the client IP. When set, the header is
+// only honored for requests arriving directly from one of these proxies.
+// Returns an error if any entry is not a valid IP or CIDR.
+func (f *IPFilter) SetTrustedProxies(proxies []string) error {
+	nets, err := parseCIDRs(proxies)
+	if err != nil {
+		return fmt.Errorf("invalid trusted proxy list: %w", err)
+	}
+	f.proxies = nets
+	return nil
+}
+
+// trustedProxy reports whether ip is one of the trusted proxies
+func (f *IPFilter) trustedProxy(ip net.IP) bool {
+	for _, n := range f.proxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed reports whether the given address passes the filter
+func (f *IPFilter) Allowed(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, n := range f.deny {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	if len(f.allow) == 0 {
+		return true
+	}
+	for _, n := range f.allow {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP extracts the source IP of a request. Returns nil if no valid
+// address is found.
+//
+// When TrustForwardedFor is set, X-Forwarded-For is walked from the right,
+// skipping trusted proxies (see SetTrustedProxies), and the first other hop
+// is the client; without trusted proxies that is the rightmost entry, added
+// by the proxy the request came from. Entries left of the client are
+// client-controlled and never used. A malformed entry makes the whole chain
+// untrustworthy, so nil is returned and the request is rejected.
+func (f *IPFilter) ClientIP(r *http.Request) net.IP {
+	remote := net.ParseIP(RemoteAddrClientID(r))
+	if !f.TrustForwardedFor || remote == nil {
+		return remote
+	}
+	if len(f.proxies) > 0 && !f.trustedProxy(remote) {
+		return remote
+	}
+	values := r.Header.Values("X-Forwarded-For")
+	if len(values) == 0 {
+		return remote
+	}
+
+	hops := strings.Split(strings.Join(values, ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			return nil
+		}
+		if i == 0 || !f.trustedProxy(ip) {
+			return ip
+		}
+	}
+	return nil
+}
+
+// IPFilterMiddleware returns HTTP middleware that rejects requests from
+// disallowed source IPs with 403 Forbidden before reaching next.
+//
+// Example:
+//
+//	filter, err := security.NewIPFilter([]string{"10.0.0.0/8"}, []string{"10.0.0.13"})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	transport.Use(security.IPFilterMiddleware(filter))
+func IPFilterMiddleware(f *IPFilter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !f.Allowed(f.ClientIP(r)) {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package security
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewIPFilter_Invalid(t *testing.T) {
+	if _, err := NewIPFilter([]string{"not-an-ip"}, nil); err == nil {
+		t.Error("NewIPFilter() with invalid allow entry should return error")
+	}
+	if _, err := NewIPFilter(nil, []string{"10.0.0.0/99"}); err == nil {
+		t.Error("NewIPFilter() with invalid deny entry should return error")
+	}
+}
+
+func TestIPFilter_SetTrustedProxies_Invalid(t *testing.T) {
+	filter, err := NewIPFilter(nil, nil)
+	if err != nil {
+		t.Fatalf("NewIPFilter() error = %v", err)
+	}
+	if err := filter.SetTrustedProxies([]string{"proxy.example"}); err == nil {
+		t.Error("SetTrustedProxies() with an invalid entry should fail")
+	}
+}
+
+func TestIPFilter_Allowed(t *testing.T) {
+	filter, err := NewIPFilter(
+		[]string{"10.0.0.0/8", "2001:db8::/32", "192.0.2.1"},
+		[]string{"10.0.0.13"},
+	)
+	if err != nil {
+		t.Fatalf("NewIPFilter() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ip   string
+		want bool
+	}{
+		{name: "in allowed CIDR", ip: "10.1.2.3", want: true},
+		{name: "denied overrides allowed CIDR", ip: "10.0.0.13", want: false},
+		{name: "single allowed address", ip: "192.0.2.1", want: true},
+		{name: "neighbor of single address", ip: "192.0.2.2", want: false},
+		{name: "outside allow list", ip: "172.16.0.1", want: false},
+		{name: "allowed IPv6", ip: "2001:db8::1", want: true},
+		{name: "disallowed IPv6", ip: "2001:db9::1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filter.Allowed(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("Allowed(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIPFilter_DenyOnly(t *testing.T) {
+	filter, err := NewIPFilter(nil, []string{"203.0.113.0/24"})
+	if err != nil {
+		t.Fatalf("NewIPFilter() error = %v", err)
+	}
+
+	if filter.Allowed(net.ParseIP("203.0.113.5")) {
+		t.Error("denied CIDR should be rejected")
+	}
+	if !filter.Allowed(net.ParseIP("198.51.100.5")) {
+		t.Error("empty allow list should accept addresses not denied")
+	}
+	if filter.Allowed(nil) {
+		t.Error("nil IP should be rejected")
+	}
+}
+
+func TestIPFilterMiddleware(t *testing.T) {
+	filter, err := NewIPFilter([]string{"10.0.0.0/8"}, nil)
+	if err != nil {
+		t.Fatalf("NewIPFilter() error = %v", err)
+	}
+
+	reached := false
+	handler := IPFilterMiddleware(filter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		remoteAddr     string
+		forwardedFor   string
+		trustForward   bool
+		trustedProxies []string
+		wantStatus     int
+		wantReachNext  bool
+	}{
+		{name: "allowed remote addr", remoteAddr: "10.0.0.1:1234", wantStatus: http.StatusOK, wantReachNext: true},
+		{name: "denied remote addr", remoteAddr: "192.0.2.1:1234", wantStatus: http.StatusForbidden},
+		{name: "forwarded header ignored when untrusted", remoteAddr: "192.0.2.1:1234", forwardedFor: "10.0.0.1", wantStatus: http.StatusForbidden},
+		{name: "trusted proxy forwards allowed client", remoteAddr: "192.0.2.1:1234", forwardedFor: "10.0.0.1", trustForward: true, wantStatus: http.StatusOK, wantReachNext: true},
+		{name: "trusted proxy forwards denied client", remoteAddr: "10.0.0.1:1234", forwardedFor: "192.0.2.50", trustForward: true, wantStatus: http.StatusForbidden},
+		{name: "spoofed leftmost entry ignored", remoteAddr: "10.0.0.1:1234", forwardedFor: "10.0.0.7, 192.0.2.50", trustForward: true, wantStatus: http.StatusForbidden},
+		{name: "proxy chain skips trusted hops", remoteAddr: "192.0.2.1:1234", forwardedFor: "192.0.2.50, 10.0.0.1, 192.0.2.2", trustForward: true, trustedProxies: []string{"192.0.2.0/30"}, wantStatus: http.StatusOK, wantReachNext: true},
+		{name: "proxy chain stops at first untrusted hop", remoteAddr: "192.0.2.1:1234", forwardedFor: "10.0.0.1, 198.51.100.9, 192.0.2.2", trustForward: true, trustedProxies: []string{"192.0.2.0/30"}, wantStatus: http.StatusForbidden},
+		{name: "header ignored from untrusted peer", remoteAddr: "192.0.2.50:1234", forwardedFor: "10.0.0.1", trustForward: true, trustedProxies: []string{"192.0.2.0/30"}, wantStatus: http.StatusForbidden},
+		{name: "malformed forwarded header rejected", remoteAddr: "10.0.0.1:1234", forwardedFor: "garbage", trustForward: true, wantStatus: http.StatusForbidden},
+		{name: "malformed hop in chain rejected", remoteAddr: "192.0.2.1:1234", forwardedFor: "10.0.0.1, garbage, 192.0.2.2", trustForward: true, trustedProxies: []string{"192.0.2.0/30"}, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reached = false
+			filter.TrustForwardedFor = tt.trustForward
+			if err := filter.SetTrustedProxies(tt.trustedProxies); err != nil {
+				t.Fatalf("SetTrustedProxies() error = %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/sse", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if reached != tt.wantReachNext {
+				t.Errorf("next handler reached = %v, want %v", reached, tt.wantReachNext)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package security
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// PIIPattern is a named regular expression identifying one kind of PII
+type PIIPattern struct {
+	Name    string
+	Pattern *regexp.Regexp
+
+	// Validate, if set, is called with each match; matches it rejects are
+	// left unmasked. Use it for checks a regexp can't express.
+	Validate func(match string) bool
+}
+
+// DefaultPIIPatterns returns the built-in PII patterns:
+// email addresses, card numbers (13-19 digits passing the Luhn check),
+// and US social security numbers
+func DefaultPIIPatterns() []PIIPattern {
+	return []PIIPattern{
+		{Name: "email", Pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)},
+		{Name: "card", Pattern: regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`), Validate: luhnValid},
+		{Name: "ssn", Pattern: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)},
+	}
+}
+
+// PIIScrubber masks personally identifiable information in text and tool
+// arguments before they are logged or audited. Each match is replaced with
+// "[REDACTED:<name>]" so logs still show what kind of value was present.
+//
+// Example:
+//
+//	scrubber := security.NewPIIScrubber()
+//	_ = scrubber.AddPattern("api_key", `sk-[A-Za-z0-9]{20,}`)
+//	logger.Info("", "args: %s", scrubber.ScrubJSON(args))
+type PIIScrubber struct {
+	mu       sync.RWMutex
+	patterns []PIIPattern
+}
+
+// NewPIIScrubber creates a scrubber with the default PII patterns
+func NewPIIScrubber() *PIIScrubber {
+	return &PIIScrubber{
+		patterns: DefaultPIIPatterns(),
+	}
+}
+
+// AddPattern compiles and adds a custom PII pattern
+func (s *PIIScrubber) AddPattern(name, expr string) error {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid PII pattern %q: %w", name, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.patterns = append(s.patterns, PIIPattern{Name: name, Pattern: re})
+	return nil
+}
+
+// Scrub masks all PII matches in text
+func (s *PIIScrubber) Scrub(text string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, p := range s.patterns {
+		mask := "[REDACTED:" + p.Name + "]"
+		if p.Validate == nil {
+			text = p.Pattern.ReplaceAllString(text, mask)
+			continue
+		}
+		validate := p.Validate
+		text = p.Pattern.ReplaceAllStringFunc(text, func(match string) string {
+			if validate(match) {
+				return mask
+			}
+			return match
+		})
+	}
+	return text
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum used by
+// payment card numbers. Non-digit characters (separators) are ignored.
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n > 0 && sum%10 == 0
+}
+
+// ScrubParams returns a copy of params with PII masked in every string
+// value, recursing into nested maps and slices. The input is not modified.
+func (s *PIIScrubber) ScrubParams(params map[string]interface{}) map[string]interface{} {
+	if params == nil {
+		return nil
+	}
+	return s.scrubValue(params).(map[string]interface{})
+}
+
+// ScrubJSON masks PII in raw JSON tool arguments and returns the result as a
+// string suitable for logging. Invalid JSON is scrubbed as plain text.
+func (s *PIIScrubber) ScrubJSON(raw json.RawMessage) string {
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return s.Scrub(string(raw))
+	}
+	scrubbed, err := json.Marshal(s.scrubValue(decoded))
+	if err != nil {
+		return s.Scrub(string(raw))
+	}
+	return string(scrubbed)
+}
+
+// scrubValue recursively scrubs strings inside maps and slices
+func (s *PIIScrubber) scrubValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return s.Scrub(val)
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			result[k] = s.scrubValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(val))
+		for i, item := range val {
+			result[i] = s.scrubValue(item)
+		}
+		return result
+	default:
+		return v
+	}
+}
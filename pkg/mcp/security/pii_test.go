@@ -0,0 +1,138 @@
+package security
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPIIScrubber_Scrub(t *testing.T) {
+	scrubber := NewPIIScrubber()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "email",
+			input: "contact alice@example.com today",
+			want:  "contact [REDACTED:email] today",
+		},
+		{
+			name:  "card with spaces",
+			input: "card 4111 1111 1111 1111 on file",
+			want:  "card [REDACTED:card] on file",
+		},
+		{
+			name:  "card with dashes",
+			input: "4111-1111-1111-1111",
+			want:  "[REDACTED:card]",
+		},
+		{
+			name:  "amex card grouping",
+			input: "amex 3782 822463 10005",
+			want:  "amex [REDACTED:card]",
+		},
+		{
+			name:  "card-length number failing Luhn",
+			input: "order 1234567812345678 and 4111-1111-1111-1112",
+			want:  "order 1234567812345678 and 4111-1111-1111-1112",
+		},
+		{
+			name:  "millisecond timestamp",
+			input: "created at 1697040000000",
+			want:  "created at 1697040000000",
+		},
+		{
+			name:  "card inside a longer digit run",
+			input: "id 41111111111111111111111",
+			want:  "id 41111111111111111111111",
+		},
+		{
+			name:  "ssn",
+			input: "ssn 123-45-6789",
+			want:  "ssn [REDACTED:ssn]",
+		},
+		{
+			name:  "ordinary text",
+			input: "list files in /tmp, limit 10",
+			want:  "list files in /tmp, limit 10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scrubber.Scrub(tt.input); got != tt.want {
+				t.Errorf("Scrub(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPIIScrubber_ScrubParams(t *testing.T) {
+	scrubber := NewPIIScrubber()
+
+	params := map[string]interface{}{
+		"query": "find bob@example.org",
+		"limit": 10.0,
+		"nested": map[string]interface{}{
+			"card": "4111111111111111",
+		},
+		"list": []interface{}{"plain", "carol@example.net"},
+	}
+
+	scrubbed := scrubber.ScrubParams(params)
+
+	if got := scrubbed["query"]; got != "find [REDACTED:email]" {
+		t.Errorf("query = %v, want masked email", got)
+	}
+	if got := scrubbed["limit"]; got != 10.0 {
+		t.Errorf("limit = %v, want 10", got)
+	}
+	if got := scrubbed["nested"].(map[string]interface{})["card"]; got != "[REDACTED:card]" {
+		t.Errorf("nested.card = %v, want masked card", got)
+	}
+	list := scrubbed["list"].([]interface{})
+	if list[0] != "plain" || list[1] != "[REDACTED:email]" {
+		t.Errorf("list = %v, want [plain [REDACTED:email]]", list)
+	}
+
+	// Original must be untouched
+	if params["query"] != "find bob@example.org" {
+		t.Error("ScrubParams modified the input map")
+	}
+}
+
+func TestPIIScrubber_ScrubJSON(t *testing.T) {
+	scrubber := NewPIIScrubber()
+
+	raw := json.RawMessage(`{"email":"dave@example.com","count":3}`)
+	got := scrubber.ScrubJSON(raw)
+	if strings.Contains(got, "dave@example.com") {
+		t.Errorf("ScrubJSON() = %s, email not masked", got)
+	}
+	if !strings.Contains(got, `"count":3`) {
+		t.Errorf("ScrubJSON() = %s, want count preserved", got)
+	}
+
+	// Invalid JSON is scrubbed as text
+	if got := scrubber.ScrubJSON(json.RawMessage(`not json eve@example.com`)); got != "not json [REDACTED:email]" {
+		t.Errorf("ScrubJSON(invalid) = %q", got)
+	}
+}
+
+func TestPIIScrubber_AddPattern(t *testing.T) {
+	scrubber := NewPIIScrubber()
+
+	if err := scrubber.AddPattern("api_key", `sk-[A-Za-z0-9]{8,}`); err != nil {
+		t.Fatalf("AddPattern() error = %v", err)
+	}
+	if got := scrubber.Scrub("key sk-abcdef123456"); got != "key [REDACTED:api_key]" {
+		t.Errorf("Scrub() = %q, want custom pattern masked", got)
+	}
+
+	if err := scrubber.AddPattern("bad", `(`); err == nil {
+		t.Error("AddPattern() with invalid regex should return error")
+	}
+}
@@ -0,0 +1,71 @@
+package security
+
+import "fmt"
+
+// Policy combines the security checks applied to a tool call, so servers can
+// configure rate limiting, access control and size limits in one place.
+// Nil or zero fields skip their check.
+//
+// Example:
+//
+//	ac := NewAccessControl(PermissionAllow)
+//	ac.DenyToolGroup("admin")
+//	policy := &Policy{
+//		Access:      ac,
+//		RateLimit:   NewRateLimiter(time.Minute, 100),
+//		MaxArgBytes: 1 << 20,
+//	}
+//	if err := policy.CheckRequest(clientID, toolName, len(args)); err != nil {
+//		// reject the call
+//	}
+type Policy struct {
+	// Access decides which tools may be called
+	Access *AccessControl
+
+	// RateLimit limits calls per client
+	RateLimit *RateLimiter
+
+	// MaxArgBytes caps the size of a call's raw arguments (0 = unlimited)
+	MaxArgBytes int
+}
+
+// CheckRequest checks a tool call against the policy and returns the first
+// violation, or nil if the call is allowed. Cheap checks run first: request
+// size, then access control, then rate limiting, so rejected calls don't
+// consume rate limit capacity.
+//
+// Returns *RequestTooLargeError, *AccessDeniedError or *RateLimitError.
+func (p *Policy) CheckRequest(clientID, toolName string, argBytes int) error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxArgBytes > 0 && argBytes > p.MaxArgBytes {
+		return &RequestTooLargeError{
+			Size:  argBytes,
+			Limit: p.MaxArgBytes,
+		}
+	}
+	if p.Access != nil {
+		if err := p.Access.CheckToolForClient(clientID, toolName); err != nil {
+			return err
+		}
+	}
+	return CheckRateLimits(p.RateLimit, nil, clientID)
+}
+
+// Stop stops the policy's rate limiter, if any
+func (p *Policy) Stop() {
+	if p != nil && p.RateLimit != nil {
+		p.RateLimit.Stop()
+	}
+}
+
+// RequestTooLargeError represents tool call arguments exceeding a size limit
+type RequestTooLargeError struct {
+	Size  int
+	Limit int
+}
+
+func (e *RequestTooLargeError) Error() string {
+	return fmt.Sprintf("arguments size %d bytes exceeds limit of %d bytes", e.Size, e.Limit)
+}
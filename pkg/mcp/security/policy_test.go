@@ -0,0 +1,57 @@
+package security
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPolicy_CheckRequest(t *testing.T) {
+	ac := NewAccessControl(PermissionAllow)
+	ac.DenyTool("shell")
+	policy := &Policy{
+		Access:      ac,
+		RateLimit:   NewRateLimiter(time.Minute, 2),
+		MaxArgBytes: 100,
+	}
+	defer policy.Stop()
+
+	var tooLarge *RequestTooLargeError
+	if err := policy.CheckRequest("client1", "echo", 101); !errors.As(err, &tooLarge) {
+		t.Errorf("oversized request error = %v, want *RequestTooLargeError", err)
+	} else if tooLarge.Size != 101 || tooLarge.Limit != 100 {
+		t.Errorf("RequestTooLargeError = %+v, want size 101, limit 100", tooLarge)
+	}
+
+	var denied *AccessDeniedError
+	if err := policy.CheckRequest("client1", "shell", 10); !errors.As(err, &denied) {
+		t.Errorf("denied tool error = %v, want *AccessDeniedError", err)
+	}
+
+	// Rejected requests above did not consume rate limit capacity
+	for i := 0; i < 2; i++ {
+		if err := policy.CheckRequest("client1", "echo", 10); err != nil {
+			t.Fatalf("request %d error = %v, want nil", i+1, err)
+		}
+	}
+	var limited *RateLimitError
+	if err := policy.CheckRequest("client1", "echo", 10); !errors.As(err, &limited) {
+		t.Errorf("rate limited request error = %v, want *RateLimitError", err)
+	}
+	if err := policy.CheckRequest("client2", "echo", 10); err != nil {
+		t.Errorf("other client error = %v, want nil", err)
+	}
+}
+
+func TestPolicy_ZeroValue(t *testing.T) {
+	var policy Policy
+	if err := policy.CheckRequest("client1", "anything", 1<<30); err != nil {
+		t.Errorf("zero Policy CheckRequest() error = %v, want nil", err)
+	}
+	policy.Stop()
+
+	var nilPolicy *Policy
+	if err := nilPolicy.CheckRequest("client1", "anything", 1); err != nil {
+		t.Errorf("nil Policy CheckRequest() error = %v, want nil", err)
+	}
+}
@@ -3,33 +3,58 @@ package security
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 )
 
 // RateLimiter implements a sliding window rate limiter
 type RateLimiter struct {
-	mu          sync.RWMutex
-	requests    map[string][]time.Time // client -> request timestamps
-	window      time.Duration          // time window
-	maxRequests int                    // max requests per window
-	cleanup     *time.Ticker           // periodic cleanup
-	stopCleanup chan struct{}
+	mu              sync.RWMutex
+	requests        map[string][]time.Time // client -> request timestamps
+	window          time.Duration          // time window
+	maxRequests     int                    // max requests per window
+	cleanupInterval time.Duration          // how often stale clients are dropped
+	cleanup         *time.Ticker           // periodic cleanup
+	stopCleanup     chan struct{}
+	stopOnce        sync.Once
+}
+
+// MaxDefaultCleanupInterval caps the default cleanup interval, so limiters
+// with long windows still drop idle clients regularly
+const MaxDefaultCleanupInterval = time.Minute
+
+// RateLimiterOption configures NewRateLimiter
+type RateLimiterOption func(*RateLimiter)
+
+// WithCleanupInterval sets how often entries that have left the window are
+// removed (default: the window, capped at MaxDefaultCleanupInterval).
+// Non-positive intervals are ignored.
+func WithCleanupInterval(interval time.Duration) RateLimiterOption {
+	return func(rl *RateLimiter) {
+		if interval > 0 {
+			rl.cleanupInterval = interval
+		}
+	}
 }
 
 // NewRateLimiter creates a new rate limiter
 // window: time window (e.g., 1 minute)
 // maxRequests: maximum requests allowed in the window
-func NewRateLimiter(window time.Duration, maxRequests int) *RateLimiter {
+func NewRateLimiter(window time.Duration, maxRequests int, opts ...RateLimiterOption) *RateLimiter {
 	rl := &RateLimiter{
-		requests:    make(map[string][]time.Time),
-		window:      window,
-		maxRequests: maxRequests,
-		stopCleanup: make(chan struct{}),
+		requests:        make(map[string][]time.Time),
+		window:          window,
+		maxRequests:     maxRequests,
+		cleanupInterval: min(window, MaxDefaultCleanupInterval),
+		stopCleanup:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(rl)
 	}
 
 	// Start cleanup goroutine to remove old entries
-	rl.cleanup = time.NewTicker(window)
+	rl.cleanup = time.NewTicker(rl.cleanupInterval)
 	go rl.cleanupOldEntries()
 
 	return rl
@@ -38,6 +63,17 @@ func NewRateLimiter(window time.Duration, maxRequests int) *RateLimiter {
 // Allow checks if a request from the given client should be allowed
 // Returns true if allowed, false if rate limit exceeded
 func (rl *RateLimiter) Allow(clientID string) bool {
+	return rl.AllowN(clientID, 1)
+}
+
+// AllowN is Allow for an operation that counts as n requests (e.g. a batch
+// call). The n slots are taken atomically: if fewer than n are available,
+// none are consumed and it returns false. n <= 0 is always allowed.
+func (rl *RateLimiter) AllowN(clientID string, n int) bool {
+	if n <= 0 {
+		return true
+	}
+
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
@@ -50,51 +86,74 @@ func (rl *RateLimiter) Allow(clientID string) bool {
 		requests = make([]time.Time, 0, rl.maxRequests)
 	}
 
-	// Remove requests outside the window
-	validRequests := make([]time.Time, 0, len(requests))
-	for _, reqTime := range requests {
-		if reqTime.After(cutoff) {
-			validRequests = append(validRequests, reqTime)
-		}
-	}
+	// Remove requests outside the window, in place so the hot path does not
+	// allocate
+	requests = pruneRequests(requests, cutoff)
+	rl.requests[clientID] = requests
 
 	// Check if we've exceeded the limit
-	if len(validRequests) >= rl.maxRequests {
+	if len(requests)+n > rl.maxRequests {
 		return false
 	}
 
-	// Add current request
-	validRequests = append(validRequests, now)
-	rl.requests[clientID] = validRequests
+	// Add current request(s)
+	for i := 0; i < n; i++ {
+		requests = append(requests, now)
+	}
+	rl.requests[clientID] = requests
 
 	return true
 }
 
+// release gives back one slot taken by Allow, e.g. when a request allowed by
+// this limiter is rejected by another
+func (rl *RateLimiter) release(clientID string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if requests := rl.requests[clientID]; len(requests) > 0 {
+		rl.requests[clientID] = requests[:len(requests)-1]
+	}
+}
+
+// pruneRequests drops the timestamps at or before cutoff from requests,
+// which is in ascending order, reusing its backing array
+func pruneRequests(requests []time.Time, cutoff time.Time) []time.Time {
+	expired := 0
+	for expired < len(requests) && !requests[expired].After(cutoff) {
+		expired++
+	}
+	if expired == 0 {
+		return requests
+	}
+	n := copy(requests, requests[expired:])
+	return requests[:n]
+}
+
 // Wait blocks until a request can be made (or context expires)
 func (rl *RateLimiter) Wait(ctx context.Context, clientID string) error {
+	return rl.WaitN(ctx, clientID, 1)
+}
+
+// WaitN blocks until n requests can be made at once (see AllowN), or the
+// context expires. It fails immediately if n exceeds the limit itself, since
+// that could never be satisfied.
+func (rl *RateLimiter) WaitN(ctx context.Context, clientID string, n int) error {
+	if n > rl.maxRequests {
+		return fmt.Errorf("cannot reserve %d requests: limit is %d per %v", n, rl.maxRequests, rl.window)
+	}
 	for {
-		if rl.Allow(clientID) {
+		if rl.AllowN(clientID, n) {
 			return nil
 		}
 
-		// Calculate when the oldest request will expire
-		rl.mu.RLock()
-		requests := rl.requests[clientID]
-		var waitTime time.Duration
-		if len(requests) > 0 {
-			oldest := requests[0]
-			waitTime = rl.window - time.Since(oldest)
-			if waitTime < 0 {
-				waitTime = 0
-			}
-		}
-		rl.mu.RUnlock()
-
-		// Wait for the oldest request to expire or context cancellation
+		// Wait for enough requests to expire or context cancellation
+		timer := time.NewTimer(rl.retryAfterN(clientID, n))
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return ctx.Err()
-		case <-time.After(waitTime):
+		case <-timer.C:
 			// Try again
 		}
 	}
@@ -110,12 +169,7 @@ func (rl *RateLimiter) cleanupOldEntries() {
 			rl.mu.Lock()
 			cutoff := time.Now().Add(-rl.window)
 			for clientID, requests := range rl.requests {
-				validRequests := make([]time.Time, 0)
-				for _, reqTime := range requests {
-					if reqTime.After(cutoff) {
-						validRequests = append(validRequests, reqTime)
-					}
-				}
+				validRequests := pruneRequests(requests, cutoff)
 				if len(validRequests) == 0 {
 					delete(rl.requests, clientID)
 				} else {
@@ -127,10 +181,17 @@ func (rl *RateLimiter) cleanupOldEntries() {
 	}
 }
 
-// Stop stops the rate limiter and cleans up resources
+// Stop stops the rate limiter and cleans up resources. It is safe to call
+// more than once.
 func (rl *RateLimiter) Stop() {
-	rl.cleanup.Stop()
-	close(rl.stopCleanup)
+	rl.stopOnce.Do(func() {
+		if rl.cleanup != nil {
+			rl.cleanup.Stop()
+		}
+		if rl.stopCleanup != nil {
+			close(rl.stopCleanup)
+		}
+	})
 }
 
 // GetRemaining returns the number of remaining requests for a client
@@ -149,6 +210,125 @@ func (rl *RateLimiter) GetRemaining(clientID string) int {
 	return rl.maxRequests - count
 }
 
+// Reset forgets the requests made by a client, restoring its full quota
+func (rl *RateLimiter) Reset(clientID string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	delete(rl.requests, clientID)
+}
+
+// RetryAfter returns how long until the oldest in-window request for a
+// client expires, freeing a slot: window - time.Since(oldest). Returns 0 if
+// the client has made no requests in the window.
+func (rl *RateLimiter) RetryAfter(clientID string) time.Duration {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	cutoff := time.Now().Add(-rl.window)
+	for _, reqTime := range rl.requests[clientID] {
+		if reqTime.After(cutoff) {
+			return rl.window - time.Since(reqTime)
+		}
+	}
+	return 0
+}
+
+// retryAfterN returns how long until n slots are free for a client: when
+// the request whose expiry frees the nth slot leaves the window
+func (rl *RateLimiter) retryAfterN(clientID string, n int) time.Duration {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	requests := pruneCopy(rl.requests[clientID], time.Now().Add(-rl.window))
+	// Requests beyond the first len(requests)+n-maxRequests can stay
+	expire := len(requests) + n - rl.maxRequests
+	if expire <= 0 {
+		return 0
+	}
+	return rl.window - time.Since(requests[expire-1])
+}
+
+// pruneCopy returns the part of requests (ascending) after cutoff, without
+// modifying requests
+func pruneCopy(requests []time.Time, cutoff time.Time) []time.Time {
+	expired := 0
+	for expired < len(requests) && !requests[expired].After(cutoff) {
+		expired++
+	}
+	return requests[expired:]
+}
+
+// RateLimiterGroup applies separate rate limits per tool (or resource), so
+// expensive tools can be throttled harder than cheap ones. Names without a
+// limit of their own share the default limiter. Each client is counted
+// separately within every limiter.
+type RateLimiterGroup struct {
+	mu       sync.RWMutex
+	limiters map[string]*RateLimiter // tool name -> limiter
+	fallback *RateLimiter
+	stopped  bool // set by Stop; later limiters are created stopped
+}
+
+// NewRateLimiterGroup creates a group whose default limiter allows
+// maxRequests per window for each client
+func NewRateLimiterGroup(window time.Duration, maxRequests int) *RateLimiterGroup {
+	return &RateLimiterGroup{
+		limiters: make(map[string]*RateLimiter),
+		fallback: NewRateLimiter(window, maxRequests),
+	}
+}
+
+// RegisterLimit sets the limit for toolName, replacing (and stopping) any
+// limiter previously registered for it. After the group is stopped the new
+// limiter still applies, but is created stopped so no cleanup goroutine leaks.
+func (g *RateLimiterGroup) RegisterLimit(toolName string, window time.Duration, maxRequests int) {
+	rl := NewRateLimiter(window, maxRequests)
+
+	g.mu.Lock()
+	if g.stopped {
+		rl.Stop()
+	}
+	old := g.limiters[toolName]
+	g.limiters[toolName] = rl
+	g.mu.Unlock()
+
+	if old != nil {
+		old.Stop()
+	}
+}
+
+// limiterFor returns the limiter for toolName, or the default
+func (g *RateLimiterGroup) limiterFor(toolName string) *RateLimiter {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if rl, ok := g.limiters[toolName]; ok {
+		return rl
+	}
+	return g.fallback
+}
+
+// AllowFor checks if a call to toolName from clientID should be allowed,
+// using the tool's own limiter if one is registered and the default otherwise
+func (g *RateLimiterGroup) AllowFor(clientID, toolName string) bool {
+	return g.limiterFor(toolName).Allow(clientID)
+}
+
+// CheckFor is AllowFor returning a *RateLimitError when the limit is exceeded
+func (g *RateLimiterGroup) CheckFor(clientID, toolName string) error {
+	return CheckRateLimits(g.limiterFor(toolName), nil, clientID)
+}
+
+// Stop stops the cleanup of the default and every registered limiter
+func (g *RateLimiterGroup) Stop() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.stopped = true
+	for _, rl := range g.limiters {
+		rl.Stop()
+	}
+	g.fallback.Stop()
+}
+
 // DefaultRateLimiter is the default rate limiter instance
 var (
 	defaultRateLimiter *RateLimiter
@@ -183,14 +363,77 @@ func (e *RateLimitError) Error() string {
 		e.ClientID, e.MaxRequests-e.Remaining, e.Window, e.MaxRequests)
 }
 
+// HTTPHeaders returns the Retry-After (whole seconds, rounded up) and
+// X-RateLimit-Remaining headers describing the error, for HTTP transports
+// to set on their rejection responses
+func (e *RateLimitError) HTTPHeaders() map[string]string {
+	return map[string]string{
+		HeaderRetryAfter:         formatRetryAfter(e.RetryAfter),
+		HeaderRateLimitRemaining: strconv.Itoa(e.Remaining),
+	}
+}
+
+// globalClientID is the bucket key used when a RateLimiter acts as a
+// server-wide limit shared by all clients
+const globalClientID = "*"
+
+// GlobalRateLimitError represents the server-wide rate limit being exceeded.
+// Unlike RateLimitError it is not attributable to a single client.
+type GlobalRateLimitError struct {
+	RetryAfter  time.Duration
+	MaxRequests int
+	Window      time.Duration
+}
+
+func (e *GlobalRateLimitError) Error() string {
+	return fmt.Sprintf("global rate limit exceeded: %d requests in %v", e.MaxRequests, e.Window)
+}
+
+// IsGlobalRateLimit checks if error is a GlobalRateLimitError
+func IsGlobalRateLimit(err error) bool {
+	_, ok := err.(*GlobalRateLimitError)
+	return ok
+}
+
+// CheckRateLimits checks a request against a per-client limiter and an
+// optional global limiter that caps total throughput across all clients.
+// The per-client limit is checked first, so a throttled client does not
+// consume global capacity, and a request rejected by the global limit gives
+// its per-client slot back. Either limiter may be nil to skip it.
+//
+// Returns *RateLimitError when the client's limit is hit, or
+// *GlobalRateLimitError when the server-wide limit is hit.
+func CheckRateLimits(perClient, global *RateLimiter, clientID string) error {
+	if perClient != nil && !perClient.Allow(clientID) {
+		return &RateLimitError{
+			ClientID:    clientID,
+			RetryAfter:  perClient.RetryAfter(clientID),
+			Remaining:   perClient.GetRemaining(clientID),
+			MaxRequests: perClient.maxRequests,
+			Window:      perClient.window,
+		}
+	}
+	if global != nil && !global.Allow(globalClientID) {
+		if perClient != nil {
+			perClient.release(clientID)
+		}
+		return &GlobalRateLimitError{
+			RetryAfter:  global.RetryAfter(globalClientID),
+			MaxRequests: global.maxRequests,
+			Window:      global.window,
+		}
+	}
+	return nil
+}
+
 // CheckRateLimit checks rate limit and returns an error if exceeded
 func CheckRateLimit(clientID string) error {
 	rl := GetDefaultRateLimiter()
 	if !rl.Allow(clientID) {
-		remaining := rl.GetRemaining(clientID)
 		return &RateLimitError{
 			ClientID:    clientID,
-			Remaining:   remaining,
+			RetryAfter:  rl.RetryAfter(clientID),
+			Remaining:   rl.GetRemaining(clientID),
 			MaxRequests: rl.maxRequests,
 			Window:      rl.window,
 		}
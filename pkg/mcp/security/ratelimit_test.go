@@ -2,6 +2,8 @@ package security
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -135,3 +137,538 @@ func TestCheckRateLimit(t *testing.T) {
 
 	rl.Stop()
 }
+
+func TestCheckRateLimits_Global(t *testing.T) {
+	perClient := NewRateLimiter(time.Minute, 5)
+	defer perClient.Stop()
+	global := NewRateLimiter(time.Minute, 4)
+	defer global.Stop()
+
+	// Spread requests across clients so no single client hits its own limit
+	for i := 0; i < 4; i++ {
+		clientID := []string{"client1", "client2"}[i%2]
+		if err := CheckRateLimits(perClient, global, clientID); err != nil {
+			t.Fatalf("request %d should be allowed: %v", i+1, err)
+		}
+	}
+
+	err := CheckRateLimits(perClient, global, "client3")
+	if err == nil {
+		t.Fatal("request beyond global limit should be denied")
+	}
+	if !IsGlobalRateLimit(err) {
+		t.Errorf("expected *GlobalRateLimitError, got %T: %v", err, err)
+	}
+}
+
+func TestCheckRateLimits_PerClient(t *testing.T) {
+	perClient := NewRateLimiter(time.Minute, 1)
+	defer perClient.Stop()
+	global := NewRateLimiter(time.Minute, 10)
+	defer global.Stop()
+
+	if err := CheckRateLimits(perClient, global, "client1"); err != nil {
+		t.Fatalf("first request should be allowed: %v", err)
+	}
+
+	err := CheckRateLimits(perClient, global, "client1")
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Fatalf("expected *RateLimitError, got %T: %v", err, err)
+	}
+
+	// Throttled client must not consume global capacity
+	if remaining := global.GetRemaining(globalClientID); remaining != 9 {
+		t.Errorf("global remaining = %d, want 9", remaining)
+	}
+}
+
+func TestCheckRateLimits_GlobalKeepsClientCapacity(t *testing.T) {
+	perClient := NewRateLimiter(time.Minute, 5)
+	defer perClient.Stop()
+	global := NewRateLimiter(time.Minute, 1)
+	defer global.Stop()
+
+	if err := CheckRateLimits(perClient, global, "client1"); err != nil {
+		t.Fatalf("first request should be allowed: %v", err)
+	}
+	if err := CheckRateLimits(perClient, global, "client1"); !IsGlobalRateLimit(err) {
+		t.Fatalf("expected *GlobalRateLimitError, got %T: %v", err, err)
+	}
+
+	// The globally rejected request must not count against the client
+	if remaining := perClient.GetRemaining("client1"); remaining != 4 {
+		t.Errorf("client remaining = %d, want 4", remaining)
+	}
+}
+
+func TestCheckRateLimits_NilLimiters(t *testing.T) {
+	if err := CheckRateLimits(nil, nil, "client1"); err != nil {
+		t.Errorf("nil limiters should allow: %v", err)
+	}
+}
+
+func TestRateLimiterGroup(t *testing.T) {
+	g := NewRateLimiterGroup(time.Minute, 10)
+	defer g.Stop()
+	g.RegisterLimit("shell", time.Minute, 2)
+
+	// The tight per-tool limit applies to the registered tool only
+	for i := 0; i < 2; i++ {
+		if !g.AllowFor("client1", "shell") {
+			t.Fatalf("shell request %d should be allowed", i+1)
+		}
+	}
+	if g.AllowFor("client1", "shell") {
+		t.Error("3rd shell request should be denied")
+	}
+	if _, ok := g.CheckFor("client1", "shell").(*RateLimitError); !ok {
+		t.Error("CheckFor should return *RateLimitError for an exhausted tool")
+	}
+
+	// Other tools share the loose default, unaffected by the shell limit
+	for i := 0; i < 10; i++ {
+		tool := []string{"echo", "math"}[i%2]
+		if !g.AllowFor("client1", tool) {
+			t.Fatalf("default request %d (%s) should be allowed", i+1, tool)
+		}
+	}
+	if g.AllowFor("client1", "echo") {
+		t.Error("11th default request should be denied")
+	}
+
+	// Limits are still per client
+	if !g.AllowFor("client2", "shell") {
+		t.Error("client2 shell request should be allowed")
+	}
+	if err := g.CheckFor("client2", "echo"); err != nil {
+		t.Errorf("client2 default request should be allowed: %v", err)
+	}
+}
+
+func TestRateLimiterGroup_RegisterLimitReplaces(t *testing.T) {
+	g := NewRateLimiterGroup(time.Minute, 10)
+	defer g.Stop()
+
+	g.RegisterLimit("shell", time.Minute, 1)
+	if !g.AllowFor("client1", "shell") || g.AllowFor("client1", "shell") {
+		t.Fatal("limit of 1 not enforced")
+	}
+
+	g.RegisterLimit("shell", time.Minute, 3)
+	for i := 0; i < 3; i++ {
+		if !g.AllowFor("client1", "shell") {
+			t.Fatalf("request %d under the replaced limit should be allowed", i+1)
+		}
+	}
+	if g.AllowFor("client1", "shell") {
+		t.Error("request beyond the replaced limit should be denied")
+	}
+}
+
+func TestRateLimiterGroup_RegisterLimitAfterStop(t *testing.T) {
+	g := NewRateLimiterGroup(time.Minute, 10)
+	g.Stop()
+
+	g.RegisterLimit("shell", time.Minute, 1)
+
+	g.mu.RLock()
+	rl := g.limiters["shell"]
+	g.mu.RUnlock()
+	select {
+	case <-rl.stopCleanup:
+	default:
+		t.Error("limiter registered after Stop should be created stopped")
+	}
+
+	// The limit still applies
+	if !g.AllowFor("client1", "shell") || g.AllowFor("client1", "shell") {
+		t.Error("limit of 1 not enforced after Stop")
+	}
+}
+
+func TestCheckRateLimit_RetryAfter(t *testing.T) {
+	const clientID = "retry-after-client"
+	rl := GetDefaultRateLimiter()
+	for i := 0; i < rl.maxRequests; i++ {
+		if err := CheckRateLimit(clientID); err != nil {
+			t.Fatalf("request %d should be allowed: %v", i+1, err)
+		}
+	}
+
+	err := CheckRateLimit(clientID)
+	rlErr, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("expected *RateLimitError, got %T: %v", err, err)
+	}
+	if rlErr.RetryAfter <= 0 || rlErr.RetryAfter > rl.window {
+		t.Errorf("RetryAfter = %v, want in (0, %v]", rlErr.RetryAfter, rl.window)
+	}
+	if rlErr.Remaining != 0 {
+		t.Errorf("Remaining = %d, want 0", rlErr.Remaining)
+	}
+}
+
+func TestRateLimiter_RetryAfter(t *testing.T) {
+	rl := NewRateLimiter(time.Minute, 2)
+	defer rl.Stop()
+
+	if d := rl.RetryAfter("client1"); d != 0 {
+		t.Errorf("RetryAfter() with no requests = %v, want 0", d)
+	}
+
+	rl.Allow("client1")
+	time.Sleep(20 * time.Millisecond)
+	rl.Allow("client1")
+
+	// Measured from the oldest request, not the newest
+	d := rl.RetryAfter("client1")
+	if d <= 0 || d > time.Minute-20*time.Millisecond {
+		t.Errorf("RetryAfter() = %v, want in (0, %v]", d, time.Minute-20*time.Millisecond)
+	}
+}
+
+func TestRateLimitError_HTTPHeaders(t *testing.T) {
+	err := &RateLimitError{ClientID: "client1", RetryAfter: 1500 * time.Millisecond, Remaining: 0, MaxRequests: 5, Window: time.Minute}
+	headers := err.HTTPHeaders()
+	if headers[HeaderRetryAfter] != "2" {
+		t.Errorf("%s = %q, want %q", HeaderRetryAfter, headers[HeaderRetryAfter], "2")
+	}
+	if headers[HeaderRateLimitRemaining] != "0" {
+		t.Errorf("%s = %q, want %q", HeaderRateLimitRemaining, headers[HeaderRateLimitRemaining], "0")
+	}
+}
+
+func TestRateLimiter_Reset(t *testing.T) {
+	rl := NewRateLimiter(time.Minute, 2)
+	defer rl.Stop()
+
+	rl.Allow("client1")
+	rl.Allow("client1")
+	rl.Allow("client2")
+	if rl.Allow("client1") {
+		t.Fatal("third request should be denied")
+	}
+
+	rl.Reset("client1")
+	if remaining := rl.GetRemaining("client1"); remaining != 2 {
+		t.Errorf("GetRemaining after Reset = %d, want 2", remaining)
+	}
+	if !rl.Allow("client1") {
+		t.Error("request after Reset should be allowed")
+	}
+	if remaining := rl.GetRemaining("client2"); remaining != 1 {
+		t.Errorf("Reset affected another client: remaining = %d, want 1", remaining)
+	}
+}
+
+// TestRateLimiter_Concurrent hammers one limiter from many goroutines; run
+// with -race. The window is long enough that nothing expires, so exactly
+// maxRequests calls per client may succeed.
+func TestRateLimiter_Concurrent(t *testing.T) {
+	const (
+		maxRequests = 50
+		workers     = 16
+		calls       = 20 // per worker per client
+	)
+	rl := NewRateLimiter(time.Minute, maxRequests)
+	defer rl.Stop()
+
+	clients := []string{"shared", "client-a", "client-b"}
+	var allowed [3]atomic.Int32
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < calls; i++ {
+				for c, clientID := range clients {
+					if rl.Allow(clientID) {
+						allowed[c].Add(1)
+					}
+					if remaining := rl.GetRemaining(clientID); remaining < 0 {
+						t.Errorf("GetRemaining(%s) = %d", clientID, remaining)
+					}
+					rl.RetryAfter(clientID)
+				}
+			}
+		}()
+	}
+
+	// Reset and Wait run concurrently on clients of their own
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < calls; i++ {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+				_ = rl.Wait(ctx, "waiter")
+				cancel()
+				rl.Allow("reset")
+				rl.Reset("reset")
+			}
+		}()
+	}
+	wg.Wait()
+
+	for c, clientID := range clients {
+		if n := allowed[c].Load(); n != maxRequests {
+			t.Errorf("%s: %d requests allowed, want exactly %d", clientID, n, maxRequests)
+		}
+	}
+	if n := rl.GetRemaining("waiter"); n < 0 {
+		t.Errorf("waiter: GetRemaining = %d, limit exceeded", n)
+	}
+}
+
+func TestRateLimiter_AllowPrunesInPlace(t *testing.T) {
+	const window = time.Minute
+	rl := NewRateLimiter(window, 3)
+	defer rl.Stop()
+
+	// age moves the client's timestamps d into the past, so the test
+	// controls expiry instead of sleeping through the window
+	age := func(d time.Duration) {
+		rl.mu.Lock()
+		defer rl.mu.Unlock()
+		for i := range rl.requests["client1"] {
+			rl.requests["client1"][i] = rl.requests["client1"][i].Add(-d)
+		}
+	}
+	stored := func() int {
+		rl.mu.RLock()
+		defer rl.mu.RUnlock()
+		return len(rl.requests["client1"])
+	}
+
+	for i := 0; i < 3; i++ {
+		if !rl.Allow("client1") {
+			t.Fatalf("request %d should be allowed", i+1)
+		}
+	}
+	if rl.Allow("client1") {
+		t.Fatal("request over the limit should be denied")
+	}
+
+	// After the window the old entries are dropped, not kept alongside
+	age(window + time.Second)
+	if !rl.Allow("client1") {
+		t.Fatal("request after the window should be allowed")
+	}
+	if n := stored(); n != 1 {
+		t.Errorf("stored %d timestamps, want 1", n)
+	}
+	if remaining := rl.GetRemaining("client1"); remaining != 2 {
+		t.Errorf("GetRemaining = %d, want 2", remaining)
+	}
+
+	// Partially expired: only the older entry is pruned
+	age(window / 2)
+	rl.Allow("client1")
+	age(window/2 + time.Second)
+	rl.Allow("client1")
+	if n := stored(); n != 2 {
+		t.Errorf("stored %d timestamps, want 2", n)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { rl.Allow("client1") }); allocs != 0 {
+		t.Errorf("Allow allocated %v times per call, want 0", allocs)
+	}
+}
+
+func TestPruneRequests(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds ...int) []time.Time {
+		times := make([]time.Time, 0, len(seconds))
+		for _, s := range seconds {
+			times = append(times, base.Add(time.Duration(s)*time.Second))
+		}
+		return times
+	}
+	cutoff := base.Add(10 * time.Second)
+
+	tests := []struct {
+		name     string
+		requests []time.Time
+		want     []time.Time
+	}{
+		{"none expired", at(11, 12), at(11, 12)},
+		{"some expired", at(5, 10, 11, 12), at(11, 12)},
+		{"all expired", at(1, 2, 3), at()},
+		{"empty", at(), at()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pruneRequests(tt.requests, cutoff)
+			if len(got) != len(tt.want) {
+				t.Fatalf("pruneRequests() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("pruneRequests()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+			// Pruned in place: the result shares the input's backing array
+			if len(got) > 0 && &got[0] != &tt.requests[0] {
+				t.Error("pruneRequests() allocated a new slice, want in place")
+			}
+		})
+	}
+}
+
+func TestRateLimiter_CleanupInterval(t *testing.T) {
+	defaults := []struct {
+		window time.Duration
+		opts   []RateLimiterOption
+		want   time.Duration
+	}{
+		{time.Hour, nil, MaxDefaultCleanupInterval},
+		{time.Second, nil, time.Second},
+		{time.Second, []RateLimiterOption{WithCleanupInterval(0)}, time.Second},
+	}
+	for _, tt := range defaults {
+		rl := NewRateLimiter(tt.window, 10, tt.opts...)
+		if rl.cleanupInterval != tt.want {
+			t.Errorf("cleanup interval for %v window = %v, want %v", tt.window, rl.cleanupInterval, tt.want)
+		}
+		rl.Stop()
+	}
+
+	rl := NewRateLimiter(time.Hour, 10, WithCleanupInterval(10*time.Millisecond))
+	defer rl.Stop()
+
+	// A client whose requests left the hour-long window long ago, and one
+	// that is still active
+	rl.mu.Lock()
+	rl.requests["stale"] = []time.Time{time.Now().Add(-2 * time.Hour)}
+	rl.mu.Unlock()
+	rl.Allow("active")
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		rl.mu.RLock()
+		_, stale := rl.requests["stale"]
+		_, active := rl.requests["active"]
+		rl.mu.RUnlock()
+		if !active {
+			t.Fatal("cleanup removed an active client")
+		}
+		if !stale {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("stale client not cleaned up within 1s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRateLimiter_StopTwice(t *testing.T) {
+	rl := NewRateLimiter(time.Minute, 10)
+	rl.Stop()
+	rl.Stop()
+
+	// Stopping only ends cleanup; the limiter still answers
+	if !rl.Allow("client1") {
+		t.Error("Allow after Stop should still work")
+	}
+
+	// A zero RateLimiter has no cleanup to stop
+	var zero RateLimiter
+	zero.Stop()
+
+	g := NewRateLimiterGroup(time.Minute, 10)
+	g.RegisterLimit("tool", time.Minute, 1)
+	g.Stop()
+	g.Stop()
+}
+
+func TestRateLimiter_AllowN(t *testing.T) {
+	rl := NewRateLimiter(time.Minute, 5)
+	defer rl.Stop()
+
+	if !rl.AllowN("client1", 3) {
+		t.Fatal("reserving 3 of 5 should be allowed")
+	}
+	if remaining := rl.GetRemaining("client1"); remaining != 2 {
+		t.Errorf("GetRemaining = %d, want 2", remaining)
+	}
+
+	// Not enough capacity: nothing is consumed
+	if rl.AllowN("client1", 3) {
+		t.Error("reserving 3 with 2 remaining should be denied")
+	}
+	if remaining := rl.GetRemaining("client1"); remaining != 2 {
+		t.Errorf("GetRemaining after denied AllowN = %d, want 2", remaining)
+	}
+
+	if !rl.AllowN("client1", 2) {
+		t.Error("reserving the last 2 should be allowed")
+	}
+	if rl.Allow("client1") {
+		t.Error("request after using all slots should be denied")
+	}
+	if !rl.AllowN("client1", 0) {
+		t.Error("AllowN with n = 0 should always be allowed")
+	}
+	if rl.AllowN("client2", 6) {
+		t.Error("reserving more than the limit should be denied")
+	}
+}
+
+func TestRateLimiter_WaitN(t *testing.T) {
+	rl := NewRateLimiter(100*time.Millisecond, 3)
+	defer rl.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := rl.WaitN(ctx, "client1", 2); err != nil {
+		t.Fatalf("first WaitN failed: %v", err)
+	}
+
+	// Only one slot left: waits for the first reservation to expire
+	start := time.Now()
+	if err := rl.WaitN(ctx, "client1", 2); err != nil {
+		t.Fatalf("second WaitN failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("second WaitN returned after %v, expected it to wait", elapsed)
+	}
+
+	if err := rl.WaitN(ctx, "client1", 4); err == nil {
+		t.Error("WaitN for more than the limit should fail")
+	}
+
+	shortCtx, shortCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer shortCancel()
+	if err := rl.WaitN(shortCtx, "client1", 3); err == nil {
+		t.Error("WaitN should fail when the context expires first")
+	}
+}
+
+// BenchmarkRateLimiter_Allow measures the hot path for a client that stays
+// under its limit while old requests keep expiring
+func BenchmarkRateLimiter_Allow(b *testing.B) {
+	rl := NewRateLimiter(time.Millisecond, 100)
+	defer rl.Stop()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rl.Allow("client1")
+	}
+}
+
+// BenchmarkRateLimiter_AllowDenied measures calls rejected at the limit
+func BenchmarkRateLimiter_AllowDenied(b *testing.B) {
+	rl := NewRateLimiter(time.Minute, 100)
+	defer rl.Stop()
+	for i := 0; i < 100; i++ {
+		rl.Allow("client1")
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rl.Allow("client1")
+	}
+}
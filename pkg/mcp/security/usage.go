@@ -0,0 +1,147 @@
+package security
+
+import (
+	"sync"
+	"time"
+)
+
+// UsageStats holds cumulative usage for a single client
+type UsageStats struct {
+	// Calls is the total number of recorded calls
+	Calls int64
+	// Bytes is the total number of recorded bytes
+	Bytes int64
+	// ToolCalls breaks Calls down by tool name
+	ToolCalls map[string]int64
+	// Since is when the current accounting period started
+	Since time.Time
+}
+
+// UsageTracker records cumulative per-client usage (call counts and bytes)
+// for billing or fair-use accounting. Unlike RateLimiter it never rejects
+// requests; it only counts them.
+//
+// If created with a non-zero reset interval, all counters are cleared
+// periodically (e.g., daily quotas).
+//
+// Example:
+//
+//	tracker := security.NewUsageTracker(24 * time.Hour)
+//	defer tracker.Stop()
+//	tracker.Record(clientID, "search", len(args))
+//	stats := tracker.Usage(clientID)
+type UsageTracker struct {
+	mu        sync.RWMutex
+	usage     map[string]*UsageStats // client -> usage
+	reset     *time.Ticker           // periodic reset (nil if disabled)
+	stopReset chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewUsageTracker creates a new usage tracker
+// resetInterval: how often to clear all counters (0 disables periodic reset)
+func NewUsageTracker(resetInterval time.Duration) *UsageTracker {
+	ut := &UsageTracker{
+		usage:     make(map[string]*UsageStats),
+		stopReset: make(chan struct{}),
+	}
+
+	if resetInterval > 0 {
+		ut.reset = time.NewTicker(resetInterval)
+		go ut.periodicReset()
+	}
+
+	return ut
+}
+
+// Record adds one call of the given tool and its byte count to a client's usage
+func (ut *UsageTracker) Record(clientID, tool string, bytes int) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+
+	stats, exists := ut.usage[clientID]
+	if !exists {
+		stats = &UsageStats{
+			ToolCalls: make(map[string]int64),
+			Since:     time.Now(),
+		}
+		ut.usage[clientID] = stats
+	}
+
+	stats.Calls++
+	stats.Bytes += int64(bytes)
+	stats.ToolCalls[tool]++
+}
+
+// Usage returns a snapshot of a client's usage
+// Returns zero-value stats for clients with no recorded usage.
+func (ut *UsageTracker) Usage(clientID string) UsageStats {
+	ut.mu.RLock()
+	defer ut.mu.RUnlock()
+
+	stats, exists := ut.usage[clientID]
+	if !exists {
+		return UsageStats{ToolCalls: make(map[string]int64)}
+	}
+
+	// Copy so callers can't mutate tracker state
+	toolCalls := make(map[string]int64, len(stats.ToolCalls))
+	for tool, count := range stats.ToolCalls {
+		toolCalls[tool] = count
+	}
+	return UsageStats{
+		Calls:     stats.Calls,
+		Bytes:     stats.Bytes,
+		ToolCalls: toolCalls,
+		Since:     stats.Since,
+	}
+}
+
+// Clients returns the IDs of all clients with recorded usage
+func (ut *UsageTracker) Clients() []string {
+	ut.mu.RLock()
+	defer ut.mu.RUnlock()
+
+	clients := make([]string, 0, len(ut.usage))
+	for clientID := range ut.usage {
+		clients = append(clients, clientID)
+	}
+	return clients
+}
+
+// Reset clears usage for a single client
+func (ut *UsageTracker) Reset(clientID string) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	delete(ut.usage, clientID)
+}
+
+// ResetAll clears usage for all clients
+func (ut *UsageTracker) ResetAll() {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	ut.usage = make(map[string]*UsageStats)
+}
+
+// periodicReset clears all counters on every tick
+func (ut *UsageTracker) periodicReset() {
+	for {
+		select {
+		case <-ut.stopReset:
+			return
+		case <-ut.reset.C:
+			ut.ResetAll()
+		}
+	}
+}
+
+// Stop stops periodic reset and cleans up resources
+// Safe to call multiple times.
+func (ut *UsageTracker) Stop() {
+	ut.stopOnce.Do(func() {
+		if ut.reset != nil {
+			ut.reset.Stop()
+		}
+		close(ut.stopReset)
+	})
+}
@@ -0,0 +1,134 @@
+package security
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUsageTracker_Record(t *testing.T) {
+	ut := NewUsageTracker(0)
+	defer ut.Stop()
+
+	ut.Record("client1", "search", 100)
+	ut.Record("client1", "search", 50)
+	ut.Record("client1", "write", 25)
+	ut.Record("client2", "search", 10)
+
+	stats := ut.Usage("client1")
+	if stats.Calls != 3 {
+		t.Errorf("client1 Calls = %d, want 3", stats.Calls)
+	}
+	if stats.Bytes != 175 {
+		t.Errorf("client1 Bytes = %d, want 175", stats.Bytes)
+	}
+	if stats.ToolCalls["search"] != 2 {
+		t.Errorf("client1 ToolCalls[search] = %d, want 2", stats.ToolCalls["search"])
+	}
+	if stats.ToolCalls["write"] != 1 {
+		t.Errorf("client1 ToolCalls[write] = %d, want 1", stats.ToolCalls["write"])
+	}
+	if stats.Since.IsZero() {
+		t.Error("client1 Since should be set")
+	}
+
+	stats2 := ut.Usage("client2")
+	if stats2.Calls != 1 || stats2.Bytes != 10 {
+		t.Errorf("client2 usage = %d calls / %d bytes, want 1 / 10", stats2.Calls, stats2.Bytes)
+	}
+
+	if len(ut.Clients()) != 2 {
+		t.Errorf("Clients() = %v, want 2 entries", ut.Clients())
+	}
+}
+
+func TestUsageTracker_UnknownClient(t *testing.T) {
+	ut := NewUsageTracker(0)
+	defer ut.Stop()
+
+	stats := ut.Usage("nobody")
+	if stats.Calls != 0 || stats.Bytes != 0 {
+		t.Errorf("unknown client usage = %+v, want zero", stats)
+	}
+	if stats.ToolCalls == nil {
+		t.Error("ToolCalls should be non-nil for unknown client")
+	}
+}
+
+func TestUsageTracker_SnapshotIsolation(t *testing.T) {
+	ut := NewUsageTracker(0)
+	defer ut.Stop()
+
+	ut.Record("client1", "search", 1)
+	stats := ut.Usage("client1")
+	stats.ToolCalls["search"] = 999
+
+	if got := ut.Usage("client1").ToolCalls["search"]; got != 1 {
+		t.Errorf("tracker state mutated through snapshot: ToolCalls[search] = %d, want 1", got)
+	}
+}
+
+func TestUsageTracker_Reset(t *testing.T) {
+	ut := NewUsageTracker(0)
+	defer ut.Stop()
+
+	ut.Record("client1", "search", 1)
+	ut.Record("client2", "search", 1)
+
+	ut.Reset("client1")
+	if ut.Usage("client1").Calls != 0 {
+		t.Error("Reset should clear client1")
+	}
+	if ut.Usage("client2").Calls != 1 {
+		t.Error("Reset should not affect client2")
+	}
+
+	ut.ResetAll()
+	if ut.Usage("client2").Calls != 0 {
+		t.Error("ResetAll should clear client2")
+	}
+}
+
+func TestUsageTracker_PeriodicReset(t *testing.T) {
+	ut := NewUsageTracker(50 * time.Millisecond)
+	defer ut.Stop()
+
+	ut.Record("client1", "search", 1)
+	time.Sleep(120 * time.Millisecond)
+
+	if calls := ut.Usage("client1").Calls; calls != 0 {
+		t.Errorf("Calls after periodic reset = %d, want 0", calls)
+	}
+}
+
+func TestUsageTracker_Concurrent(t *testing.T) {
+	ut := NewUsageTracker(0)
+	defer ut.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clientID := []string{"client1", "client2"}[i%2]
+			for j := 0; j < 100; j++ {
+				ut.Record(clientID, "tool", 2)
+				_ = ut.Usage(clientID)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, clientID := range []string{"client1", "client2"} {
+		stats := ut.Usage(clientID)
+		if stats.Calls != 500 || stats.Bytes != 1000 {
+			t.Errorf("%s usage = %d calls / %d bytes, want 500 / 1000", clientID, stats.Calls, stats.Bytes)
+		}
+	}
+}
+
+func TestUsageTracker_StopTwice(t *testing.T) {
+	ut := NewUsageTracker(time.Second)
+	ut.Stop()
+	ut.Stop()
+}
@@ -13,12 +13,52 @@ type ToolSchema struct {
 	Type       string                 `json:"type"`
 	Properties map[string]interface{} `json:"properties"`
 	Required   []string               `json:"required,omitempty"`
+	Defs       map[string]interface{} `json:"$defs,omitempty"` // Reusable definitions for "$ref": "#/$defs/Name"
 }
 
 // ToolInfo represents tool metadata
 // Contains all information about a registered tool
 type ToolInfo struct {
+	Name         string
+	Description  string
+	Schema       ToolSchema
+	Annotations  *ToolAnnotations // nil if the tool was registered without annotations
+	OutputSchema *ToolSchema      // nil if the tool was registered without an output schema
+}
+
+// ToolAnnotations describes a tool's side effects
+// Mirrors the MCP readOnlyHint/destructiveHint/idempotentHint/openWorldHint
+// tool annotations.
+// They are hints for clients and middleware (e.g. to require confirmation
+// before destructive calls), not guarantees enforced by the server.
+type ToolAnnotations struct {
+	ReadOnlyHint    bool `json:"readOnlyHint,omitempty"`    // Tool does not modify its environment
+	DestructiveHint bool `json:"destructiveHint,omitempty"` // Tool may delete or overwrite data
+	IdempotentHint  bool `json:"idempotentHint,omitempty"`  // Repeating a call with the same arguments has no further effect
+	// OpenWorldHint reports whether the tool interacts with external entities
+	// (e.g. the web) rather than a closed domain. nil leaves it unspecified,
+	// which MCP clients treat as true.
+	OpenWorldHint *bool `json:"openWorldHint,omitempty"`
+}
+
+// PromptInfo represents prompt metadata
+type PromptInfo struct {
+	Name        string
+	Description string
+	Arguments   []PromptArgument // nil if the prompt declares no arguments
+}
+
+// PromptArgument describes an argument a prompt accepts
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"` // GetPrompt fails if the argument is missing
+}
+
+// ResourceInfo represents resource metadata
+type ResourceInfo struct {
+	URI         string
 	Name        string
 	Description string
-	Schema      ToolSchema
+	MimeType    string
 }
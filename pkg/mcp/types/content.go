@@ -0,0 +1,258 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ContentTypeText is the MCP content type for text content
+const ContentTypeText = "text"
+
+// Text returns a text content item
+func Text(s string) TextContent {
+	return TextContent{Type: ContentTypeText, Text: s}
+}
+
+// Textf returns a text content item with fmt.Sprintf formatting
+func Textf(format string, args ...interface{}) TextContent {
+	return Text(fmt.Sprintf(format, args...))
+}
+
+// TextList returns one text content item per string, ready to return
+// from a tool handler
+//
+// Example:
+//
+//	return types.TextList("done", fmt.Sprintf("%d files", n)), nil
+func TextList(texts ...string) []TextContent {
+	contents := make([]TextContent, len(texts))
+	for i, s := range texts {
+		contents[i] = Text(s)
+	}
+	return contents
+}
+
+// JSON marshals v as indented JSON into a single text content item.
+// Use response.FormatResult instead when the result should also be written
+// to a file.
+func JSON(v interface{}) ([]TextContent, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return []TextContent{Text(string(data))}, nil
+}
+
+// MergeContent concatenates content slices from several sources, in order.
+// Nil and empty slices are skipped; the result is nil if there is no content.
+func MergeContent(parts ...[]TextContent) []TextContent {
+	total := 0
+	for _, part := range parts {
+		total += len(part)
+	}
+	if total == 0 {
+		return nil
+	}
+	merged := make([]TextContent, 0, total)
+	for _, part := range parts {
+		merged = append(merged, part...)
+	}
+	return merged
+}
+
+// ContentBuilder accumulates tool result content fluently: text, images and
+// embedded resources. Build returns it for a framework.RichToolHandler;
+// BuildText renders it as text for a plain framework.ToolHandler.
+// The first error (e.g. from JSON) is retained and returned by Build, so
+// calls can be chained without checking each step.
+//
+// Example:
+//
+//	return types.NewContentBuilder().
+//		Textf("Rendered %d charts", n).
+//		Image(png, "image/png").
+//		Resource("file:///report.csv", "text/csv", csv).
+//		JSON(summary).
+//		Build()
+type ContentBuilder struct {
+	contents []Content
+	err      error
+}
+
+// NewContentBuilder creates an empty content builder
+func NewContentBuilder() *ContentBuilder {
+	return &ContentBuilder{}
+}
+
+// Text adds a text content item
+func (b *ContentBuilder) Text(s string) *ContentBuilder {
+	b.contents = append(b.contents, Text(s))
+	return b
+}
+
+// Textf adds a formatted text content item
+func (b *ContentBuilder) Textf(format string, args ...interface{}) *ContentBuilder {
+	b.contents = append(b.contents, Textf(format, args...))
+	return b
+}
+
+// JSON adds v marshaled as indented JSON text
+func (b *ContentBuilder) JSON(v interface{}) *ContentBuilder {
+	contents, err := JSON(v)
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	return b.Append(contents...)
+}
+
+// Image adds an image content item
+func (b *ContentBuilder) Image(data []byte, mimeType string) *ContentBuilder {
+	b.contents = append(b.contents, Image(data, mimeType))
+	return b
+}
+
+// Resource adds an embedded textual resource
+func (b *ContentBuilder) Resource(uri, mimeType, text string) *ContentBuilder {
+	b.contents = append(b.contents, ResourceContent{URI: uri, MIMEType: mimeType, Text: text})
+	return b
+}
+
+// ResourceBlob adds an embedded binary resource
+func (b *ContentBuilder) ResourceBlob(uri, mimeType string, blob []byte) *ContentBuilder {
+	b.contents = append(b.contents, ResourceContent{URI: uri, MIMEType: mimeType, Blob: blob})
+	return b
+}
+
+// Append adds existing text content, e.g. results of sub-operations
+func (b *ContentBuilder) Append(contents ...TextContent) *ContentBuilder {
+	for _, content := range contents {
+		b.contents = append(b.contents, content)
+	}
+	return b
+}
+
+// AppendContent adds existing rich content
+func (b *ContentBuilder) AppendContent(contents ...Content) *ContentBuilder {
+	b.contents = append(b.contents, contents...)
+	return b
+}
+
+// Len returns the number of content items added so far
+func (b *ContentBuilder) Len() int {
+	return len(b.contents)
+}
+
+// Build returns the accumulated content, or the first error encountered
+func (b *ContentBuilder) Build() ([]Content, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.contents, nil
+}
+
+// BuildText is Build for text-only results: images and binary resources are
+// replaced by a short description (see ContentToText)
+func (b *ContentBuilder) BuildText() ([]TextContent, error) {
+	contents, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return ContentToText(contents), nil
+}
+
+// Content types of rich tool results besides ContentTypeText
+const (
+	ContentTypeImage    = "image"
+	ContentTypeResource = "resource"
+)
+
+// Content is one item of a rich tool result (see framework.RichToolHandler):
+// a TextContent, ImageContent or ResourceContent.
+type Content interface {
+	// ContentType returns the MCP content type, e.g. "text" or "image"
+	ContentType() string
+}
+
+// ContentType returns ContentTypeText
+func (TextContent) ContentType() string { return ContentTypeText }
+
+// ImageContent is an image in a tool result.
+// Data holds the raw image bytes; they are base64-encoded on the wire.
+type ImageContent struct {
+	Data     []byte `json:"data"`
+	MIMEType string `json:"mimeType"` // e.g. "image/png"
+}
+
+// ContentType returns ContentTypeImage
+func (ImageContent) ContentType() string { return ContentTypeImage }
+
+// ResourceContent embeds a resource in a tool result, e.g. a generated file.
+// Set Text for textual resources or Blob for binary ones; Blob holds raw
+// bytes and is base64-encoded on the wire.
+type ResourceContent struct {
+	URI      string `json:"uri"`
+	MIMEType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     []byte `json:"blob,omitempty"`
+}
+
+// ContentType returns ContentTypeResource
+func (ResourceContent) ContentType() string { return ContentTypeResource }
+
+// Image returns an image content item
+func Image(data []byte, mimeType string) ImageContent {
+	return ImageContent{Data: data, MIMEType: mimeType}
+}
+
+// TextToContent converts text content to rich content, e.g. to combine the
+// result of a text-only handler with images
+func TextToContent(contents []TextContent) []Content {
+	if contents == nil {
+		return nil
+	}
+	rich := make([]Content, len(contents))
+	for i, content := range contents {
+		rich[i] = content
+	}
+	return rich
+}
+
+// ContentToText renders rich content as text for outputs that can only show
+// text, such as the CLI. Text (including textual resources) is kept; images
+// and binary resources are replaced by a short description.
+func ContentToText(contents []Content) []TextContent {
+	if contents == nil {
+		return nil
+	}
+	texts := make([]TextContent, 0, len(contents))
+	for _, content := range contents {
+		switch c := content.(type) {
+		case TextContent:
+			texts = append(texts, c)
+		case *TextContent:
+			texts = append(texts, *c)
+		case ImageContent:
+			texts = append(texts, Textf("[image %s, %d bytes]", c.MIMEType, len(c.Data)))
+		case *ImageContent:
+			texts = append(texts, Textf("[image %s, %d bytes]", c.MIMEType, len(c.Data)))
+		case ResourceContent:
+			texts = append(texts, resourceText(c))
+		case *ResourceContent:
+			texts = append(texts, resourceText(*c))
+		default:
+			texts = append(texts, Textf("[%s content]", content.ContentType()))
+		}
+	}
+	return texts
+}
+
+// resourceText renders an embedded resource for ContentToText
+func resourceText(r ResourceContent) TextContent {
+	if r.Blob == nil {
+		return Text(r.Text)
+	}
+	return Textf("[resource %s (%s), %d bytes]", r.URI, r.MIMEType, len(r.Blob))
+}
@@ -0,0 +1,219 @@
+package types
+
+import "testing"
+
+func TestText(t *testing.T) {
+	got := Text("hello")
+	if got.Type != "text" || got.Text != "hello" {
+		t.Errorf("Text() = %+v, want {Type:text Text:hello}", got)
+	}
+}
+
+func TestTextf(t *testing.T) {
+	got := Textf("%d files in %s", 3, "src")
+	if got.Type != "text" || got.Text != "3 files in src" {
+		t.Errorf("Textf() = %+v, want {Type:text Text:3 files in src}", got)
+	}
+}
+
+func TestTextList(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+	}{
+		{name: "none", texts: nil},
+		{name: "single", texts: []string{"a"}},
+		{name: "multiple", texts: []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TextList(tt.texts...)
+			if len(got) != len(tt.texts) {
+				t.Fatalf("len(TextList()) = %d, want %d", len(got), len(tt.texts))
+			}
+			for i, content := range got {
+				if content.Type != "text" || content.Text != tt.texts[i] {
+					t.Errorf("TextList()[%d] = %+v, want text %q", i, content, tt.texts[i])
+				}
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type result struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	tests := []struct {
+		name    string
+		input   interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "struct",
+			input: result{Name: "files", Count: 2},
+			want:  "{\n  \"name\": \"files\",\n  \"count\": 2\n}",
+		},
+		{
+			name:  "map",
+			input: map[string]interface{}{"b": true, "a": []int{1}},
+			want:  "{\n  \"a\": [\n    1\n  ],\n  \"b\": true\n}",
+		},
+		{
+			name:    "non-serializable",
+			input:   map[string]interface{}{"fn": func() {}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSON(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("JSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != 1 || got[0].Type != "text" || got[0].Text != tt.want {
+				t.Errorf("JSON() = %+v, want single text content %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeContent(t *testing.T) {
+	merged := MergeContent(
+		TextList("a", "b"),
+		nil,
+		[]TextContent{},
+		TextList("c"),
+	)
+	if len(merged) != 3 {
+		t.Fatalf("len(MergeContent()) = %d, want 3", len(merged))
+	}
+	for i, want := range []string{"a", "b", "c"} {
+		if merged[i].Text != want {
+			t.Errorf("MergeContent()[%d].Text = %q, want %q", i, merged[i].Text, want)
+		}
+	}
+
+	if got := MergeContent(nil, []TextContent{}); got != nil {
+		t.Errorf("MergeContent(empty) = %v, want nil", got)
+	}
+}
+
+func TestContentBuilder(t *testing.T) {
+	contents, err := NewContentBuilder().
+		Text("header").
+		Textf("%d items", 2).
+		JSON(map[string]int{"n": 2}).
+		Append(TextList("x", "y")...).
+		BuildText()
+	if err != nil {
+		t.Fatalf("BuildText() error = %v", err)
+	}
+
+	want := []string{"header", "2 items", "{\n  \"n\": 2\n}", "x", "y"}
+	if len(contents) != len(want) {
+		t.Fatalf("len(BuildText()) = %d, want %d", len(contents), len(want))
+	}
+	for i, w := range want {
+		if contents[i].Type != "text" || contents[i].Text != w {
+			t.Errorf("BuildText()[%d] = %+v, want text %q", i, contents[i], w)
+		}
+	}
+}
+
+func TestContentBuilder_MixedContent(t *testing.T) {
+	png := []byte{0x89, 'P', 'N', 'G'}
+	builder := NewContentBuilder().
+		Text("caption").
+		Image(png, "image/png").
+		Resource("file:///notes.txt", "text/plain", "notes").
+		ResourceBlob("file:///data.bin", "application/octet-stream", []byte{1, 2}).
+		AppendContent(Image([]byte{1}, "image/gif"))
+	contents, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	wantTypes := []string{ContentTypeText, ContentTypeImage, ContentTypeResource, ContentTypeResource, ContentTypeImage}
+	if len(contents) != len(wantTypes) || builder.Len() != len(wantTypes) {
+		t.Fatalf("Build() returned %d items (Len %d), want %d", len(contents), builder.Len(), len(wantTypes))
+	}
+	for i, want := range wantTypes {
+		if got := contents[i].ContentType(); got != want {
+			t.Errorf("Build()[%d] type = %q, want %q", i, got, want)
+		}
+	}
+	if img := contents[1].(ImageContent); string(img.Data) != string(png) || img.MIMEType != "image/png" {
+		t.Errorf("image = %+v", img)
+	}
+	if res := contents[2].(ResourceContent); res.URI != "file:///notes.txt" || res.Text != "notes" {
+		t.Errorf("resource = %+v", res)
+	}
+	if blob := contents[3].(ResourceContent); len(blob.Blob) != 2 || blob.Text != "" {
+		t.Errorf("blob resource = %+v", blob)
+	}
+
+	texts, err := builder.BuildText()
+	if err != nil {
+		t.Fatalf("BuildText() error = %v", err)
+	}
+	if texts[1].Text != "[image image/png, 4 bytes]" || texts[2].Text != "notes" {
+		t.Errorf("BuildText() = %+v", texts)
+	}
+}
+
+func TestContentBuilder_JSONError(t *testing.T) {
+	builder := NewContentBuilder().Text("ok").JSON(func() {}).Text("after")
+	if _, err := builder.Build(); err == nil {
+		t.Error("Build() error = nil, want marshal error")
+	}
+	if _, err := builder.BuildText(); err == nil {
+		t.Error("BuildText() error = nil, want marshal error")
+	}
+}
+
+func TestContentToText(t *testing.T) {
+	contents := []Content{
+		Text("caption"),
+		Image([]byte{1, 2, 3}, "image/png"),
+		&ResourceContent{URI: "file:///notes.txt", MIMEType: "text/plain", Text: "notes"},
+		ResourceContent{URI: "file:///data.bin", MIMEType: "application/octet-stream", Blob: make([]byte, 10)},
+	}
+	want := []string{
+		"caption",
+		"[image image/png, 3 bytes]",
+		"notes",
+		"[resource file:///data.bin (application/octet-stream), 10 bytes]",
+	}
+
+	got := ContentToText(contents)
+	if len(got) != len(want) {
+		t.Fatalf("ContentToText() returned %d items, want %d", len(got), len(want))
+	}
+	for i, content := range got {
+		if content.Type != ContentTypeText || content.Text != want[i] {
+			t.Errorf("ContentToText()[%d] = %+v, want text %q", i, content, want[i])
+		}
+	}
+	if ContentToText(nil) != nil {
+		t.Error("ContentToText(nil) should be nil")
+	}
+}
+
+func TestTextToContent(t *testing.T) {
+	got := TextToContent(TextList("a", "b"))
+	if len(got) != 2 || got[1].ContentType() != ContentTypeText || got[1].(TextContent).Text != "b" {
+		t.Errorf("TextToContent() = %+v", got)
+	}
+	if TextToContent(nil) != nil {
+		t.Error("TextToContent(nil) should be nil")
+	}
+}
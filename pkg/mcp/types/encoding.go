@@ -0,0 +1,80 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Encoding identifies the wire format of a request payload
+type Encoding int
+
+const (
+	// EncodingUnknown means the payload is empty or matches neither format
+	EncodingUnknown Encoding = iota
+	// EncodingJSON means the payload is valid JSON
+	EncodingJSON
+	// EncodingProtobuf means the payload is well-formed protobuf binary
+	EncodingProtobuf
+)
+
+// String returns the encoding name
+func (e Encoding) String() string {
+	switch e {
+	case EncodingJSON:
+		return "json"
+	case EncodingProtobuf:
+		return "protobuf"
+	default:
+		return "unknown"
+	}
+}
+
+// DetectEncoding reports whether data is JSON or protobuf binary.
+//
+// JSON is checked first: data whose first non-space byte is '{', '[' or '"'
+// and that is valid JSON is reported as EncodingJSON. Otherwise data that
+// parses as a complete sequence of protobuf fields is EncodingProtobuf.
+// Empty input, and input valid as neither, is EncodingUnknown.
+//
+// Protobuf has no magic bytes, so the protobuf check only establishes that
+// the bytes are well-formed on the wire, not that they match a message type.
+func DetectEncoding(data []byte) Encoding {
+	if len(data) == 0 {
+		return EncodingUnknown
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 {
+		switch trimmed[0] {
+		case '{', '[', '"':
+			if json.Valid(trimmed) {
+				return EncodingJSON
+			}
+		}
+	}
+
+	if isProtobufWire(data) {
+		return EncodingProtobuf
+	}
+	return EncodingUnknown
+}
+
+// isProtobufWire reports whether data is a complete sequence of valid
+// protobuf fields
+func isProtobufWire(data []byte) bool {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeField(data)
+		if n < 0 || num < protowire.MinValidNumber {
+			return false
+		}
+		// Reject the deprecated group wire types; they never appear in
+		// proto3 messages and make random text look like protobuf
+		if typ == protowire.StartGroupType || typ == protowire.EndGroupType {
+			return false
+		}
+		data = data[n:]
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestDetectEncoding(t *testing.T) {
+	protoBytes, err := proto.Marshal(structpb.NewStringValue("hello"))
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want Encoding
+	}{
+		{name: "json object", data: []byte(`{"action":"run"}`), want: EncodingJSON},
+		{name: "json with whitespace", data: []byte("  \n[1, 2]"), want: EncodingJSON},
+		{name: "protobuf", data: protoBytes, want: EncodingProtobuf},
+		{name: "protobuf varint field", data: []byte{0x08, 0x96, 0x01}, want: EncodingProtobuf},
+		{name: "empty", data: nil, want: EncodingUnknown},
+		{name: "truncated protobuf", data: []byte{0x0a, 0x05, 'h', 'i'}, want: EncodingUnknown},
+		{name: "malformed json", data: []byte(`{"action":`), want: EncodingUnknown},
+		{name: "plain text", data: []byte("hello world"), want: EncodingUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectEncoding(tt.data); got != tt.want {
+				t.Errorf("DetectEncoding(%q) = %v, want %v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
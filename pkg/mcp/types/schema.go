@@ -0,0 +1,81 @@
+package types
+
+// PropertySchema describes a single tool parameter in a ToolSchema.
+// It can be used as a ToolSchema.Properties value in place of a raw map;
+// adapters convert it with Map, preserving Default and Examples for client UIs.
+//
+// Example:
+//
+//	schema := types.ToolSchema{
+//		Type: "object",
+//		Properties: map[string]interface{}{
+//			"limit": types.IntegerProperty("Max results").WithDefault(10).WithExamples(5, 50),
+//		},
+//	}
+type PropertySchema struct {
+	Type        string        `json:"type,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Examples    []interface{} `json:"examples,omitempty"`
+}
+
+// StringProperty returns a string parameter schema
+func StringProperty(description string) PropertySchema {
+	return PropertySchema{Type: "string", Description: description}
+}
+
+// NumberProperty returns a number parameter schema
+func NumberProperty(description string) PropertySchema {
+	return PropertySchema{Type: "number", Description: description}
+}
+
+// IntegerProperty returns an integer parameter schema
+func IntegerProperty(description string) PropertySchema {
+	return PropertySchema{Type: "integer", Description: description}
+}
+
+// BooleanProperty returns a boolean parameter schema
+func BooleanProperty(description string) PropertySchema {
+	return PropertySchema{Type: "boolean", Description: description}
+}
+
+// WithDefault returns a copy of the schema with a default value
+func (p PropertySchema) WithDefault(value interface{}) PropertySchema {
+	p.Default = value
+	return p
+}
+
+// WithExamples returns a copy of the schema with example values
+func (p PropertySchema) WithExamples(examples ...interface{}) PropertySchema {
+	p.Examples = examples
+	return p
+}
+
+// WithEnum returns a copy of the schema restricted to the given values
+func (p PropertySchema) WithEnum(values ...interface{}) PropertySchema {
+	p.Enum = values
+	return p
+}
+
+// Map returns the schema as a JSON Schema map. Unset fields are omitted;
+// a Default of false or 0 is kept, since only nil means "no default".
+func (p PropertySchema) Map() map[string]interface{} {
+	m := make(map[string]interface{}, 5)
+	if p.Type != "" {
+		m["type"] = p.Type
+	}
+	if p.Description != "" {
+		m["description"] = p.Description
+	}
+	if len(p.Enum) > 0 {
+		m["enum"] = p.Enum
+	}
+	if p.Default != nil {
+		m["default"] = p.Default
+	}
+	if len(p.Examples) > 0 {
+		m["examples"] = p.Examples
+	}
+	return m
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPropertySchema_Map(t *testing.T) {
+	tests := []struct {
+		name string
+		prop PropertySchema
+		want map[string]interface{}
+	}{
+		{
+			name: "default and examples",
+			prop: IntegerProperty("Max results").WithDefault(10).WithExamples(5, 50),
+			want: map[string]interface{}{
+				"type":        "integer",
+				"description": "Max results",
+				"default":     10,
+				"examples":    []interface{}{5, 50},
+			},
+		},
+		{
+			name: "falsy default kept",
+			prop: BooleanProperty("Verbose").WithDefault(false),
+			want: map[string]interface{}{
+				"type":        "boolean",
+				"description": "Verbose",
+				"default":     false,
+			},
+		},
+		{
+			name: "enum",
+			prop: StringProperty("Mode").WithEnum("fast", "safe"),
+			want: map[string]interface{}{
+				"type":        "string",
+				"description": "Mode",
+				"enum":        []interface{}{"fast", "safe"},
+			},
+		},
+		{
+			name: "unset fields omitted",
+			prop: NumberProperty(""),
+			want: map[string]interface{}{"type": "number"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.prop.Map(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Map() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}